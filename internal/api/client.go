@@ -5,28 +5,123 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/timimsms/cu/internal/auth"
+	"github.com/timimsms/cu/internal/clock"
+	"github.com/timimsms/cu/internal/config"
 	"github.com/timimsms/cu/internal/errors"
 )
 
+// DefaultRateLimit is the number of requests per minute allowed on the
+// ClickUp free tier
+const DefaultRateLimit = 100
+
 // Client wraps the ClickUp API client
 type Client struct {
 	client      *clickup.Client
 	rateLimiter *RateLimiter
 	userLookup  *UserLookup
+
+	usersMu     sync.Mutex
+	usersLoaded bool
+
+	cacheScope string
 }
 
 // NewClient creates a new API client
 func NewClient() (*Client, error) {
-	authMgr := auth.NewManager()
-	token, err := authMgr.GetCurrentToken()
+	token, err := currentToken(auth.NewManager())
 	if err != nil {
 		return nil, errors.ErrNotAuthenticated
 	}
 
+	if token.IsExpired() {
+		return nil, errors.NewUserError(
+			fmt.Sprintf("Authentication token expired on %s", token.ExpiresAt.Local().Format("2006-01-02 15:04")),
+			"Run 'cu auth refresh' to re-validate it, or 'cu auth login' to use a new token",
+			errors.ErrTokenExpired,
+		)
+	}
+
+	return NewClientWithToken(token), nil
+}
+
+// currentToken returns the token in CU_TOKEN or else the token of the
+// workspace set as default_workspace, falling back to the default
+// workspace's token when that workspace has none
+func currentToken(authMgr *auth.Manager) (*auth.Token, error) {
+	token, _, err := authMgr.ResolveToken(config.GetString("default_workspace"))
+	return token, err
+}
+
+// loadUsers loads the members of the first workspace into the user lookup
+// so the given assignees can be resolved by username. Members are fetched at
+// most once per client, which keeps bulk operations from reloading them for
+// every task, and not at all when every assignee is already a numeric ID.
+func (c *Client) loadUsers(ctx context.Context, assignees ...[]string) {
+	if !needsUserLookup(assignees...) {
+		return
+	}
+
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+	if c.usersLoaded {
+		return
+	}
+
+	workspaces, err := c.GetWorkspaces(ctx)
+	if err != nil || len(workspaces) == 0 {
+		return
+	}
+	if err := c.userLookup.LoadWorkspaceUsers(ctx, workspaces[0].ID); err == nil {
+		c.usersLoaded = true
+	}
+}
+
+// needsUserLookup reports whether any assignee is a username rather than an ID
+func needsUserLookup(assignees ...[]string) bool {
+	for _, names := range assignees {
+		for _, name := range names {
+			if _, err := strconv.Atoi(name); err != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveAssignees converts assignee usernames to user IDs, loading the
+// workspace members once. The IDs are returned as strings so they can be
+// passed straight into TaskOptions or TaskUpdateOptions for many tasks
+// without any further lookups.
+func (c *Client) ResolveAssignees(ctx context.Context, assignees []string) ([]string, error) {
+	if len(assignees) == 0 {
+		return nil, nil
+	}
+
+	c.loadUsers(ctx, assignees)
+	ids, err := c.userLookup.ConvertUsernamesToIDs(assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]string, 0, len(ids))
+	for _, id := range ids {
+		resolved = append(resolved, strconv.Itoa(id))
+	}
+	return resolved, nil
+}
+
+// NewClientWithToken creates a new API client for the given token without
+// checking its expiry. Use NewClient for normal commands.
+func NewClientWithToken(token *auth.Token) *Client {
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second,
 		Transport: &retryTransport{
@@ -38,11 +133,33 @@ func NewClient() (*Client, error) {
 
 	c := &Client{
 		client:      client,
-		rateLimiter: NewRateLimiter(100, time.Minute), // 100 requests per minute for free tier
+		rateLimiter: NewRateLimiter(configuredRateLimit(), time.Minute),
+		cacheScope:  token.CacheScope(),
 	}
 	c.userLookup = NewUserLookup(c)
 
-	return c, nil
+	return c
+}
+
+// CacheScope identifies the client's account in cache keys, so cached data
+// is never served to another account
+func (c *Client) CacheScope() string {
+	return c.cacheScope
+}
+
+// configuredRateLimit returns the requests per minute set with --rate-limit or
+// the rate_limit config key, falling back to DefaultRateLimit. Paid plans allow
+// higher rates; the configured value is the most cu will ever send.
+func configuredRateLimit() int {
+	if limit := config.GetInt("rate_limit"); limit > 0 {
+		return limit
+	}
+	return DefaultRateLimit
+}
+
+// RateLimit returns the requests per minute the client's rate limiter allows
+func (c *Client) RateLimit() int {
+	return c.rateLimiter.Limit()
 }
 
 // UserLookup returns the user lookup service
@@ -78,6 +195,34 @@ func (c *Client) GetSpaces(ctx context.Context, workspaceID string) ([]clickup.S
 	return spaces, nil
 }
 
+// GetSpace returns a single space by ID
+func (c *Client) GetSpace(ctx context.Context, spaceID string) (*clickup.Space, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	space, _, err := c.client.Spaces.GetSpace(ctx, spaceID)
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+
+	return space, nil
+}
+
+// GetSpaceTags returns the tags defined in a space
+func (c *Client) GetSpaceTags(ctx context.Context, spaceID string) ([]clickup.Tag, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	tags, _, err := c.client.Tags.GetTags(ctx, spaceID)
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+
+	return tags, nil
+}
+
 // GetFolders returns all folders in a space
 func (c *Client) GetFolders(ctx context.Context, spaceID string) ([]clickup.Folder, error) {
 	if err := c.rateLimiter.Wait(ctx); err != nil {
@@ -106,6 +251,20 @@ func (c *Client) GetLists(ctx context.Context, folderID string) ([]clickup.List,
 	return lists, nil
 }
 
+// GetList returns a single list by ID
+func (c *Client) GetList(ctx context.Context, listID string) (*clickup.List, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	list, _, err := c.client.Lists.GetList(ctx, listID)
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+
+	return &list, nil
+}
+
 // GetFolderlessLists returns lists directly in a space (not in folders)
 func (c *Client) GetFolderlessLists(ctx context.Context, spaceID string) ([]clickup.List, error) {
 	if err := c.rateLimiter.Wait(ctx); err != nil {
@@ -153,15 +312,80 @@ func (c *Client) GetTasks(ctx context.Context, listID string, options *TaskQuery
 	if options.Tags != nil {
 		opts.Tags = options.Tags
 	}
+	opts.IncludeClosed = options.IncludeClosed
+	opts.Subtasks = options.Subtasks
+	opts.Archived = options.Archived
+	opts.OrderBy = options.OrderBy
+	opts.Reverse = options.Reverse
 
 	tasks, _, err := c.client.Tasks.GetTasks(ctx, listID, opts)
 	if err != nil {
 		return nil, c.handleError(err)
 	}
 
+	if !options.IncludeCustomFields {
+		for i := range tasks {
+			tasks[i].CustomFields = nil
+		}
+	}
+
 	return tasks, nil
 }
 
+// TasksPageSize is the number of tasks ClickUp returns per page
+const TasksPageSize = 100
+
+// GetAllTasks returns every task in a list matching the options, fetching
+// pages from options.Page until ClickUp returns a short page or a page
+// reaches options.UpdatedSince
+func (c *Client) GetAllTasks(ctx context.Context, listID string, options *TaskQueryOptions) ([]clickup.Task, error) {
+	return GetAllTaskPages(ctx, c, listID, options)
+}
+
+// TaskPageGetter gets one page of tasks from a list
+type TaskPageGetter interface {
+	GetTasks(ctx context.Context, listID string, options *TaskQueryOptions) ([]clickup.Task, error)
+}
+
+// GetAllTaskPages is GetAllTasks for any page getter, such as a cache in
+// front of the client
+func GetAllTaskPages(ctx context.Context, getter TaskPageGetter, listID string, options *TaskQueryOptions) ([]clickup.Task, error) {
+	pageOpts := *options
+
+	var all []clickup.Task
+	for {
+		tasks, err := getter.GetTasks(ctx, listID, &pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tasks...)
+
+		if len(tasks) < TasksPageSize {
+			return all, nil
+		}
+		if !options.UpdatedSince.IsZero() && !TaskUpdatedAt(tasks[len(tasks)-1]).After(options.UpdatedSince) {
+			return all, nil
+		}
+		pageOpts.Page++
+	}
+}
+
+// TaskUpdatedAt returns when a task was last updated, or the zero time if
+// ClickUp did not say
+func TaskUpdatedAt(task clickup.Task) time.Time {
+	return parseTaskTime(task.DateUpdated)
+}
+
+// parseTaskTime parses one of ClickUp's millisecond task timestamps, or
+// returns the zero time if it is missing or malformed
+func parseTaskTime(ms string) time.Time {
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(n)
+}
+
 // DeleteTask deletes a task
 func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
 	if err := c.rateLimiter.Wait(ctx); err != nil {
@@ -176,6 +400,171 @@ func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
 	return nil
 }
 
+// Dependency types accepted by AddDependency and RemoveDependency
+const (
+	// DependencyWaitingOn means the task is waiting on the other task
+	DependencyWaitingOn = "waiting-on"
+	// DependencyBlocks means the task is blocking the other task
+	DependencyBlocks = "blocks"
+)
+
+// dependencyRequest maps a dependency type onto ClickUp's depends_on and
+// dependency_of fields
+func dependencyRequest(otherID, depType string) (*clickup.AddDependencyRequest, error) {
+	switch depType {
+	case DependencyWaitingOn:
+		return &clickup.AddDependencyRequest{DependsOn: otherID}, nil
+	case DependencyBlocks:
+		return &clickup.AddDependencyRequest{DependencyOf: otherID}, nil
+	default:
+		return nil, errors.NewUserError(
+			fmt.Sprintf("invalid dependency type '%s'", depType),
+			fmt.Sprintf("Use '%s' or '%s'", DependencyBlocks, DependencyWaitingOn),
+			errors.ErrInvalidInput,
+		)
+	}
+}
+
+// AddDependency records a dependency between taskID and otherID
+func (c *Client) AddDependency(ctx context.Context, taskID, otherID, depType string) error {
+	request, err := dependencyRequest(otherID, depType)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	_, err = c.client.Dependencies.AddDependency(ctx, taskID, request, nil)
+	if err != nil {
+		return c.handleError(err)
+	}
+
+	return nil
+}
+
+// RemoveDependency deletes a dependency between taskID and otherID
+func (c *Client) RemoveDependency(ctx context.Context, taskID, otherID, depType string) error {
+	request, err := dependencyRequest(otherID, depType)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	opts := &clickup.DeleteDependencyOptions{
+		DependsOn:    request.DependsOn,
+		DependencyOf: request.DependencyOf,
+	}
+	_, err = c.client.Dependencies.DeleteDependency(ctx, taskID, opts)
+	if err != nil {
+		return c.handleError(err)
+	}
+
+	return nil
+}
+
+// CreateChecklist adds a named checklist to a task
+func (c *Client) CreateChecklist(ctx context.Context, taskID, name string) (*clickup.Checklist, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	checklist, _, err := c.client.Checklists.CreateChecklist(ctx, taskID, nil, &clickup.ChecklistRequest{Name: name})
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+
+	return checklist, nil
+}
+
+// CreateChecklistItem adds an item to a checklist and returns the updated checklist
+func (c *Client) CreateChecklistItem(ctx context.Context, checklistID, name string) (*clickup.Checklist, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	checklist, _, err := c.client.Checklists.CreateChecklistItem(ctx, checklistID, &clickup.ChecklistItemRequest{Name: name})
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+
+	return checklist, nil
+}
+
+// TaskActivity is one change in a task's history
+type TaskActivity struct {
+	Field string    `json:"field"`
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+	Date  time.Time `json:"date"`
+	// Minutes is how long the task kept the new value
+	Minutes int `json:"minutes"`
+}
+
+// GetTaskActivity returns a task's status changes, newest first. ClickUp's
+// public API only exposes the status history of a task, not who made each
+// change or changes to other fields.
+func (c *Client) GetTaskActivity(ctx context.Context, taskID string) ([]TaskActivity, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	inStatus, _, err := c.client.Tasks.GetTasksTimeInStatus(ctx, taskID, &clickup.GetTaskOptions{})
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+
+	history := inStatus.StatusHistory
+	current := inStatus.CurrentStatus
+	if current.Status != "" && !hasStatusEntry(history, current.Status, current.TotalTime.Since) {
+		history = append(history, clickup.TaskStatusHistory{Status: current.Status, TotalTime: current.TotalTime})
+	}
+
+	entries := make([]TaskActivity, 0, len(history))
+	for _, h := range history {
+		entries = append(entries, TaskActivity{
+			Field:   "status",
+			To:      h.Status,
+			Date:    parseMillis(h.TotalTime.Since),
+			Minutes: h.TotalTime.ByMinute,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.Before(entries[j].Date)
+	})
+	for i := 1; i < len(entries); i++ {
+		entries[i].From = entries[i-1].To
+	}
+	slices.Reverse(entries)
+
+	return entries, nil
+}
+
+// hasStatusEntry reports whether history has an entry for status since the
+// given time
+func hasStatusEntry(history []clickup.TaskStatusHistory, status, since string) bool {
+	for _, h := range history {
+		if h.Status == status && h.TotalTime.Since == since {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMillis parses a ClickUp Unix millisecond timestamp. Invalid values
+// give the zero time.
+func parseMillis(value string) time.Time {
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
 // GetCurrentUser returns the authenticated user
 func (c *Client) GetCurrentUser(ctx context.Context) (*clickup.User, error) {
 	if err := c.rateLimiter.Wait(ctx); err != nil {
@@ -190,6 +579,26 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*clickup.User, error) {
 	return user, nil
 }
 
+// GetCurrentUserRate returns the current user along with the rate limit
+// state ClickUp reported for the request. The rate is nil when the response
+// had no rate limit headers. When ClickUp is throttling the token, the error
+// is a *clickup.RateLimitError carrying the rate.
+func (c *Client) GetCurrentUserRate(ctx context.Context) (*clickup.User, *clickup.Rate, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	user, resp, err := c.client.Authorization.GetAuthorizedUser(ctx)
+	if err != nil {
+		return nil, nil, c.handleError(err)
+	}
+
+	if resp == nil || resp.Rate.Limit == 0 {
+		return user, nil, nil
+	}
+	return user, &resp.Rate, nil
+}
+
 // GetWorkspaceMembers returns all members of a workspace
 func (c *Client) GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]clickup.TeamUser, error) {
 	if err := c.rateLimiter.Wait(ctx); err != nil {
@@ -238,12 +647,29 @@ func (c *Client) handleError(err error) error {
 
 // TaskQueryOptions represents options for querying tasks
 type TaskQueryOptions struct {
-	Page      int
-	Assignees []string
-	Statuses  []string
-	Tags      []string
-	Priority  *int
-	DueDate   *time.Time
+	Page          int
+	Assignees     []string
+	Statuses      []string
+	Tags          []string
+	Priority      *int
+	DueDate       *time.Time
+	IncludeClosed bool
+	Subtasks      bool
+	// Archived includes archived tasks in the results
+	Archived bool
+	// IncludeCustomFields keeps custom field values on the returned tasks.
+	// ClickUp always sends them; they are dropped otherwise to keep results
+	// small.
+	IncludeCustomFields bool
+	// OrderBy sorts tasks server-side by id, created, updated or due_date.
+	// ClickUp returns them newest first unless Reverse is set.
+	OrderBy string
+	Reverse bool
+	// UpdatedSince stops GetAllTasks at the first page that reaches tasks
+	// updated at or before it, so OrderBy must be updated. Tasks are not
+	// filtered and nothing is sent to ClickUp, so it is left out of cache
+	// keys.
+	UpdatedSince time.Time `json:"-"`
 }
 
 // TaskCreateOptions represents options for creating a task
@@ -255,6 +681,8 @@ type TaskCreateOptions struct {
 	Priority    string
 	Tags        []string
 	DueDate     string
+	// NotifyAll sets ClickUp's notify_all field; nil keeps ClickUp's default
+	NotifyAll *bool
 }
 
 // TaskUpdateOptions represents options for updating a task
@@ -267,6 +695,8 @@ type TaskUpdateOptions struct {
 	DueDate         string
 	AddAssignees    []string
 	RemoveAssignees []string
+	// NotifyAll sets ClickUp's notify_all field; nil keeps ClickUp's default
+	NotifyAll *bool
 }
 
 // HasUpdates checks if any updates are specified
@@ -290,11 +720,7 @@ func (c *Client) CreateTask(ctx context.Context, listID string, options *TaskCre
 
 	// Handle assignees
 	if len(options.Assignees) > 0 {
-		// First, ensure we have loaded users
-		workspaces, err := c.GetWorkspaces(ctx)
-		if err == nil && len(workspaces) > 0 {
-			_ = c.userLookup.LoadWorkspaceUsers(ctx, workspaces[0].ID)
-		}
+		c.loadUsers(ctx, options.Assignees)
 
 		// Convert usernames to IDs
 		ids, err := c.userLookup.ConvertUsernamesToIDs(options.Assignees)
@@ -313,44 +739,30 @@ func (c *Client) CreateTask(ctx context.Context, listID string, options *TaskCre
 
 	// Set priority if provided
 	if options.Priority != "" {
-		// Convert priority string to int based on ClickUp's scale
-		var priorityInt int
-		switch options.Priority {
-		case "urgent":
-			priorityInt = 1
-		case "high":
-			priorityInt = 2
-		case "normal":
-			priorityInt = 3
-		case "low":
-			priorityInt = 4
-		default:
-			priorityInt = 3 // Default to normal
-		}
-		request.Priority = priorityInt
+		request.Priority = PriorityValue(options.Priority)
 	}
 
 	// Set due date if provided
 	if options.DueDate != "" {
 		// Parse due date
-		t, err := parseDueDate(options.DueDate)
+		t, err := ParseDueDate(options.DueDate)
 		if err == nil {
 			request.DueDate = clickup.NewDate(t)
 		}
 		// If parsing fails, just skip setting the due date
 	}
 
-	task, _, err := c.client.Tasks.CreateTask(ctx, listID, request)
-	if err != nil {
-		return nil, c.handleError(err)
+	if options.NotifyAll != nil {
+		request.NotifyAll = *options.NotifyAll
 	}
 
-	return task, nil
+	return c.createTaskOnce(ctx, listID, request)
 }
 
-// parseDueDate parses various date formats including relative dates
-func parseDueDate(input string) (time.Time, error) {
-	now := time.Now()
+// ParseDueDate parses the due date formats accepted by CreateTask and
+// UpdateTask: today, tomorrow, week, RFC 3339 and YYYY-MM-DD
+func ParseDueDate(input string) (time.Time, error) {
+	now := clock.Now()
 
 	// Handle relative dates
 	switch input {
@@ -375,95 +787,135 @@ func parseDueDate(input string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unable to parse date: %s", input)
 }
 
-// UpdateTask updates an existing task with simplified options
-func (c *Client) UpdateTask(ctx context.Context, taskID string, options *TaskUpdateOptions) (*clickup.Task, error) {
-	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return nil, err
+// TaskUpdatePlan is the resolved form of TaskUpdateOptions: assignee names
+// converted to user IDs, the due date parsed and the priority mapped to
+// ClickUp's scale. It is what UpdateTask sends to the API.
+type TaskUpdatePlan struct {
+	Name            string     `json:"name,omitempty"`
+	Description     string     `json:"description,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	Priority        int        `json:"priority,omitempty"`
+	DueDate         *time.Time `json:"due_date,omitempty"`
+	Tags            []string   `json:"tags,omitempty"`
+	AddAssignees    []int      `json:"add_assignees,omitempty"`
+	RemoveAssignees []int      `json:"remove_assignees,omitempty"`
+	NotifyAll       bool       `json:"notify_all,omitempty"`
+}
+
+// request converts the plan to a ClickUp update request
+func (p *TaskUpdatePlan) request() *clickup.TaskUpdateRequest {
+	request := &clickup.TaskUpdateRequest{
+		Name:        p.Name,
+		Description: p.Description,
+		Status:      p.Status,
+		Priority:    p.Priority,
+		Tags:        p.Tags,
+		NotifyAll:   p.NotifyAll,
+		Assignees: clickup.TaskAssigneeUpdateRequest{
+			Add: p.AddAssignees,
+			Rem: p.RemoveAssignees,
+		},
 	}
+	if p.DueDate != nil {
+		request.DueDate = clickup.NewDate(*p.DueDate)
+	}
+	return request
+}
 
-	// Build the update request
-	request := &clickup.TaskUpdateRequest{}
+// PriorityValue converts a priority name to ClickUp's scale, where 1 is urgent
+// and 4 is low. Names are case-insensitive; unknown names map to normal.
+func PriorityValue(priority string) int {
+	if value, ok := ParsePriority(priority); ok {
+		return value
+	}
+	return 3 // Default to normal
+}
 
-	// Set simple string fields
-	if options.Name != "" {
-		request.Name = options.Name
+// ParsePriority converts a priority name to ClickUp's scale and reports
+// whether the name is known. Names are case-insensitive.
+func ParsePriority(priority string) (int, bool) {
+	switch strings.ToLower(priority) {
+	case "urgent":
+		return 1, true
+	case "high":
+		return 2, true
+	case "normal":
+		return 3, true
+	case "low":
+		return 4, true
+	default:
+		return 0, false
 	}
-	if options.Description != "" {
-		request.Description = options.Description
+}
+
+// PlanTaskUpdate resolves update options without modifying the task. Assignee
+// names are looked up in the first workspace; names that can't be resolved
+// are reported as warnings and skipped, as is an unparseable due date.
+func (c *Client) PlanTaskUpdate(ctx context.Context, options *TaskUpdateOptions) (*TaskUpdatePlan, error) {
+	plan := &TaskUpdatePlan{
+		Name:        options.Name,
+		Description: options.Description,
+		Status:      options.Status,
 	}
-	if options.Status != "" {
-		request.Status = options.Status
+
+	if options.NotifyAll != nil {
+		plan.NotifyAll = *options.NotifyAll
 	}
 
-	// Set priority if provided
 	if options.Priority != "" {
-		// Convert priority string to int based on ClickUp's scale
-		var priorityInt int
-		switch options.Priority {
-		case "urgent":
-			priorityInt = 1
-		case "high":
-			priorityInt = 2
-		case "normal":
-			priorityInt = 3
-		case "low":
-			priorityInt = 4
-		default:
-			priorityInt = 3 // Default to normal
-		}
-		request.Priority = priorityInt
+		plan.Priority = PriorityValue(options.Priority)
 	}
 
-	// Set tags - this replaces all tags
+	// Tags replace all existing tags
 	if len(options.Tags) > 0 {
-		request.Tags = options.Tags
+		plan.Tags = options.Tags
 	}
 
-	// Set due date if provided
 	if options.DueDate != "" {
-		t, err := parseDueDate(options.DueDate)
+		t, err := ParseDueDate(options.DueDate)
 		if err == nil {
-			request.DueDate = clickup.NewDate(t)
+			plan.DueDate = &t
 		}
 	}
 
 	// Handle assignees
 	if len(options.AddAssignees) > 0 || len(options.RemoveAssignees) > 0 {
-		// First, ensure we have loaded users
-		workspaces, err := c.GetWorkspaces(ctx)
-		if err == nil && len(workspaces) > 0 {
-			_ = c.userLookup.LoadWorkspaceUsers(ctx, workspaces[0].ID)
-		}
+		c.loadUsers(ctx, options.AddAssignees, options.RemoveAssignees)
 
-		assigneeUpdate := clickup.TaskAssigneeUpdateRequest{}
-
-		// Convert add assignees
 		if len(options.AddAssignees) > 0 {
 			addIDs, err := c.userLookup.ConvertUsernamesToIDs(options.AddAssignees)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 			} else {
-				assigneeUpdate.Add = addIDs
+				plan.AddAssignees = addIDs
 			}
 		}
 
-		// Convert remove assignees
 		if len(options.RemoveAssignees) > 0 {
 			remIDs, err := c.userLookup.ConvertUsernamesToIDs(options.RemoveAssignees)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 			} else {
-				assigneeUpdate.Rem = remIDs
+				plan.RemoveAssignees = remIDs
 			}
 		}
+	}
 
-		// Only set if we have something to update
-		if len(assigneeUpdate.Add) > 0 || len(assigneeUpdate.Rem) > 0 {
-			request.Assignees = assigneeUpdate
-		}
+	return plan, nil
+}
+
+// UpdateTask updates an existing task with simplified options
+func (c *Client) UpdateTask(ctx context.Context, taskID string, options *TaskUpdateOptions) (*clickup.Task, error) {
+	plan, err := c.PlanTaskUpdate(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
 	}
 
-	task, _, err := c.client.Tasks.UpdateTask(ctx, taskID, &clickup.GetTaskOptions{}, request)
+	task, _, err := c.client.Tasks.UpdateTask(ctx, taskID, &clickup.GetTaskOptions{}, plan.request())
 	if err != nil {
 		return nil, c.handleError(err)
 	}
@@ -471,6 +923,36 @@ func (c *Client) UpdateTask(ctx context.Context, taskID string, options *TaskUpd
 	return task, nil
 }
 
+// Custom field methods
+
+// GetCustomFields returns the custom fields available on a list
+func (c *Client) GetCustomFields(ctx context.Context, listID string) ([]clickup.CustomField, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	fields, _, err := c.client.CustomFields.GetAccessibleCustomFields(ctx, listID)
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+
+	return fields, nil
+}
+
+// SetCustomFieldValue sets a custom field on a task. value is sent as the
+// request body, e.g. {"value": "..."}.
+func (c *Client) SetCustomFieldValue(ctx context.Context, taskID string, fieldID string, value map[string]interface{}) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	if _, err := c.client.CustomFields.SetCustomFieldValue(ctx, taskID, fieldID, value, nil); err != nil {
+		return c.handleError(err)
+	}
+
+	return nil
+}
+
 // Comment-related methods
 
 // GetTaskComments retrieves all comments for a task
@@ -500,11 +982,7 @@ func (c *Client) CreateTaskComment(ctx context.Context, taskID string, text stri
 
 	// Handle assignee if specified
 	if assignee != "" {
-		// First, ensure we have loaded users
-		workspaces, err := c.GetWorkspaces(ctx)
-		if err == nil && len(workspaces) > 0 {
-			_ = c.userLookup.LoadWorkspaceUsers(ctx, workspaces[0].ID)
-		}
+		c.loadUsers(ctx, []string{assignee})
 
 		// Convert username to ID
 		userIDs, err := c.userLookup.ConvertUsernamesToIDs([]string{assignee})
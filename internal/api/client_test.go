@@ -1,8 +1,23 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/auth"
+	"github.com/timimsms/cu/internal/clock"
+	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
 )
 
 func TestRateLimiter(t *testing.T) {
@@ -45,3 +60,630 @@ func TestMinFunction(t *testing.T) {
 		}
 	}
 }
+
+func TestNewClient_TokenExpiry(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+	t.Setenv(auth.AuthStoreEnv, "file")
+
+	authMgr := auth.NewManager()
+
+	t.Run("not authenticated", func(t *testing.T) {
+		_, err := NewClient()
+		assert.ErrorIs(t, err, errors.ErrNotAuthenticated)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		require.NoError(t, authMgr.SaveToken("", &auth.Token{
+			Value:     "pk_test",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}))
+
+		client, err := NewClient()
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("token without expiry", func(t *testing.T) {
+		require.NoError(t, authMgr.SaveToken("", &auth.Token{Value: "pk_test"}))
+
+		_, err := NewClient()
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		require.NoError(t, authMgr.SaveToken("", &auth.Token{
+			Value:     "pk_test",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}))
+
+		_, err := NewClient()
+		assert.ErrorIs(t, err, errors.ErrTokenExpired)
+		assert.Contains(t, err.Error(), "cu auth refresh")
+	})
+}
+
+func TestCurrentToken(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+	t.Setenv(auth.AuthStoreEnv, "file")
+
+	oldWorkspace := config.Get("default_workspace")
+	defer config.Set("default_workspace", oldWorkspace)
+
+	authMgr := auth.NewManager()
+	require.NoError(t, authMgr.SaveToken("", &auth.Token{Value: "pk_default"}))
+	require.NoError(t, authMgr.SaveToken("work", &auth.Token{Value: "pk_work", Workspace: "work"}))
+
+	tests := []struct {
+		workspace string
+		expected  string
+	}{
+		{"", "pk_default"},
+		{"work", "pk_work"},
+		{"missing", "pk_default"},
+	}
+
+	for _, tt := range tests {
+		t.Run("workspace "+tt.workspace, func(t *testing.T) {
+			config.Set("default_workspace", tt.workspace)
+			token, err := currentToken(authMgr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, token.Value)
+		})
+	}
+}
+
+func TestNewClient_RateLimit(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+	t.Setenv(auth.AuthStoreEnv, "file")
+	require.NoError(t, auth.NewManager().SaveToken("", &auth.Token{Value: "pk_test"}))
+
+	oldLimit := config.Get("rate_limit")
+	defer config.Set("rate_limit", oldLimit)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected int
+	}{
+		{"unset uses default", nil, DefaultRateLimit},
+		{"zero uses default", 0, DefaultRateLimit},
+		{"configured value", 300, 300},
+		{"string value from config set", "250", 250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Set("rate_limit", tt.value)
+
+			client, err := NewClient()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, client.rateLimiter.maxTokens)
+			assert.Equal(t, time.Minute/time.Duration(tt.expected), client.rateLimiter.refillRate)
+		})
+	}
+}
+
+func TestPlanTaskUpdate(t *testing.T) {
+	client := NewClientWithToken(&auth.Token{Value: "pk_test"})
+
+	t.Run("resolves priority and due date", func(t *testing.T) {
+		plan, err := client.PlanTaskUpdate(context.Background(), &TaskUpdateOptions{
+			Name:     "Renamed",
+			Status:   "in progress",
+			Priority: "high",
+			DueDate:  "2026-03-15",
+			Tags:     []string{"backend"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Renamed", plan.Name)
+		assert.Equal(t, "in progress", plan.Status)
+		assert.Equal(t, 2, plan.Priority)
+		require.NotNil(t, plan.DueDate)
+		assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), *plan.DueDate)
+		assert.Equal(t, []string{"backend"}, plan.Tags)
+		assert.Empty(t, plan.AddAssignees)
+	})
+
+	t.Run("invalid due date is skipped", func(t *testing.T) {
+		plan, err := client.PlanTaskUpdate(context.Background(), &TaskUpdateOptions{DueDate: "someday"})
+		require.NoError(t, err)
+		assert.Nil(t, plan.DueDate)
+	})
+
+	t.Run("plan converts to request", func(t *testing.T) {
+		due := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+		plan := &TaskUpdatePlan{Priority: 1, DueDate: &due, AddAssignees: []int{123}}
+
+		request := plan.request()
+		assert.Equal(t, 1, request.Priority)
+		require.NotNil(t, request.DueDate)
+		assert.Equal(t, due, *request.DueDate.Time())
+		assert.Equal(t, []int{123}, request.Assignees.Add)
+	})
+}
+
+func TestParseDueDate(t *testing.T) {
+	// Thirty seconds before midnight, where "tomorrow" is a different day
+	// from now plus a few hours
+	now := time.Date(2026, 3, 14, 23, 59, 30, 0, time.Local)
+	defer clock.Set(clock.Fixed(now))()
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"today", time.Date(2026, 3, 14, 23, 59, 59, 0, time.Local)},
+		{"tomorrow", time.Date(2026, 3, 15, 23, 59, 30, 0, time.Local)},
+		{"week", time.Date(2026, 3, 21, 23, 59, 30, 0, time.Local)},
+		{"2026-04-01", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-04-01T09:30:00Z", time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDueDate(tt.input)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "got %s", got)
+		})
+	}
+
+	_, err := ParseDueDate("someday")
+	assert.EqualError(t, err, "unable to parse date: someday")
+}
+
+func TestPriorityValue(t *testing.T) {
+	tests := map[string]int{
+		"urgent":  1,
+		"high":    2,
+		"normal":  3,
+		"low":     4,
+		"High":    2,
+		"unknown": 3,
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, PriorityValue(name), name)
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	value, ok := ParsePriority("URGENT")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	_, ok = ParsePriority("someday")
+	assert.False(t, ok)
+}
+
+// newTestServerClient returns a client that sends requests to handler
+func newTestServerClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClientWithToken(&auth.Token{Value: "pk_test"})
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.client.BaseURL = baseURL
+	return client
+}
+
+func TestDependencies(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		query  url.Values
+		body   map[string]string
+	}
+
+	record := func(t *testing.T) (*Client, *request) {
+		var got request
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			got.method = r.Method
+			got.path = r.URL.Path
+			got.query = r.URL.Query()
+			if r.Body != nil {
+				_ = json.NewDecoder(r.Body).Decode(&got.body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("{}"))
+		})
+		return client, &got
+	}
+
+	ctx := context.Background()
+
+	t.Run("add waiting on", func(t *testing.T) {
+		client, got := record(t)
+
+		require.NoError(t, client.AddDependency(ctx, "abc", "def", DependencyWaitingOn))
+		assert.Equal(t, http.MethodPost, got.method)
+		assert.Equal(t, "/task/abc/dependency", got.path)
+		assert.Equal(t, map[string]string{"depends_on": "def"}, got.body)
+	})
+
+	t.Run("add blocks", func(t *testing.T) {
+		client, got := record(t)
+
+		require.NoError(t, client.AddDependency(ctx, "abc", "def", DependencyBlocks))
+		assert.Equal(t, map[string]string{"dependency_of": "def"}, got.body)
+	})
+
+	t.Run("remove waiting on", func(t *testing.T) {
+		client, got := record(t)
+
+		require.NoError(t, client.RemoveDependency(ctx, "abc", "def", DependencyWaitingOn))
+		assert.Equal(t, http.MethodDelete, got.method)
+		assert.Equal(t, "/task/abc/dependency", got.path)
+		assert.Equal(t, "def", got.query.Get("depends_on"))
+		assert.Empty(t, got.query.Get("dependency_of"))
+	})
+
+	t.Run("remove blocks", func(t *testing.T) {
+		client, got := record(t)
+
+		require.NoError(t, client.RemoveDependency(ctx, "abc", "def", DependencyBlocks))
+		assert.Equal(t, "def", got.query.Get("dependency_of"))
+		assert.Empty(t, got.query.Get("depends_on"))
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		client, got := record(t)
+
+		err := client.AddDependency(ctx, "abc", "def", "relates")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Empty(t, got.method, "no request should be sent")
+	})
+}
+
+func TestChecklists(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create checklist", func(t *testing.T) {
+		var path string
+		var body map[string]interface{}
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(`{"checklist": {"id": "cl1", "task_id": "abc", "name": "Release"}}`))
+		})
+
+		checklist, err := client.CreateChecklist(ctx, "abc", "Release")
+		require.NoError(t, err)
+		assert.Equal(t, "/task/abc/checklist/", path)
+		assert.Equal(t, "Release", body["name"])
+		assert.Equal(t, "cl1", checklist.ID)
+	})
+
+	t.Run("create item", func(t *testing.T) {
+		var path string
+		var body map[string]interface{}
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(`{"checklist": {"id": "cl1", "name": "Release", "items": [{"id": "i1", "name": "Tag it"}]}}`))
+		})
+
+		checklist, err := client.CreateChecklistItem(ctx, "cl1", "Tag it")
+		require.NoError(t, err)
+		assert.Equal(t, "/checklist/cl1/checklist_item", path)
+		assert.Equal(t, "Tag it", body["name"])
+		require.Len(t, checklist.Items, 1)
+		assert.Equal(t, "i1", checklist.Items[0].ID)
+	})
+}
+
+func TestGetCurrentUserRate(t *testing.T) {
+	t.Run("rate from the response headers", func(t *testing.T) {
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", "87")
+			w.Header().Set("X-RateLimit-Reset", "1700000000")
+			_, _ = w.Write([]byte(`{"user": {"id": 1, "username": "alice"}}`))
+		})
+
+		user, rate, err := client.GetCurrentUserRate(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "alice", user.Username)
+		require.NotNil(t, rate)
+		assert.Equal(t, 100, rate.Limit)
+		assert.Equal(t, 87, rate.Remaining)
+		assert.Equal(t, time.Unix(1700000000, 0), rate.Reset.Time)
+	})
+
+	t.Run("no rate headers", func(t *testing.T) {
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"user": {"id": 1, "username": "alice"}}`))
+		})
+
+		_, rate, err := client.GetCurrentUserRate(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, rate)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1700000000")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"err": "Rate limit reached", "ECODE": "APP_002"}`))
+		})
+
+		_, _, err := client.GetCurrentUserRate(context.Background())
+		var rateErr *clickup.RateLimitError
+		require.ErrorAs(t, err, &rateErr)
+		assert.Equal(t, 0, rateErr.Rate.Remaining)
+	})
+}
+
+func TestGetTaskActivity(t *testing.T) {
+	var path string
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{
+			"current_status": {"status": "review", "total_time": {"by_minute": 30, "since": "1700000200000"}},
+			"status_history": [
+				{"status": "in progress", "total_time": {"by_minute": 120, "since": "1700000100000"}},
+				{"status": "to do", "total_time": {"by_minute": 60, "since": "1700000000000"}}
+			]
+		}`))
+	})
+
+	activity, err := client.GetTaskActivity(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "/task/abc/time_in_status/", path)
+
+	require.Len(t, activity, 3)
+	assert.Equal(t, TaskActivity{Field: "status", From: "in progress", To: "review", Date: time.UnixMilli(1700000200000), Minutes: 30}, activity[0])
+	assert.Equal(t, "to do", activity[1].From)
+	assert.Equal(t, "in progress", activity[1].To)
+	assert.Equal(t, "", activity[2].From)
+	assert.Equal(t, "to do", activity[2].To)
+}
+
+func TestNotifyAll(t *testing.T) {
+	ctx := context.Background()
+	notify := true
+	silent := false
+
+	record := func(t *testing.T) (*Client, *map[string]interface{}) {
+		body := map[string]interface{}{}
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(`{"id": "abc"}`))
+		})
+		return client, &body
+	}
+
+	tests := []struct {
+		name      string
+		notifyAll *bool
+		expected  interface{}
+	}{
+		{"notify", &notify, true},
+		{"no notify", &silent, nil},
+		{"default", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run("create "+tt.name, func(t *testing.T) {
+			client, body := record(t)
+
+			_, err := client.CreateTask(ctx, "list1", &TaskCreateOptions{Name: "Task", NotifyAll: tt.notifyAll})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, (*body)["notify_all"])
+		})
+
+		t.Run("update "+tt.name, func(t *testing.T) {
+			client, body := record(t)
+
+			_, err := client.UpdateTask(ctx, "abc", &TaskUpdateOptions{Name: "Task", NotifyAll: tt.notifyAll})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, (*body)["notify_all"])
+		})
+	}
+}
+
+func TestGetTasksIncludeCustomFields(t *testing.T) {
+	ctx := context.Background()
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tasks": [{"id": "abc", "custom_fields": [{"id": "f1", "name": "Points", "value": 3}]}]}`))
+	})
+
+	tasks, err := client.GetTasks(ctx, "list1", &TaskQueryOptions{IncludeCustomFields: true})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Len(t, tasks[0].CustomFields, 1)
+	assert.Equal(t, "Points", tasks[0].CustomFields[0].Name)
+
+	tasks, err = client.GetTasks(ctx, "list1", &TaskQueryOptions{})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Nil(t, tasks[0].CustomFields)
+}
+
+func TestGetTasksArchived(t *testing.T) {
+	ctx := context.Background()
+	var query url.Values
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"tasks": []}`))
+	})
+
+	_, err := client.GetTasks(ctx, "list1", &TaskQueryOptions{Archived: true})
+	require.NoError(t, err)
+	assert.Equal(t, "true", query.Get("archived"))
+
+	_, err = client.GetTasks(ctx, "list1", &TaskQueryOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, query.Get("archived"))
+}
+
+func TestGetTasksOrderBy(t *testing.T) {
+	ctx := context.Background()
+	var query url.Values
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"tasks": []}`))
+	})
+
+	_, err := client.GetTasks(ctx, "list1", &TaskQueryOptions{OrderBy: "updated", Reverse: true})
+	require.NoError(t, err)
+	assert.Equal(t, "updated", query.Get("order_by"))
+	assert.Equal(t, "true", query.Get("reverse"))
+
+	_, err = client.GetTasks(ctx, "list1", &TaskQueryOptions{})
+	require.NoError(t, err)
+	assert.False(t, query.Has("order_by"))
+	assert.False(t, query.Has("reverse"))
+}
+
+func TestCustomFields(t *testing.T) {
+	ctx := context.Background()
+	var method, path string
+	var body map[string]interface{}
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		if r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"fields": [{"id": "f1", "name": "Environment", "type": "drop_down",
+			"type_config": {"options": [{"id": "opt-prod", "name": "Production"}]}}]}`))
+	})
+
+	fields, err := client.GetCustomFields(ctx, "list1")
+	require.NoError(t, err)
+	assert.Equal(t, "/list/list1/field", path)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "drop_down", fields[0].Type)
+
+	err = client.SetCustomFieldValue(ctx, "abc", "f1", map[string]interface{}{"value": "opt-prod"})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/task/abc/field/f1", path)
+	assert.Equal(t, map[string]interface{}{"value": "opt-prod"}, body)
+}
+
+func TestGetAllTasks(t *testing.T) {
+	ctx := context.Background()
+	pageSizes := []int{TasksPageSize, TasksPageSize, 37}
+
+	var pages []string
+	client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		n := 0
+		if _, err := fmt.Sscanf(page, "%d", &n); err != nil {
+			n = 0
+		}
+		tasks := make([]map[string]string, pageSizes[n])
+		for i := range tasks {
+			tasks[i] = map[string]string{"id": fmt.Sprintf("%d-%d", n, i)}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"tasks": tasks})
+	})
+
+	options := &TaskQueryOptions{Statuses: []string{"open"}}
+	tasks, err := client.GetAllTasks(ctx, "list1", options)
+	require.NoError(t, err)
+
+	assert.Len(t, tasks, 2*TasksPageSize+37)
+	assert.Equal(t, "0-0", tasks[0].ID)
+	assert.Equal(t, "2-36", tasks[len(tasks)-1].ID)
+	assert.Equal(t, []string{"", "1", "2"}, pages)
+	assert.Equal(t, 0, options.Page, "caller's options are not modified")
+}
+
+func TestAssigneeUsersLoadedOnce(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(t *testing.T) (*Client, *int, *[]map[string]interface{}) {
+		teamRequests := 0
+		var updates []map[string]interface{}
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/team" {
+				teamRequests++
+				_, _ = w.Write([]byte(`{"teams": [{"id": "ws1", "name": "Acme", "members": [
+					{"user": {"id": 11, "username": "alice"}},
+					{"user": {"id": 22, "username": "bob"}}
+				]}]}`))
+				return
+			}
+			body := map[string]interface{}{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if strings.HasSuffix(r.URL.Path, "/comment") {
+				_, _ = w.Write([]byte(`{"id": 1}`))
+				return
+			}
+			updates = append(updates, body)
+			_, _ = w.Write([]byte(`{"id": "abc"}`))
+		})
+		return client, &teamRequests, &updates
+	}
+
+	t.Run("bulk update", func(t *testing.T) {
+		client, teamRequests, updates := newClient(t)
+
+		for _, id := range []string{"t1", "t2", "t3"} {
+			_, err := client.UpdateTask(ctx, id, &TaskUpdateOptions{AddAssignees: []string{"alice"}, RemoveAssignees: []string{"Bob"}})
+			require.NoError(t, err)
+		}
+
+		// One load is GetWorkspaces plus GetWorkspaceMembers
+		assert.Equal(t, 2, *teamRequests)
+		require.Len(t, *updates, 3)
+		for _, body := range *updates {
+			assignees := body["assignees"].(map[string]interface{})
+			assert.Equal(t, []interface{}{float64(11)}, assignees["add"])
+			assert.Equal(t, []interface{}{float64(22)}, assignees["rem"])
+		}
+	})
+
+	t.Run("create and comment share the load", func(t *testing.T) {
+		client, teamRequests, _ := newClient(t)
+
+		_, err := client.CreateTask(ctx, "list1", &TaskCreateOptions{Name: "A", Assignees: []string{"alice"}})
+		require.NoError(t, err)
+		_, err = client.CreateTask(ctx, "list1", &TaskCreateOptions{Name: "B", Assignees: []string{"bob"}})
+		require.NoError(t, err)
+		_, err = client.CreateTaskComment(ctx, "t1", "hi", "alice", false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, *teamRequests)
+	})
+
+	t.Run("IDs need no load", func(t *testing.T) {
+		client, teamRequests, _ := newClient(t)
+
+		_, err := client.UpdateTask(ctx, "t1", &TaskUpdateOptions{AddAssignees: []string{"11"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, *teamRequests)
+	})
+
+	t.Run("resolve assignees", func(t *testing.T) {
+		client, teamRequests, _ := newClient(t)
+
+		ids, err := client.ResolveAssignees(ctx, []string{"alice", "22"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"11", "22"}, ids)
+
+		_, err = client.UpdateTask(ctx, "t1", &TaskUpdateOptions{AddAssignees: ids})
+		require.NoError(t, err)
+		assert.Equal(t, 2, *teamRequests)
+
+		_, err = client.ResolveAssignees(ctx, []string{"carol"})
+		assert.Error(t, err)
+	})
+}
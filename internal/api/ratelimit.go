@@ -41,6 +41,11 @@ func (r *RateLimiter) Wait(ctx context.Context) error {
 	}
 }
 
+// Limit returns the most requests the limiter allows per period
+func (r *RateLimiter) Limit() int {
+	return r.maxTokens
+}
+
 // tryAcquire attempts to acquire a token
 func (r *RateLimiter) tryAcquire() bool {
 	r.mu.Lock()
@@ -216,3 +216,8 @@ func TestTryAcquire(t *testing.T) {
 		assert.False(t, rl.tryAcquire())
 	})
 }
+
+func TestRateLimiterLimit(t *testing.T) {
+	rl := NewRateLimiter(2, time.Hour)
+	assert.Equal(t, 2, rl.Limit())
+}
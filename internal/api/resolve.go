@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/timimsms/cu/internal/errors"
+)
+
+// hierarchySource is the part of the client used to resolve spaces and lists
+type hierarchySource interface {
+	GetWorkspaces(ctx context.Context) ([]clickup.Team, error)
+	GetSpace(ctx context.Context, spaceID string) (*clickup.Space, error)
+	GetSpaces(ctx context.Context, workspaceID string) ([]clickup.Space, error)
+	GetFolders(ctx context.Context, spaceID string) ([]clickup.Folder, error)
+	GetList(ctx context.Context, listID string) (*clickup.List, error)
+	GetLists(ctx context.Context, folderID string) ([]clickup.List, error)
+	GetFolderlessLists(ctx context.Context, spaceID string) ([]clickup.List, error)
+}
+
+// ResolveSpace finds a space by ID or name. A numeric value is looked up as
+// an ID first; otherwise, or if no space has that ID, spaces in all
+// workspaces are matched by name, ignoring case. A name shared by several
+// spaces is an error listing their IDs.
+func (c *Client) ResolveSpace(ctx context.Context, nameOrID string) (*clickup.Space, error) {
+	return resolveSpace(ctx, c, nameOrID)
+}
+
+// ResolveList finds a list by ID or name, searching folderless and folder
+// lists in every space. Matching follows the same rules as ResolveSpace.
+func (c *Client) ResolveList(ctx context.Context, nameOrID string) (*clickup.List, error) {
+	return resolveList(ctx, c, nameOrID)
+}
+
+func resolveSpace(ctx context.Context, src hierarchySource, nameOrID string) (*clickup.Space, error) {
+	if nameOrID == "" {
+		return nil, fmt.Errorf("space name or ID is required")
+	}
+
+	if looksLikeID(nameOrID) {
+		if space, err := src.GetSpace(ctx, nameOrID); err == nil && space != nil {
+			return space, nil
+		}
+	}
+
+	workspaces, err := src.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []clickup.Space
+	for _, workspace := range workspaces {
+		spaces, err := src.GetSpaces(ctx, workspace.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spaces for workspace %s: %w", workspace.Name, err)
+		}
+		for _, space := range spaces {
+			if strings.EqualFold(space.Name, nameOrID) {
+				matches = append(matches, space)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.NewUserError(
+			fmt.Sprintf("Space %q not found", nameOrID),
+			"Check the name or use the space ID from 'cu space list'",
+			errors.ErrNotFound,
+		)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, space := range matches {
+			ids[i] = space.ID
+		}
+		return nil, ambiguousNameError("spaces", nameOrID, ids)
+	}
+}
+
+func resolveList(ctx context.Context, src hierarchySource, nameOrID string) (*clickup.List, error) {
+	if nameOrID == "" {
+		return nil, fmt.Errorf("list name or ID is required")
+	}
+
+	if looksLikeID(nameOrID) {
+		if list, err := src.GetList(ctx, nameOrID); err == nil && list != nil {
+			return list, nil
+		}
+	}
+
+	workspaces, err := src.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []clickup.List
+	addMatches := func(lists []clickup.List) {
+		for _, list := range lists {
+			if strings.EqualFold(list.Name, nameOrID) {
+				matches = append(matches, list)
+			}
+		}
+	}
+
+	for _, workspace := range workspaces {
+		spaces, err := src.GetSpaces(ctx, workspace.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spaces for workspace %s: %w", workspace.Name, err)
+		}
+
+		for _, space := range spaces {
+			lists, err := src.GetFolderlessLists(ctx, space.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get lists for space %s: %w", space.Name, err)
+			}
+			addMatches(lists)
+
+			folders, err := src.GetFolders(ctx, space.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get folders for space %s: %w", space.Name, err)
+			}
+			for _, folder := range folders {
+				lists, err := src.GetLists(ctx, folder.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get lists for folder %s: %w", folder.Name, err)
+				}
+				addMatches(lists)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.NewUserError(
+			fmt.Sprintf("List %q not found", nameOrID),
+			"Check the name or use the list ID from 'cu list tree'",
+			errors.ErrNotFound,
+		)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, list := range matches {
+			ids[i] = list.ID
+		}
+		return nil, ambiguousNameError("lists", nameOrID, ids)
+	}
+}
+
+// ambiguousNameError reports a name matched by several resources
+func ambiguousNameError(kind, name string, ids []string) error {
+	return errors.NewUserError(
+		fmt.Sprintf("%d %s are named %q", len(ids), kind, name),
+		fmt.Sprintf("Use an ID instead: %s", strings.Join(ids, ", ")),
+		errors.ErrInvalidInput,
+	)
+}
+
+// looksLikeID reports whether a value could be a ClickUp space or list ID
+func looksLikeID(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/errors"
+)
+
+// fakeHierarchy serves a fixed workspace hierarchy for resolver tests
+type fakeHierarchy struct {
+	workspaces      []clickup.Team
+	spaces          map[string][]clickup.Space
+	folders         map[string][]clickup.Folder
+	folderLists     map[string][]clickup.List
+	folderlessLists map[string][]clickup.List
+	getSpaceCalls   int
+	getListCalls    int
+}
+
+func (f *fakeHierarchy) GetWorkspaces(ctx context.Context) ([]clickup.Team, error) {
+	return f.workspaces, nil
+}
+
+func (f *fakeHierarchy) GetSpace(ctx context.Context, spaceID string) (*clickup.Space, error) {
+	f.getSpaceCalls++
+	for _, spaces := range f.spaces {
+		for _, space := range spaces {
+			if space.ID == spaceID {
+				return &space, nil
+			}
+		}
+	}
+	return nil, stderrors.New("not found")
+}
+
+func (f *fakeHierarchy) GetSpaces(ctx context.Context, workspaceID string) ([]clickup.Space, error) {
+	return f.spaces[workspaceID], nil
+}
+
+func (f *fakeHierarchy) GetFolders(ctx context.Context, spaceID string) ([]clickup.Folder, error) {
+	return f.folders[spaceID], nil
+}
+
+func (f *fakeHierarchy) GetList(ctx context.Context, listID string) (*clickup.List, error) {
+	f.getListCalls++
+	for _, group := range []map[string][]clickup.List{f.folderLists, f.folderlessLists} {
+		for _, lists := range group {
+			for _, list := range lists {
+				if list.ID == listID {
+					return &list, nil
+				}
+			}
+		}
+	}
+	return nil, stderrors.New("not found")
+}
+
+func (f *fakeHierarchy) GetLists(ctx context.Context, folderID string) ([]clickup.List, error) {
+	return f.folderLists[folderID], nil
+}
+
+func (f *fakeHierarchy) GetFolderlessLists(ctx context.Context, spaceID string) ([]clickup.List, error) {
+	return f.folderlessLists[spaceID], nil
+}
+
+func newFakeHierarchy() *fakeHierarchy {
+	return &fakeHierarchy{
+		workspaces: []clickup.Team{{ID: "w1", Name: "Acme"}, {ID: "w2", Name: "Side"}},
+		spaces: map[string][]clickup.Space{
+			"w1": {{ID: "100", Name: "Engineering"}, {ID: "101", Name: "Ops"}},
+			"w2": {{ID: "200", Name: "ops"}},
+		},
+		folders: map[string][]clickup.Folder{
+			"100": {{ID: "300", Name: "Sprints"}},
+		},
+		folderLists: map[string][]clickup.List{
+			"300": {{ID: "401", Name: "Sprint 1"}, {ID: "402", Name: "Backlog"}},
+		},
+		folderlessLists: map[string][]clickup.List{
+			"100": {{ID: "400", Name: "Backlog"}},
+			"101": {{ID: "403", Name: "Incidents"}},
+		},
+	}
+}
+
+func TestResolveSpace(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ID match", func(t *testing.T) {
+		src := newFakeHierarchy()
+
+		space, err := resolveSpace(ctx, src, "100")
+		require.NoError(t, err)
+		assert.Equal(t, "Engineering", space.Name)
+		assert.Equal(t, 1, src.getSpaceCalls)
+	})
+
+	t.Run("unique name match ignores case", func(t *testing.T) {
+		space, err := resolveSpace(ctx, newFakeHierarchy(), "engineering")
+		require.NoError(t, err)
+		assert.Equal(t, "100", space.ID)
+	})
+
+	t.Run("names are not looked up as IDs", func(t *testing.T) {
+		src := newFakeHierarchy()
+
+		_, err := resolveSpace(ctx, src, "Engineering")
+		require.NoError(t, err)
+		assert.Equal(t, 0, src.getSpaceCalls)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		_, err := resolveSpace(ctx, newFakeHierarchy(), "Ops")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "2 spaces are named")
+		assert.Contains(t, err.Error(), "101, 200")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := resolveSpace(ctx, newFakeHierarchy(), "Marketing")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		_, err := resolveSpace(ctx, newFakeHierarchy(), "999")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
+
+func TestResolveList(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ID match", func(t *testing.T) {
+		src := newFakeHierarchy()
+
+		list, err := resolveList(ctx, src, "401")
+		require.NoError(t, err)
+		assert.Equal(t, "Sprint 1", list.Name)
+		assert.Equal(t, 1, src.getListCalls)
+	})
+
+	t.Run("unique name in folder", func(t *testing.T) {
+		list, err := resolveList(ctx, newFakeHierarchy(), "sprint 1")
+		require.NoError(t, err)
+		assert.Equal(t, "401", list.ID)
+	})
+
+	t.Run("unique folderless name", func(t *testing.T) {
+		list, err := resolveList(ctx, newFakeHierarchy(), "Incidents")
+		require.NoError(t, err)
+		assert.Equal(t, "403", list.ID)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		_, err := resolveList(ctx, newFakeHierarchy(), "Backlog")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "400, 402")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := resolveList(ctx, newFakeHierarchy(), "Roadmap")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
+
+func TestLooksLikeID(t *testing.T) {
+	assert.True(t, looksLikeID("90123"))
+	assert.False(t, looksLikeID(""))
+	assert.False(t, looksLikeID("Engineering"))
+	assert.False(t, looksLikeID("12ab"))
+}
@@ -2,19 +2,67 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
 	"time"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/timimsms/cu/internal/errors"
 )
 
+// idempotencyKeyHeader is sent with every POST, keeping the same value across
+// retries, so a server that supports it can drop a repeated request. ClickUp
+// does not document support for it, so CreateTask also guards against
+// duplicates itself (see createTaskOnce).
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// createTaskAttempts is how many times CreateTask sends a request that fails
+// with a server or network error
+const createTaskAttempts = 3
+
+// createTaskBackoff is the wait before CreateTask's first retry. It doubles
+// for each further retry.
+var createTaskBackoff = 100 * time.Millisecond
+
+// createdTaskLookback widens the search for a task created by a failed
+// request, to allow for clock skew between this machine and ClickUp
+const createdTaskLookback = time.Minute
+
+// createTaskPath matches the path of ClickUp's create task endpoint
+var createTaskPath = regexp.MustCompile(`/list/[^/]+/task$`)
+
+// isCreateTaskRequest reports whether req creates a task. The transport does
+// not retry these after a server or network error, since the task may have
+// been created anyway; createTaskOnce retries them after checking for the
+// task instead.
+func isCreateTaskRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost && createTaskPath.MatchString(req.URL.Path)
+}
+
+// newIdempotencyKey returns a random key for the Idempotency-Key header
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 // retryTransport implements automatic retry with exponential backoff
 type retryTransport struct {
 	base http.RoundTripper
 }
 
 func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && req.Header.Get(idempotencyKeyHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(idempotencyKeyHeader, newIdempotencyKey())
+	}
+
 	var body []byte
 	if req.Body != nil {
 		var err error
@@ -42,6 +90,12 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return resp, nil
 		}
 
+		// The server may have acted on the request, so leave retrying to the
+		// caller. A 429 is rejected before any work is done and is safe to retry.
+		if isCreateTaskRequest(req) && (err != nil || resp.StatusCode != 429) {
+			return resp, err
+		}
+
 		// Check if error is retryable
 		if err != nil && !errors.IsRetryable(err) {
 			return nil, err
@@ -75,3 +129,81 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	return resp, err
 }
+
+// createTaskOnce creates a task without creating it twice. The transport does
+// not retry the request after a server or network error, since the task may
+// have been created anyway. Instead, before each retry it looks in the list for a task
+// with the same name created since the first attempt and returns that task if
+// there is one.
+//
+// The check is by name, so if a task with the same name is created in the list
+// by someone else at the same moment, that task is returned instead.
+func (c *Client) createTaskOnce(ctx context.Context, listID string, request *clickup.TaskRequest) (*clickup.Task, error) {
+	start := time.Now()
+	backoff := createTaskBackoff
+
+	for attempt := 1; ; attempt++ {
+		task, _, err := c.client.Tasks.CreateTask(ctx, listID, request)
+		if err == nil {
+			return task, nil
+		}
+		if attempt == createTaskAttempts || !(isServerError(err) || isTransportError(err)) {
+			return nil, c.handleError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+
+		if existing := c.findCreatedTask(ctx, listID, request.Name, start); existing != nil {
+			return existing, nil
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// findCreatedTask returns the task named name created in the list since the
+// given time, or nil if there is none or the lookup fails. ClickUp is asked
+// for the newest tasks first and the creation time is checked here, since
+// GetTasksOptions cannot send a date filter.
+func (c *Client) findCreatedTask(ctx context.Context, listID, name string, since time.Time) *clickup.Task {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil
+	}
+
+	tasks, _, err := c.client.Tasks.GetTasks(ctx, listID, &clickup.GetTasksOptions{
+		IncludeClosed: true,
+		Subtasks:      true,
+		OrderBy:       "created",
+	})
+	if err != nil {
+		return nil
+	}
+
+	cutoff := since.Add(-createdTaskLookback)
+	for i := range tasks {
+		if tasks[i].Name == name && !parseTaskTime(tasks[i].DateCreated).Before(cutoff) {
+			return &tasks[i]
+		}
+	}
+	return nil
+}
+
+// isTransportError reports whether err is a request that got no response,
+// such as a network error or a timeout
+func isTransportError(err error) bool {
+	var urlErr *url.Error
+	return stderrors.As(err, &urlErr)
+}
+
+// isServerError reports whether err is a 5xx response from ClickUp
+func isServerError(err error) bool {
+	var errResp *clickup.ErrorResponse
+	return stderrors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode >= 500
+}
@@ -2,9 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +20,7 @@ import (
 type mockRoundTripper struct {
 	responses []mockResponse
 	calls     int
+	requests  []*http.Request
 }
 
 type mockResponse struct {
@@ -32,6 +37,7 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 
 	resp := m.responses[m.calls]
 	m.calls++
+	m.requests = append(m.requests, req)
 
 	if resp.err != nil {
 		return nil, resp.err
@@ -205,3 +211,170 @@ func TestRetryTransport(t *testing.T) {
 		assert.True(t, elapsed < 500*time.Millisecond, "Should not exceed expected backoff")
 	})
 }
+
+func TestRetryTransportIdempotency(t *testing.T) {
+	t.Run("POST keeps one idempotency key across retries", func(t *testing.T) {
+		mock := &mockRoundTripper{
+			responses: []mockResponse{
+				{statusCode: 429, body: "rate limited"},
+				{statusCode: 200, body: "success"},
+			},
+		}
+
+		transport := &retryTransport{base: mock}
+		req, _ := http.NewRequest("POST", "http://example.com", bytes.NewBufferString("{}"))
+
+		_, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.Len(t, mock.requests, 2)
+
+		key := mock.requests[0].Header.Get(idempotencyKeyHeader)
+		assert.NotEmpty(t, key)
+		assert.Equal(t, key, mock.requests[1].Header.Get(idempotencyKeyHeader))
+		assert.Empty(t, req.Header.Get(idempotencyKeyHeader), "caller's request is not modified")
+	})
+
+	t.Run("GET has no idempotency key", func(t *testing.T) {
+		mock := &mockRoundTripper{responses: []mockResponse{{statusCode: 200}}}
+
+		transport := &retryTransport{base: mock}
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+		_, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		assert.Empty(t, mock.requests[0].Header.Get(idempotencyKeyHeader))
+	})
+
+	t.Run("task creation is not retried after a server error", func(t *testing.T) {
+		mock := &mockRoundTripper{
+			responses: []mockResponse{
+				{statusCode: 500, body: "error"},
+				{statusCode: 200, body: "success"},
+			},
+		}
+
+		transport := &retryTransport{base: mock}
+		req, _ := http.NewRequest("POST", "http://example.com/api/v2/list/123/task", bytes.NewBufferString("{}"))
+
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, 1, mock.calls)
+	})
+}
+
+func TestCreateTaskRetry(t *testing.T) {
+	defer func(backoff time.Duration) { createTaskBackoff = backoff }(createTaskBackoff)
+	createTaskBackoff = time.Millisecond
+	ctx := context.Background()
+
+	// An older task with the same name is always in the list, and must not
+	// be taken for the one being created
+	older := fmt.Sprintf(`{"id": "old", "name": "Ship it", "date_created": "%d"}`, time.Now().Add(-time.Hour).UnixMilli())
+
+	// newServer returns a client for a list whose task creations fail for the
+	// given attempts, creating the task anyway when created is set. Failures
+	// are 500 responses, or dropped connections with drop. The queries of task
+	// lookups are recorded.
+	newServer := func(t *testing.T, failures int, created, drop bool) (*Client, *int, *[]string, *[]url.Values) {
+		posts := 0
+		var tasks []string
+		var lookups []url.Values
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				posts++
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				if posts <= failures {
+					if created {
+						tasks = append(tasks, body["name"].(string))
+					}
+					if drop {
+						conn, _, err := w.(http.Hijacker).Hijack()
+						require.NoError(t, err)
+						_ = conn.Close()
+						return
+					}
+					w.WriteHeader(http.StatusInternalServerError)
+					_, _ = w.Write([]byte(`{"err": "Internal error", "ECODE": "SRV_000"}`))
+					return
+				}
+				tasks = append(tasks, body["name"].(string))
+				_, _ = fmt.Fprintf(w, `{"id": "new%d", "name": %q}`, len(tasks), body["name"])
+			case http.MethodGet:
+				lookups = append(lookups, r.URL.Query())
+				items := []string{older}
+				for i, name := range tasks {
+					items = append(items, fmt.Sprintf(`{"id": "new%d", "name": %q, "date_created": "%d"}`, i+1, name, time.Now().UnixMilli()))
+				}
+				_, _ = fmt.Fprintf(w, `{"tasks": [%s]}`, strings.Join(items, ","))
+			}
+		})
+		return client, &posts, &tasks, &lookups
+	}
+
+	t.Run("failed create that went through is not repeated", func(t *testing.T) {
+		client, posts, tasks, lookups := newServer(t, 1, true, false)
+
+		task, err := client.CreateTask(ctx, "l1", &TaskCreateOptions{Name: "Ship it"})
+		require.NoError(t, err)
+		assert.Equal(t, "new1", task.ID)
+		assert.Equal(t, 1, *posts)
+		assert.Equal(t, []string{"Ship it"}, *tasks)
+		require.Len(t, *lookups, 1)
+		assert.Equal(t, "created", (*lookups)[0].Get("order_by"))
+	})
+
+	t.Run("older task with the same name is not taken for the new one", func(t *testing.T) {
+		client, posts, tasks, lookups := newServer(t, 1, false, false)
+
+		task, err := client.CreateTask(ctx, "l1", &TaskCreateOptions{Name: "Ship it"})
+		require.NoError(t, err)
+		assert.Equal(t, "new1", task.ID)
+		assert.Equal(t, 2, *posts)
+		assert.Equal(t, []string{"Ship it"}, *tasks)
+		assert.Len(t, *lookups, 1)
+	})
+
+	t.Run("dropped connection that created the task is not repeated", func(t *testing.T) {
+		client, posts, tasks, _ := newServer(t, 1, true, true)
+
+		task, err := client.CreateTask(ctx, "l1", &TaskCreateOptions{Name: "Ship it"})
+		require.NoError(t, err)
+		assert.Equal(t, "new1", task.ID)
+		assert.Equal(t, 1, *posts)
+		assert.Equal(t, []string{"Ship it"}, *tasks)
+	})
+
+	t.Run("dropped connection is retried", func(t *testing.T) {
+		client, posts, tasks, _ := newServer(t, 1, false, true)
+
+		task, err := client.CreateTask(ctx, "l1", &TaskCreateOptions{Name: "Ship it"})
+		require.NoError(t, err)
+		assert.Equal(t, "new1", task.ID)
+		assert.Equal(t, 2, *posts)
+		assert.Equal(t, []string{"Ship it"}, *tasks)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		client, posts, _, _ := newServer(t, createTaskAttempts, false, false)
+
+		_, err := client.CreateTask(ctx, "l1", &TaskCreateOptions{Name: "Ship it"})
+		assert.Error(t, err)
+		assert.Equal(t, createTaskAttempts, *posts)
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		posts := 0
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			posts++
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"err": "Task name invalid", "ECODE": "INPUT_005"}`))
+		})
+
+		_, err := client.CreateTask(ctx, "l1", &TaskCreateOptions{Name: ""})
+		assert.Error(t, err)
+		assert.Equal(t, 1, posts)
+	})
+}
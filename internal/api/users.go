@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/timimsms/cu/internal/cache"
@@ -17,6 +18,7 @@ type UserLookup struct {
 	mu     sync.RWMutex
 	cache  map[string]*clickup.TeamUser // username -> user
 	idMap  map[int]*clickup.TeamUser    // id -> user
+	loaded map[string]time.Time         // workspace ID -> when its users were loaded
 }
 
 // NewUserLookup creates a new user lookup service
@@ -25,24 +27,28 @@ func NewUserLookup(client *Client) *UserLookup {
 		client: client,
 		cache:  make(map[string]*clickup.TeamUser),
 		idMap:  make(map[int]*clickup.TeamUser),
+		loaded: make(map[string]time.Time),
 	}
 }
 
-// LoadWorkspaceUsers loads all users from a workspace into cache
+// LoadWorkspaceUsers loads all users from a workspace into cache. A workspace
+// loaded within the user cache TTL is not loaded again, and the member list is
+// read from the user cache when one is initialized, so repeated lookups within
+// and across commands don't call the API.
 func (ul *UserLookup) LoadWorkspaceUsers(ctx context.Context, workspaceID string) error {
+	ul.mu.RLock()
+	loadedAt, ok := ul.loaded[workspaceID]
+	ul.mu.RUnlock()
+	if ok && time.Since(loadedAt) < userCacheTTL() {
+		return nil
+	}
+
 	// Try to get from cache first
-	cacheKey := fmt.Sprintf("users_%s", workspaceID)
+	cacheKey := UserCacheKey(workspaceID)
 	if cache.UserCache != nil {
 		var users []clickup.TeamUser
 		if err := cache.UserCache.Get(cacheKey, &users); err == nil {
-			// Load from cache
-			ul.mu.Lock()
-			for i := range users {
-				user := &users[i]
-				ul.cache[strings.ToLower(user.Username)] = user
-				ul.idMap[user.ID] = user
-			}
-			ul.mu.Unlock()
+			ul.store(workspaceID, users)
 			return nil
 		}
 	}
@@ -58,16 +64,34 @@ func (ul *UserLookup) LoadWorkspaceUsers(ctx context.Context, workspaceID string
 		_ = cache.UserCache.Set(cacheKey, users)
 	}
 
-	// Store in memory
+	ul.store(workspaceID, users)
+	return nil
+}
+
+// store adds users to the in-memory maps and records the workspace as loaded
+func (ul *UserLookup) store(workspaceID string, users []clickup.TeamUser) {
 	ul.mu.Lock()
+	defer ul.mu.Unlock()
+
 	for i := range users {
 		user := &users[i]
 		ul.cache[strings.ToLower(user.Username)] = user
 		ul.idMap[user.ID] = user
 	}
-	ul.mu.Unlock()
+	ul.loaded[workspaceID] = time.Now()
+}
 
-	return nil
+// UserCacheKey is the user cache key of a workspace's member list
+func UserCacheKey(workspaceID string) string {
+	return fmt.Sprintf("users_%s", workspaceID)
+}
+
+// userCacheTTL returns how long a loaded member list is reused
+func userCacheTTL() time.Duration {
+	if cache.UserCache != nil {
+		return cache.UserCache.TTL()
+	}
+	return cache.DefaultWorkspaceTTL
 }
 
 // LookupByUsername finds a user by username (case-insensitive)
@@ -96,7 +120,34 @@ func (ul *UserLookup) LookupByID(userID int) (*clickup.TeamUser, error) {
 	return user, nil
 }
 
-// ConvertUsernamesToIDs converts a list of usernames to user IDs
+// LookupByEmail finds a user by email address (case-insensitive)
+func (ul *UserLookup) LookupByEmail(email string) (*clickup.TeamUser, error) {
+	ul.mu.RLock()
+	defer ul.mu.RUnlock()
+
+	for _, user := range ul.idMap {
+		if user.Email != "" && strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user not found: %s", email)
+}
+
+// Lookup finds a user by ID, email or username. Numeric identifiers are
+// treated as IDs and identifiers containing @ as email addresses.
+func (ul *UserLookup) Lookup(identifier string) (*clickup.TeamUser, error) {
+	if id, err := strconv.Atoi(identifier); err == nil {
+		return ul.LookupByID(id)
+	}
+	if strings.Contains(identifier, "@") {
+		return ul.LookupByEmail(identifier)
+	}
+	return ul.LookupByUsername(identifier)
+}
+
+// ConvertUsernamesToIDs converts a list of usernames or email addresses to
+// user IDs. Numeric entries are taken as IDs already.
 func (ul *UserLookup) ConvertUsernamesToIDs(usernames []string) ([]int, error) {
 	ids := make([]int, 0, len(usernames))
 
@@ -107,8 +158,12 @@ func (ul *UserLookup) ConvertUsernamesToIDs(usernames []string) ([]int, error) {
 			continue
 		}
 
-		// Look up by username
-		user, err := ul.LookupByUsername(username)
+		// Look up by email or username
+		lookup := ul.LookupByUsername
+		if strings.Contains(username, "@") {
+			lookup = ul.LookupByEmail
+		}
+		user, err := lookup(username)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find user %s: %w", username, err)
 		}
@@ -1,14 +1,19 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/cache"
+	"github.com/timimsms/cu/internal/config"
 	"github.com/timimsms/cu/internal/testutil"
 )
 
@@ -65,6 +70,72 @@ func TestUserLookupLoadWorkspaceUsers(t *testing.T) {
 	})
 }
 
+func TestUserLookupLoadWorkspaceUsersCached(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(t *testing.T) (*Client, *int) {
+		requests := 0
+		client := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_, _ = w.Write([]byte(`{"teams": [{"id": "ws1", "members": [{"user": {"id": 11, "username": "alice"}}]}]}`))
+		})
+		return client, &requests
+	}
+
+	t.Run("second load reuses the members", func(t *testing.T) {
+		oldCache := cache.UserCache
+		cache.UserCache = nil
+		defer func() { cache.UserCache = oldCache }()
+
+		client, requests := newClient(t)
+		ul := client.UserLookup()
+
+		require.NoError(t, ul.LoadWorkspaceUsers(ctx, "ws1"))
+		require.NoError(t, ul.LoadWorkspaceUsers(ctx, "ws1"))
+		assert.Equal(t, 1, *requests)
+
+		ids, err := ul.ConvertUsernamesToIDs([]string{"alice"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{11}, ids)
+	})
+
+	t.Run("user cache is shared across clients", func(t *testing.T) {
+		oldConfigDir, oldCache := config.DefaultConfigDir, cache.UserCache
+		config.DefaultConfigDir = t.TempDir()
+		defer func() { config.DefaultConfigDir, cache.UserCache = oldConfigDir, oldCache }()
+
+		var err error
+		cache.UserCache, err = cache.NewCache(time.Hour)
+		require.NoError(t, err)
+
+		first, firstRequests := newClient(t)
+		require.NoError(t, first.UserLookup().LoadWorkspaceUsers(ctx, "ws1"))
+		assert.Equal(t, 1, *firstRequests)
+
+		second, secondRequests := newClient(t)
+		require.NoError(t, second.UserLookup().LoadWorkspaceUsers(ctx, "ws1"))
+		assert.Equal(t, 0, *secondRequests)
+
+		user, err := second.UserLookup().LookupByUsername("alice")
+		require.NoError(t, err)
+		assert.Equal(t, 11, user.ID)
+	})
+
+	t.Run("expired load is refreshed", func(t *testing.T) {
+		oldCache := cache.UserCache
+		cache.UserCache = nil
+		defer func() { cache.UserCache = oldCache }()
+
+		client, requests := newClient(t)
+		ul := client.UserLookup()
+
+		require.NoError(t, ul.LoadWorkspaceUsers(ctx, "ws1"))
+		ul.loaded["ws1"] = time.Now().Add(-2 * cache.DefaultWorkspaceTTL)
+		require.NoError(t, ul.LoadWorkspaceUsers(ctx, "ws1"))
+		assert.Equal(t, 2, *requests)
+	})
+}
+
 func TestUserLookupByUsername(t *testing.T) {
 	ul := &UserLookup{
 		cache: make(map[string]*clickup.TeamUser),
@@ -132,6 +203,46 @@ func TestUserLookupByID(t *testing.T) {
 	})
 }
 
+func TestUserLookupLookup(t *testing.T) {
+	ul := &UserLookup{
+		cache: make(map[string]*clickup.TeamUser),
+		idMap: make(map[int]*clickup.TeamUser),
+	}
+
+	alice := &clickup.TeamUser{ID: 100, Username: "alice", Email: "Alice@Example.com"}
+	bob := &clickup.TeamUser{ID: 200, Username: "bob"}
+	for _, user := range []*clickup.TeamUser{alice, bob} {
+		ul.cache[user.Username] = user
+		ul.idMap[user.ID] = user
+	}
+
+	tests := []struct {
+		name       string
+		identifier string
+		want       *clickup.TeamUser
+	}{
+		{"by ID", "100", alice},
+		{"by username", "Bob", bob},
+		{"by email", "alice@example.com", alice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := ul.Lookup(tt.identifier)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, user)
+		})
+	}
+
+	for _, identifier := range []string{"999", "carol", "carol@example.com"} {
+		t.Run("not found "+identifier, func(t *testing.T) {
+			user, err := ul.Lookup(identifier)
+			assert.Nil(t, user)
+			assert.ErrorContains(t, err, "user not found: "+identifier)
+		})
+	}
+}
+
 func TestConvertUsernamesToIDs(t *testing.T) {
 	ul := &UserLookup{
 		cache: make(map[string]*clickup.TeamUser),
@@ -144,7 +255,7 @@ func TestConvertUsernamesToIDs(t *testing.T) {
 		key  string
 	}{
 		{&clickup.TeamUser{ID: 100, Username: "alice"}, "alice"},
-		{&clickup.TeamUser{ID: 200, Username: "bob"}, "bob"},
+		{&clickup.TeamUser{ID: 200, Username: "bob", Email: "bob@example.com"}, "bob"},
 		{&clickup.TeamUser{ID: 300, Username: "Charlie"}, "charlie"},
 	}
 
@@ -165,6 +276,12 @@ func TestConvertUsernamesToIDs(t *testing.T) {
 		assert.Equal(t, []int{100, 999, 200}, ids)
 	})
 
+	t.Run("looks up email addresses", func(t *testing.T) {
+		ids, err := ul.ConvertUsernamesToIDs([]string{"Bob@Example.com", "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{200, 100}, ids)
+	})
+
 	t.Run("returns error for unknown username", func(t *testing.T) {
 		ids, err := ul.ConvertUsernamesToIDs([]string{"alice", "unknown"})
 		assert.Error(t, err)
@@ -1,12 +1,17 @@
 package auth
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/timimsms/cu/internal/config"
 	"github.com/timimsms/cu/internal/errors"
-	"github.com/zalando/go-keyring"
 )
 
 const (
@@ -14,24 +19,54 @@ const (
 	ServiceName = "cu-cli"
 	// DefaultWorkspace is the default workspace key
 	DefaultWorkspace = "default"
+	// TokenEnv holds a token that is used instead of any stored token
+	TokenEnv = "CU_TOKEN"
 )
 
 // Token represents an authentication token
 type Token struct {
-	Value     string `json:"value"`
-	Workspace string `json:"workspace,omitempty"`
-	Email     string `json:"email,omitempty"`
+	Value     string    `json:"value"`
+	Workspace string    `json:"workspace,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// IsExpired reports whether the token has passed its expiry time.
+// Tokens without an expiry never expire.
+func (t *Token) IsExpired() bool {
+	return !t.ExpiresAt.IsZero() && time.Now().After(t.ExpiresAt)
+}
+
+// CacheScope identifies the account behind the token in cache keys: the
+// workspace it is stored under and a short hash of its value. Data cached
+// for one account is never served to another.
+func (t *Token) CacheScope() string {
+	workspace := t.Workspace
+	if workspace == "" {
+		workspace = DefaultWorkspace
+	}
+	hash := sha256.Sum256([]byte(t.Value))
+	return workspace + ":" + hex.EncodeToString(hash[:6])
 }
 
 // Manager handles authentication
 type Manager struct {
 	service string
+	keyring Keyring
 }
 
-// NewManager creates a new authentication manager
+// NewManager creates a new authentication manager. Tokens are stored in the
+// OS keyring, falling back to a credentials file when no keyring is available
+// or when CU_AUTH_STORE=file.
 func NewManager() *Manager {
+	return NewManagerWithKeyring(defaultKeyring())
+}
+
+// NewManagerWithKeyring creates a new authentication manager with a specific keyring
+func NewManagerWithKeyring(k Keyring) *Manager {
 	return &Manager{
 		service: ServiceName,
+		keyring: k,
 	}
 }
 
@@ -46,7 +81,7 @@ func (m *Manager) SaveToken(workspace string, token *Token) error {
 		return fmt.Errorf("failed to marshal token: %w", err)
 	}
 
-	err = keyring.Set(m.service, workspace, string(data))
+	err = m.keyring.Set(m.service, workspace, string(data))
 	if err != nil {
 		return fmt.Errorf("failed to save token: %w", err)
 	}
@@ -56,16 +91,23 @@ func (m *Manager) SaveToken(workspace string, token *Token) error {
 
 // GetToken retrieves a token from the keyring
 func (m *Manager) GetToken(workspace string) (*Token, error) {
+	token, _, err := m.readToken(workspace)
+	return token, err
+}
+
+// readToken retrieves a token from the keyring along with the store it was
+// read from
+func (m *Manager) readToken(workspace string) (*Token, TokenSource, error) {
 	if workspace == "" {
 		workspace = DefaultWorkspace
 	}
 
-	data, err := keyring.Get(m.service, workspace)
+	data, source, err := getWithSource(m.keyring, m.service, workspace)
 	if err != nil {
-		if err == keyring.ErrNotFound {
-			return nil, errors.ErrNotAuthenticated
+		if err == ErrSecretNotFound {
+			return nil, "", errors.ErrNotAuthenticated
 		}
-		return nil, fmt.Errorf("failed to get token: %w", err)
+		return nil, "", fmt.Errorf("failed to get token: %w", err)
 	}
 
 	var token Token
@@ -74,11 +116,42 @@ func (m *Manager) GetToken(workspace string) (*Token, error) {
 		if !strings.Contains(data, "{") {
 			token = Token{Value: data, Workspace: workspace}
 		} else {
-			return nil, fmt.Errorf("failed to unmarshal token: %w", err)
+			return nil, "", fmt.Errorf("failed to unmarshal token: %w", err)
+		}
+	}
+
+	return &token, source, nil
+}
+
+// MigrateToken rewrites a token stored in the legacy format, a plain token
+// string, as a structured Token. It reports whether the token was rewritten;
+// missing tokens and tokens already in the current format are left alone.
+func (m *Manager) MigrateToken(workspace string) (bool, error) {
+	if workspace == "" {
+		workspace = DefaultWorkspace
+	}
+
+	data, err := m.keyring.Get(m.service, workspace)
+	if err != nil {
+		if err == ErrSecretNotFound {
+			return false, nil
 		}
+		return false, fmt.Errorf("failed to get token: %w", err)
 	}
 
-	return &token, nil
+	var current Token
+	if json.Unmarshal([]byte(data), &current) == nil {
+		return false, nil
+	}
+
+	token, err := m.GetToken(workspace)
+	if err != nil {
+		return false, err
+	}
+	if err := m.SaveToken(workspace, token); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // DeleteToken removes a token from the keyring
@@ -87,20 +160,49 @@ func (m *Manager) DeleteToken(workspace string) error {
 		workspace = DefaultWorkspace
 	}
 
-	err := keyring.Delete(m.service, workspace)
-	if err != nil && err != keyring.ErrNotFound {
+	err := m.keyring.Delete(m.service, workspace)
+	if err != nil && err != ErrSecretNotFound {
 		return fmt.Errorf("failed to delete token: %w", err)
 	}
 
 	return nil
 }
 
-// ListWorkspaces returns all workspaces with stored tokens
+// WorkspacesKey is the config key listing the workspaces logged into. The
+// keyring cannot list its entries, so they are recorded here.
+const WorkspacesKey = "auth_workspaces"
+
+// ListWorkspaces returns the default workspace and the workspaces recorded
+// with RememberWorkspace. A listed workspace may no longer have a token.
 func (m *Manager) ListWorkspaces() ([]string, error) {
-	// Note: go-keyring doesn't support listing all accounts
-	// This is a limitation we'll need to work around by storing
-	// workspace list separately in config
-	return []string{DefaultWorkspace}, nil
+	workspaces := []string{DefaultWorkspace}
+	for _, workspace := range config.GetStringSlice(WorkspacesKey) {
+		if !slices.Contains(workspaces, workspace) {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+	return workspaces, nil
+}
+
+// RememberWorkspace records a workspace that has a stored token, so
+// ListWorkspaces returns it
+func RememberWorkspace(workspace string) error {
+	known := config.GetStringSlice(WorkspacesKey)
+	if workspace == "" || workspace == DefaultWorkspace || slices.Contains(known, workspace) {
+		return nil
+	}
+	config.Set(WorkspacesKey, append(known, workspace))
+	return config.Save()
+}
+
+// ForgetWorkspace removes a workspace recorded with RememberWorkspace
+func ForgetWorkspace(workspace string) error {
+	known := config.GetStringSlice(WorkspacesKey)
+	if !slices.Contains(known, workspace) {
+		return nil
+	}
+	config.Set(WorkspacesKey, slices.DeleteFunc(slices.Clone(known), func(w string) bool { return w == workspace }))
+	return config.Save()
 }
 
 // IsAuthenticated checks if the user is authenticated
@@ -114,3 +216,31 @@ func (m *Manager) GetCurrentToken() (*Token, error) {
 	// TODO: Get current workspace from config
 	return m.GetToken(DefaultWorkspace)
 }
+
+// ResolveToken returns the token cu uses for API calls and where it was read
+// from. A token in CU_TOKEN takes precedence. Otherwise the token of
+// workspace is used, falling back to the default workspace's token when that
+// workspace has none. The returned token's Workspace is the workspace it is
+// stored under.
+func (m *Manager) ResolveToken(workspace string) (*Token, TokenSource, error) {
+	if value := strings.TrimSpace(os.Getenv(TokenEnv)); value != "" {
+		return &Token{Value: value, Workspace: workspace}, SourceEnv, nil
+	}
+
+	if workspace == "" {
+		workspace = DefaultWorkspace
+	}
+	token, source, err := m.readToken(workspace)
+	if err != nil && workspace != DefaultWorkspace {
+		workspace = DefaultWorkspace
+		token, source, err = m.readToken(workspace)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if token.Workspace == "" {
+		token.Workspace = workspace
+	}
+	return token, source, nil
+}
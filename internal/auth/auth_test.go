@@ -0,0 +1,217 @@
+package auth_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/auth"
+	"github.com/timimsms/cu/internal/auth/mock"
+	"github.com/timimsms/cu/internal/config"
+	cuerrors "github.com/timimsms/cu/internal/errors"
+)
+
+func TestManager_WithKeyring(t *testing.T) {
+	t.Run("save and get token", func(t *testing.T) {
+		k := mock.NewKeyringMock()
+		m := auth.NewManagerWithKeyring(k)
+
+		token := &auth.Token{
+			Value:     mock.ValidToken,
+			Workspace: "prod",
+			Email:     "user@example.com",
+		}
+		require.NoError(t, m.SaveToken("prod", token))
+
+		got, err := m.GetToken("prod")
+		require.NoError(t, err)
+		assert.Equal(t, token.Value, got.Value)
+		assert.Equal(t, token.Workspace, got.Workspace)
+		assert.Equal(t, token.Email, got.Email)
+
+		// Stored under the service name as JSON
+		raw, err := k.Get(auth.ServiceName, "prod")
+		require.NoError(t, err)
+		assert.Contains(t, raw, mock.ValidToken)
+	})
+
+	t.Run("empty workspace uses default", func(t *testing.T) {
+		k := mock.NewKeyringMock()
+		m := auth.NewManagerWithKeyring(k)
+
+		require.NoError(t, m.SaveToken("", &auth.Token{Value: mock.ValidToken}))
+
+		_, err := k.Get(auth.ServiceName, auth.DefaultWorkspace)
+		require.NoError(t, err)
+
+		got, err := m.GetCurrentToken()
+		require.NoError(t, err)
+		assert.Equal(t, mock.ValidToken, got.Value)
+		assert.True(t, m.IsAuthenticated(""))
+	})
+
+	t.Run("missing token is not authenticated", func(t *testing.T) {
+		m := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+
+		_, err := m.GetToken("missing")
+		assert.ErrorIs(t, err, cuerrors.ErrNotAuthenticated)
+		assert.False(t, m.IsAuthenticated("missing"))
+	})
+
+	t.Run("legacy plain token format", func(t *testing.T) {
+		k := mock.NewKeyringMock()
+		require.NoError(t, k.Set(auth.ServiceName, "legacy", mock.LegacyToken))
+		m := auth.NewManagerWithKeyring(k)
+
+		got, err := m.GetToken("legacy")
+		require.NoError(t, err)
+		assert.Equal(t, mock.LegacyToken, got.Value)
+		assert.Equal(t, "legacy", got.Workspace)
+	})
+
+	t.Run("migrate legacy token", func(t *testing.T) {
+		k := mock.NewKeyringMock()
+		require.NoError(t, k.Set(auth.ServiceName, "legacy", mock.LegacyToken))
+		m := auth.NewManagerWithKeyring(k)
+
+		migrated, err := m.MigrateToken("legacy")
+		require.NoError(t, err)
+		assert.True(t, migrated)
+
+		raw, err := k.Get(auth.ServiceName, "legacy")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"value": "`+mock.LegacyToken+`", "workspace": "legacy"}`, raw)
+
+		// Running again changes nothing
+		migrated, err = m.MigrateToken("legacy")
+		require.NoError(t, err)
+		assert.False(t, migrated)
+
+		got, err := m.GetToken("legacy")
+		require.NoError(t, err)
+		assert.Equal(t, mock.LegacyToken, got.Value)
+	})
+
+	t.Run("migrate leaves current and missing tokens alone", func(t *testing.T) {
+		k := mock.NewKeyringMock()
+		m := auth.NewManagerWithKeyring(k)
+		require.NoError(t, m.SaveToken("prod", &auth.Token{Value: mock.ValidToken}))
+
+		migrated, err := m.MigrateToken("prod")
+		require.NoError(t, err)
+		assert.False(t, migrated)
+
+		migrated, err = m.MigrateToken("missing")
+		require.NoError(t, err)
+		assert.False(t, migrated)
+	})
+
+	t.Run("delete token", func(t *testing.T) {
+		k := mock.NewKeyringMock()
+		m := auth.NewManagerWithKeyring(k)
+		require.NoError(t, m.SaveToken("prod", &auth.Token{Value: mock.ValidToken}))
+
+		require.NoError(t, m.DeleteToken("prod"))
+
+		_, err := m.GetToken("prod")
+		assert.ErrorIs(t, err, cuerrors.ErrNotAuthenticated)
+
+		// Deleting again is not an error
+		assert.NoError(t, m.DeleteToken("prod"))
+	})
+
+	t.Run("keyring errors are wrapped", func(t *testing.T) {
+		k := mock.NewKeyringMock()
+		k.SetError(errors.New("access denied"))
+		m := auth.NewManagerWithKeyring(k)
+
+		err := m.SaveToken("prod", &auth.Token{Value: mock.ValidToken})
+		assert.ErrorContains(t, err, "failed to save token")
+
+		_, err = m.GetToken("prod")
+		assert.ErrorContains(t, err, "failed to get token")
+
+		err = m.DeleteToken("prod")
+		assert.ErrorContains(t, err, "failed to delete token")
+	})
+}
+
+func TestManager_ListWorkspaces(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	t.Cleanup(func() {
+		config.DefaultConfigDir = oldConfigDir
+		config.Reset()
+	})
+	config.Reset()
+	m := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+
+	workspaces, err := m.ListWorkspaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.DefaultWorkspace}, workspaces)
+
+	require.NoError(t, auth.RememberWorkspace("work"))
+	require.NoError(t, auth.RememberWorkspace("personal"))
+	require.NoError(t, auth.RememberWorkspace("work"))
+	require.NoError(t, auth.RememberWorkspace(auth.DefaultWorkspace))
+
+	workspaces, err = m.ListWorkspaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.DefaultWorkspace, "work", "personal"}, workspaces)
+
+	require.NoError(t, auth.ForgetWorkspace("work"))
+	workspaces, err = m.ListWorkspaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.DefaultWorkspace, "personal"}, workspaces)
+}
+
+func TestToken_IsExpired(t *testing.T) {
+	tests := []struct {
+		name     string
+		expires  time.Time
+		expected bool
+	}{
+		{"no expiry", time.Time{}, false},
+		{"expires in future", time.Now().Add(time.Hour), false},
+		{"expired", time.Now().Add(-time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &auth.Token{Value: mock.ValidToken, ExpiresAt: tt.expires}
+			assert.Equal(t, tt.expected, token.IsExpired())
+		})
+	}
+}
+
+func TestToken_CacheScope(t *testing.T) {
+	work := &auth.Token{Value: mock.ValidToken, Workspace: "work"}
+	assert.Regexp(t, `^work:[0-9a-f]{12}$`, work.CacheScope())
+	assert.NotContains(t, work.CacheScope(), mock.ValidToken)
+
+	other := &auth.Token{Value: mock.LegacyToken, Workspace: "work"}
+	assert.NotEqual(t, work.CacheScope(), other.CacheScope())
+
+	unnamed := &auth.Token{Value: mock.ValidToken}
+	assert.Regexp(t, `^default:`, unnamed.CacheScope())
+}
+
+func TestManager_TokenExpiryRoundTrip(t *testing.T) {
+	k := mock.NewKeyringMock()
+	m := auth.NewManagerWithKeyring(k)
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, m.SaveToken("prod", &auth.Token{Value: mock.ValidToken, ExpiresAt: expires}))
+
+	got, err := m.GetToken("prod")
+	require.NoError(t, err)
+	assert.True(t, expires.Equal(got.ExpiresAt))
+
+	// Tokens without an expiry don't store one
+	require.NoError(t, m.SaveToken("dev", &auth.Token{Value: mock.ValidToken}))
+	raw, err := k.Get(auth.ServiceName, "dev")
+	require.NoError(t, err)
+	assert.NotContains(t, raw, "expires_at")
+}
@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/fsutil"
+)
+
+const (
+	// AuthStoreEnv selects the credential store ("file" or "keyring")
+	AuthStoreEnv = "CU_AUTH_STORE"
+	// CredentialsFileName is the name of the file-backed credential store
+	CredentialsFileName = "credentials.json"
+)
+
+// FileKeyring stores secrets in a JSON file readable only by the current user.
+// It is used when no OS credential store is available, e.g. on headless Linux.
+type FileKeyring struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileKeyring creates a file-backed keyring at the given path
+func NewFileKeyring(path string) *FileKeyring {
+	return &FileKeyring{
+		path: path,
+	}
+}
+
+// DefaultCredentialsPath returns the default location of the credentials file
+func DefaultCredentialsPath() string {
+	return filepath.Join(config.DefaultConfigDir, CredentialsFileName)
+}
+
+// Path returns the location of the credentials file
+func (k *FileKeyring) Path() string {
+	return k.path
+}
+
+// Get retrieves a secret from the credentials file
+func (k *FileKeyring) Get(service, account string) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	store, err := k.load()
+	if err != nil {
+		return "", err
+	}
+
+	secret, ok := store[service][account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+
+	return secret, nil
+}
+
+// getWithSource retrieves a secret from the credentials file
+func (k *FileKeyring) getWithSource(service, account string) (string, TokenSource, error) {
+	secret, err := k.Get(service, account)
+	return secret, SourceFile, err
+}
+
+// Set stores a secret in the credentials file
+func (k *FileKeyring) Set(service, account, secret string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	store, err := k.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := store[service]; !ok {
+		store[service] = make(map[string]string)
+	}
+	store[service][account] = secret
+
+	return k.save(store)
+}
+
+// Delete removes a secret from the credentials file
+func (k *FileKeyring) Delete(service, account string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	store, err := k.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := store[service][account]; !ok {
+		return ErrSecretNotFound
+	}
+
+	delete(store[service], account)
+	if len(store[service]) == 0 {
+		delete(store, service)
+	}
+
+	return k.save(store)
+}
+
+// load reads the credentials file, returning an empty store if it doesn't exist
+func (k *FileKeyring) load() (map[string]map[string]string, error) {
+	store := make(map[string]map[string]string)
+
+	data, err := os.ReadFile(k.path) // #nosec G304 - path is set by the application, not user input
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+
+	return store, nil
+}
+
+// save writes the credentials file with owner-only permissions
+func (k *FileKeyring) save(store map[string]map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(k.path), 0700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	if err := fsutil.WriteFileAtomic(k.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+
+	// WriteFileAtomic keeps the mode of an existing file, so tighten it explicitly
+	if err := os.Chmod(k.path, 0600); err != nil {
+		return fmt.Errorf("failed to set credentials file permissions: %w", err)
+	}
+
+	return nil
+}
+
+// fallbackKeyring uses a primary keyring and falls back to a secondary one
+// when the primary is unavailable
+type fallbackKeyring struct {
+	primary  Keyring
+	fallback Keyring
+
+	// warn receives the warning printed the first time a secret is stored
+	// in the fallback, os.Stderr when nil
+	warn     io.Writer
+	warnOnce sync.Once
+}
+
+// Get retrieves a secret from the primary keyring, then the fallback
+func (k *fallbackKeyring) Get(service, account string) (string, error) {
+	secret, _, err := k.getWithSource(service, account)
+	return secret, err
+}
+
+// getWithSource retrieves a secret like Get, along with the store of the
+// keyring it was read from
+func (k *fallbackKeyring) getWithSource(service, account string) (string, TokenSource, error) {
+	secret, source, err := getWithSource(k.primary, service, account)
+	if err == nil {
+		return secret, source, nil
+	}
+	return getWithSource(k.fallback, service, account)
+}
+
+// Set stores a secret in the primary keyring, or the fallback if that fails.
+// The first fallback prints a warning, since the file store is plaintext.
+func (k *fallbackKeyring) Set(service, account, secret string) error {
+	primaryErr := k.primary.Set(service, account, secret)
+	if primaryErr == nil {
+		return nil
+	}
+	if err := k.fallback.Set(service, account, secret); err != nil {
+		return err
+	}
+
+	k.warnOnce.Do(func() {
+		w := k.warn
+		if w == nil {
+			w = os.Stderr
+		}
+		location := "a plaintext file"
+		if f, ok := k.fallback.(*FileKeyring); ok {
+			location = f.Path()
+		}
+		fmt.Fprintf(w, "Warning: system keychain unavailable (%v); token stored unencrypted in %s\n", primaryErr, location)
+	})
+	return nil
+}
+
+// Delete removes a secret from both keyrings
+func (k *fallbackKeyring) Delete(service, account string) error {
+	primaryErr := k.primary.Delete(service, account)
+	fallbackErr := k.fallback.Delete(service, account)
+
+	if primaryErr == nil || fallbackErr == nil {
+		return nil
+	}
+	if fallbackErr != ErrSecretNotFound {
+		return fallbackErr
+	}
+	return primaryErr
+}
+
+// defaultKeyring selects the keyring based on the CU_AUTH_STORE environment variable
+func defaultKeyring() Keyring {
+	switch strings.ToLower(os.Getenv(AuthStoreEnv)) {
+	case "file":
+		return NewFileKeyring(DefaultCredentialsPath())
+	case "keyring", "system":
+		return NewSystemKeyring()
+	default:
+		return &fallbackKeyring{
+			primary:  NewSystemKeyring(),
+			fallback: NewFileKeyring(DefaultCredentialsPath()),
+		}
+	}
+}
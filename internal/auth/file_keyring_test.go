@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/config"
+	"github.com/zalando/go-keyring"
+)
+
+// failingKeyring simulates an unavailable OS keyring. gets counts the reads.
+type failingKeyring struct {
+	err  error
+	gets int
+}
+
+func (k *failingKeyring) Get(service, account string) (string, error) {
+	k.gets++
+	return "", k.err
+}
+
+func (k *failingKeyring) Set(service, account, secret string) error { return k.err }
+func (k *failingKeyring) Delete(service, account string) error      { return k.err }
+
+func TestFileKeyring(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cu", CredentialsFileName)
+		k := NewFileKeyring(path)
+
+		require.NoError(t, k.Set(ServiceName, "default", "secret-1"))
+		require.NoError(t, k.Set(ServiceName, "prod", "secret-2"))
+
+		secret, err := k.Get(ServiceName, "default")
+		require.NoError(t, err)
+		assert.Equal(t, "secret-1", secret)
+
+		// A new instance reads the same file
+		secret, err = NewFileKeyring(path).Get(ServiceName, "prod")
+		require.NoError(t, err)
+		assert.Equal(t, "secret-2", secret)
+
+		require.NoError(t, k.Delete(ServiceName, "default"))
+		_, err = k.Get(ServiceName, "default")
+		assert.Equal(t, ErrSecretNotFound, err)
+
+		assert.Equal(t, ErrSecretNotFound, k.Delete(ServiceName, "default"))
+	})
+
+	t.Run("missing file is empty", func(t *testing.T) {
+		k := NewFileKeyring(filepath.Join(t.TempDir(), CredentialsFileName))
+
+		_, err := k.Get(ServiceName, "default")
+		assert.Equal(t, ErrSecretNotFound, err)
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), CredentialsFileName)
+		require.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+
+		_, err := NewFileKeyring(path).Get(ServiceName, "default")
+		assert.ErrorContains(t, err, "failed to parse credentials file")
+	})
+
+	t.Run("permission bits", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file permissions are not enforced on Windows")
+		}
+
+		path := filepath.Join(t.TempDir(), CredentialsFileName)
+		// Pre-existing file with loose permissions gets tightened
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0644)) // #nosec G306 - testing permission fix
+
+		require.NoError(t, NewFileKeyring(path).Set(ServiceName, "default", "secret"))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("leaves no temporary files", func(t *testing.T) {
+		dir := t.TempDir()
+		k := NewFileKeyring(filepath.Join(dir, CredentialsFileName))
+
+		require.NoError(t, k.Set(ServiceName, "default", "secret-1"))
+		require.NoError(t, k.Set(ServiceName, "default", "secret-2"))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, CredentialsFileName, entries[0].Name())
+	})
+
+	t.Run("works with manager", func(t *testing.T) {
+		m := NewManagerWithKeyring(NewFileKeyring(filepath.Join(t.TempDir(), CredentialsFileName)))
+
+		require.NoError(t, m.SaveToken("", &Token{Value: "pk_test"}))
+
+		token, err := m.GetCurrentToken()
+		require.NoError(t, err)
+		assert.Equal(t, "pk_test", token.Value)
+
+		require.NoError(t, m.DeleteToken(""))
+		assert.False(t, m.IsAuthenticated(""))
+	})
+}
+
+func TestFallbackKeyring(t *testing.T) {
+	unavailable := &failingKeyring{err: errors.New("no secret service")}
+
+	t.Run("falls back when primary is unavailable", func(t *testing.T) {
+		fallback := NewFileKeyring(filepath.Join(t.TempDir(), CredentialsFileName))
+		var warn bytes.Buffer
+		k := &fallbackKeyring{primary: unavailable, fallback: fallback, warn: &warn}
+
+		require.NoError(t, k.Set(ServiceName, "default", "secret"))
+		require.NoError(t, k.Set(ServiceName, "work", "secret"))
+		assert.Equal(t, "Warning: system keychain unavailable (no secret service); token stored unencrypted in "+fallback.Path()+"\n", warn.String(),
+			"warned once")
+
+		secret, err := fallback.Get(ServiceName, "default")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", secret)
+
+		secret, err = k.Get(ServiceName, "default")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", secret)
+
+		require.NoError(t, k.Delete(ServiceName, "default"))
+		_, err = fallback.Get(ServiceName, "default")
+		assert.Equal(t, ErrSecretNotFound, err)
+	})
+
+	t.Run("prefers primary when available", func(t *testing.T) {
+		primary := NewFileKeyring(filepath.Join(t.TempDir(), "primary.json"))
+		fallback := NewFileKeyring(filepath.Join(t.TempDir(), "fallback.json"))
+		var warn bytes.Buffer
+		k := &fallbackKeyring{primary: primary, fallback: fallback, warn: &warn}
+
+		require.NoError(t, k.Set(ServiceName, "default", "secret"))
+		assert.Empty(t, warn.String())
+
+		_, err := fallback.Get(ServiceName, "default")
+		assert.Equal(t, ErrSecretNotFound, err)
+
+		secret, err := primary.Get(ServiceName, "default")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", secret)
+	})
+}
+
+func TestDefaultKeyring(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+
+	t.Run("file store", func(t *testing.T) {
+		t.Setenv(AuthStoreEnv, "file")
+
+		k, ok := defaultKeyring().(*FileKeyring)
+		require.True(t, ok)
+		assert.Equal(t, filepath.Join(config.DefaultConfigDir, CredentialsFileName), k.Path())
+	})
+
+	t.Run("system store", func(t *testing.T) {
+		t.Setenv(AuthStoreEnv, "keyring")
+
+		_, ok := defaultKeyring().(*SystemKeyring)
+		assert.True(t, ok)
+	})
+
+	t.Run("automatic fallback", func(t *testing.T) {
+		t.Setenv(AuthStoreEnv, "")
+
+		_, ok := defaultKeyring().(*fallbackKeyring)
+		assert.True(t, ok)
+	})
+}
+
+func TestResolveToken(t *testing.T) {
+	keyring.MockInit()
+
+	newManager := func(t *testing.T, primary Keyring) (*Manager, *FileKeyring) {
+		t.Setenv(TokenEnv, "")
+		fallback := NewFileKeyring(filepath.Join(t.TempDir(), CredentialsFileName))
+		return NewManagerWithKeyring(&fallbackKeyring{primary: primary, fallback: fallback, warn: io.Discard}), fallback
+	}
+
+	t.Run("environment", func(t *testing.T) {
+		m, _ := newManager(t, &failingKeyring{err: errors.New("no secret service")})
+		require.NoError(t, m.SaveToken("", &Token{Value: "pk_stored"}))
+		t.Setenv(TokenEnv, " pk_env\n")
+
+		token, source, err := m.ResolveToken("work")
+		require.NoError(t, err)
+		assert.Equal(t, SourceEnv, source)
+		assert.Equal(t, "pk_env", token.Value)
+		assert.Equal(t, "work", token.Workspace)
+	})
+
+	t.Run("keychain", func(t *testing.T) {
+		m, _ := newManager(t, NewSystemKeyring())
+		require.NoError(t, m.SaveToken("keychain-test", &Token{Value: "pk_keychain"}))
+		t.Cleanup(func() { _ = m.DeleteToken("keychain-test") })
+
+		token, source, err := m.ResolveToken("keychain-test")
+		require.NoError(t, err)
+		assert.Equal(t, SourceKeychain, source)
+		assert.Equal(t, "pk_keychain", token.Value)
+		assert.Equal(t, "keychain-test", token.Workspace)
+	})
+
+	t.Run("file store", func(t *testing.T) {
+		primary := &failingKeyring{err: errors.New("no secret service")}
+		m, fallback := newManager(t, primary)
+		require.NoError(t, m.SaveToken("", &Token{Value: "pk_file"}))
+		_, err := fallback.Get(ServiceName, DefaultWorkspace)
+		require.NoError(t, err)
+
+		token, source, err := m.ResolveToken("")
+		require.NoError(t, err)
+		assert.Equal(t, SourceFile, source)
+		assert.Equal(t, 1, primary.gets, "the keychain is read once")
+		assert.Equal(t, "pk_file", token.Value)
+		assert.Equal(t, DefaultWorkspace, token.Workspace)
+	})
+
+	t.Run("missing workspace falls back to default", func(t *testing.T) {
+		m, _ := newManager(t, &failingKeyring{err: errors.New("no secret service")})
+		require.NoError(t, m.SaveToken("", &Token{Value: "pk_default"}))
+
+		token, source, err := m.ResolveToken("missing")
+		require.NoError(t, err)
+		assert.Equal(t, SourceFile, source)
+		assert.Equal(t, DefaultWorkspace, token.Workspace)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		m, _ := newManager(t, &failingKeyring{err: errors.New("no secret service")})
+
+		_, _, err := m.ResolveToken("")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+package auth
+
+import "github.com/zalando/go-keyring"
+
+// ErrSecretNotFound is returned by a Keyring when no secret is stored
+var ErrSecretNotFound = keyring.ErrNotFound
+
+// Keyring stores secrets by service and account
+type Keyring interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+	Delete(service, account string) error
+}
+
+// TokenSource names where a token was read from
+type TokenSource string
+
+const (
+	// SourceEnv is a token given in the CU_TOKEN environment variable
+	SourceEnv TokenSource = "env"
+	// SourceKeychain is a token stored in the OS credential store
+	SourceKeychain TokenSource = "keychain"
+	// SourceFile is a token stored in the credentials file
+	SourceFile TokenSource = "file"
+)
+
+// sourcedGetter is implemented by keyrings that can tell which store a
+// secret was read from
+type sourcedGetter interface {
+	getWithSource(service, account string) (string, TokenSource, error)
+}
+
+// getWithSource reads a secret from k along with the store it was read
+// from, which is "" when k cannot tell
+func getWithSource(k Keyring, service, account string) (string, TokenSource, error) {
+	if s, ok := k.(sourcedGetter); ok {
+		return s.getWithSource(service, account)
+	}
+	secret, err := k.Get(service, account)
+	return secret, "", err
+}
+
+// SystemKeyring stores secrets in the operating system's credential store
+type SystemKeyring struct{}
+
+// NewSystemKeyring creates a keyring backed by the OS credential store
+func NewSystemKeyring() *SystemKeyring {
+	return &SystemKeyring{}
+}
+
+// Get retrieves a secret from the OS credential store
+func (k *SystemKeyring) Get(service, account string) (string, error) {
+	return keyring.Get(service, account)
+}
+
+// Set stores a secret in the OS credential store
+func (k *SystemKeyring) Set(service, account, secret string) error {
+	return keyring.Set(service, account, secret)
+}
+
+// Delete removes a secret from the OS credential store
+func (k *SystemKeyring) Delete(service, account string) error {
+	return keyring.Delete(service, account)
+}
+
+// getWithSource retrieves a secret from the OS credential store
+func (k *SystemKeyring) getWithSource(service, account string) (string, TokenSource, error) {
+	secret, err := k.Get(service, account)
+	return secret, SourceKeychain, err
+}
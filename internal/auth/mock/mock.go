@@ -219,6 +219,7 @@ func (m *AuthProvider) SetToken(workspace string, token string, expiry time.Time
 	m.tokens[workspace] = &auth.Token{
 		Value:     token,
 		Workspace: workspace,
+		ExpiresAt: expiry,
 	}
 	m.authenticated[workspace] = true
 
@@ -352,6 +353,9 @@ func (m *AuthProvider) Reset() {
 	m.refreshBehavior = nil
 }
 
+// Ensure KeyringMock implements auth.Keyring
+var _ auth.Keyring = (*KeyringMock)(nil)
+
 // KeyringMock provides a mock implementation of the keyring interface
 type KeyringMock struct {
 	mu    sync.RWMutex
@@ -381,7 +385,7 @@ func (k *KeyringMock) Get(service, account string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("secret not found in keyring")
+	return "", auth.ErrSecretNotFound
 }
 
 // Set stores a secret in the mock keyring
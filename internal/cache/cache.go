@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"crypto/cipher"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -11,18 +12,43 @@ import (
 	"time"
 
 	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/fsutil"
 )
 
-// Cache represents a simple file-based cache
+// Cache represents a simple file-based cache with an in-memory layer that
+// lives for the duration of the process
 type Cache struct {
-	mu  sync.RWMutex
-	dir string
-	ttl time.Duration
+	mu   sync.RWMutex
+	dir  string
+	ttl  time.Duration
+	aead cipher.AEAD // nil unless encryption is enabled
+
+	memMu sync.Mutex
+	mem   map[string]memoryEntry
+}
+
+// memoryEntry is an entry held in memory as the JSON encoding of its data
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// keyLocks holds a *sync.Mutex per cache file so that writers to the same key
+// are serialized, even across Cache instances sharing a directory
+var keyLocks sync.Map
+
+// lockFile locks the mutex for a cache file and returns its unlock function
+func lockFile(filename string) func() {
+	mu, _ := keyLocks.LoadOrStore(filename, &sync.Mutex{})
+	m := mu.(*sync.Mutex)
+	m.Lock()
+	return m.Unlock
 }
 
 // CacheEntry represents a cached item with metadata
 type CacheEntry struct {
 	Data      interface{} `json:"data"`
+	Encrypted []byte      `json:"encrypted,omitempty"`
 	ExpiresAt time.Time   `json:"expires_at"`
 }
 
@@ -44,6 +70,14 @@ func (c *Cache) Get(key string, dest interface{}) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	// Check the in-memory layer first
+	if jsonData, ok := c.memGet(key); ok {
+		if err := json.Unmarshal(jsonData, dest); err != nil {
+			return fmt.Errorf("failed to unmarshal to destination: %w", err)
+		}
+		return nil
+	}
+
 	filename := c.filename(key)
 	data, err := os.ReadFile(filename) // #nosec G304 - filename is generated from SHA256 hash
 	if err != nil {
@@ -63,48 +97,80 @@ func (c *Cache) Get(key string, dest interface{}) error {
 		return fmt.Errorf("cache expired: %s", key)
 	}
 
-	// Marshal data to JSON then unmarshal to destination
-	jsonData, err := json.Marshal(entry.Data)
-	if err != nil {
-		return fmt.Errorf("failed to marshal cached data: %w", err)
+	var jsonData []byte
+	if entry.Encrypted != nil {
+		jsonData, err = c.decrypt(key, entry.Encrypted)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Marshal data to JSON then unmarshal to destination
+		jsonData, err = json.Marshal(entry.Data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal cached data: %w", err)
+		}
 	}
 
 	if err := json.Unmarshal(jsonData, dest); err != nil {
 		return fmt.Errorf("failed to unmarshal to destination: %w", err)
 	}
 
+	c.memSet(key, jsonData, entry.ExpiresAt)
 	return nil
 }
 
-// Set stores an item in the cache
+// Set stores an item in the cache. The entry is written to a temporary file
+// and renamed into place, so readers never see a partially written entry.
 func (c *Cache) Set(key string, value interface{}) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
 	entry := CacheEntry{
 		Data:      value,
 		ExpiresAt: time.Now().Add(c.ttl),
 	}
 
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if c.aead != nil {
+		entry.Encrypted, err = c.encrypt(key, jsonData)
+		if err != nil {
+			return err
+		}
+		entry.Data = nil
+	}
+
 	data, err := json.MarshalIndent(entry, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal cache entry: %w", err)
 	}
 
 	filename := c.filename(key)
-	if err := os.WriteFile(filename, data, 0600); err != nil {
+	unlock := lockFile(filename)
+	defer unlock()
+
+	if err := fsutil.WriteFileAtomic(filename, data, 0600); err != nil {
+		c.memDelete(key)
 		return fmt.Errorf("failed to write cache: %w", err)
 	}
 
+	c.memSet(key, jsonData, entry.ExpiresAt)
 	return nil
 }
 
 // Delete removes an item from the cache
 func (c *Cache) Delete(key string) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
 	filename := c.filename(key)
+	unlock := lockFile(filename)
+	defer unlock()
+
+	c.memDelete(key)
 	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to delete cache: %w", err)
 	}
@@ -117,6 +183,8 @@ func (c *Cache) Clear() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.memClear()
+
 	entries, err := os.ReadDir(c.dir)
 	if err != nil {
 		return fmt.Errorf("failed to read cache directory: %w", err)
@@ -134,6 +202,62 @@ func (c *Cache) Clear() error {
 	return nil
 }
 
+// memGet returns the in-memory JSON for a key if present and not expired
+func (c *Cache) memGet(key string) ([]byte, bool) {
+	c.memMu.Lock()
+	defer c.memMu.Unlock()
+
+	entry, ok := c.mem[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.mem, key)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// memSet stores the JSON for a key in memory
+func (c *Cache) memSet(key string, data []byte, expiresAt time.Time) {
+	c.memMu.Lock()
+	defer c.memMu.Unlock()
+
+	if c.mem == nil {
+		c.mem = make(map[string]memoryEntry)
+	}
+	c.mem[key] = memoryEntry{data: data, expiresAt: expiresAt}
+}
+
+// memDelete removes a key from memory
+func (c *Cache) memDelete(key string) {
+	c.memMu.Lock()
+	defer c.memMu.Unlock()
+
+	delete(c.mem, key)
+}
+
+// memClear removes all keys from memory
+func (c *Cache) memClear() {
+	c.memMu.Lock()
+	defer c.memMu.Unlock()
+
+	c.mem = nil
+}
+
+// memCleanExpired removes expired keys from memory
+func (c *Cache) memCleanExpired() {
+	c.memMu.Lock()
+	defer c.memMu.Unlock()
+
+	now := time.Now()
+	for key, entry := range c.mem {
+		if now.After(entry.expiresAt) {
+			delete(c.mem, key)
+		}
+	}
+}
+
 // filename generates a safe filename for a cache key using SHA256
 func (c *Cache) filename(key string) string {
 	// Use SHA256 to generate a safe filename from the key
@@ -214,6 +338,8 @@ func (c *Cache) CleanExpired() (int, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.memCleanExpired()
+
 	entries, err := os.ReadDir(c.dir)
 	if err != nil {
 		return 0, fmt.Errorf("failed to read cache directory: %w", err)
@@ -247,34 +373,99 @@ func (c *Cache) CleanExpired() (int, error) {
 	return removed, nil
 }
 
+// Default TTLs for the global caches
+const (
+	// DefaultWorkspaceTTL applies to the workspace and user caches
+	DefaultWorkspaceTTL = 1 * time.Hour
+	// DefaultTaskTTL applies to the task cache
+	DefaultTaskTTL = 5 * time.Minute
+)
+
 // Global cache instances with different TTLs
 var (
-	// WorkspaceCache for workspace structure (1 hour)
+	// WorkspaceCache for workspace structure (1 hour by default)
 	WorkspaceCache *Cache
-	// UserCache for user list (1 hour)
+	// UserCache for user list (1 hour by default)
 	UserCache *Cache
-	// TaskCache for recent tasks (5 minutes)
+	// TaskCache for recent tasks (5 minutes by default)
 	TaskCache *Cache
 )
 
-// InitCaches initializes the global cache instances
+// TTL returns how long entries stay valid
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
+}
+
+// ParseTTL parses a cache TTL such as "30s" or "2h". Zero disables caching in
+// practice since entries expire immediately; negative values are rejected.
+func ParseTTL(value string) (time.Duration, error) {
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (use a value like 30s, 10m or 2h)", value)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("invalid duration %q: must not be negative", value)
+	}
+	return ttl, nil
+}
+
+// configuredTTL returns the TTL for a cache. The cache_ttl key, set by the
+// --cache-ttl flag, overrides every cache; otherwise the cache's own key is
+// used, falling back to def.
+func configuredTTL(key string, def time.Duration) (time.Duration, error) {
+	for _, k := range []string{"cache_ttl", key} {
+		value := config.GetString(k)
+		if value == "" {
+			continue
+		}
+		ttl, err := ParseTTL(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %w", k, err)
+		}
+		return ttl, nil
+	}
+	return def, nil
+}
+
+// InitCaches initializes the global cache instances. TTLs can be overridden
+// with the cache_ttl_workspaces and cache_ttl_tasks config keys or the
+// --cache-ttl flag.
 func InitCaches() error {
-	var err error
+	workspaceTTL, err := configuredTTL("cache_ttl_workspaces", DefaultWorkspaceTTL)
+	if err != nil {
+		return err
+	}
+	taskTTL, err := configuredTTL("cache_ttl_tasks", DefaultTaskTTL)
+	if err != nil {
+		return err
+	}
 
-	WorkspaceCache, err = NewCache(1 * time.Hour)
+	WorkspaceCache, err = NewCache(workspaceTTL)
 	if err != nil {
 		return fmt.Errorf("failed to create workspace cache: %w", err)
 	}
 
-	UserCache, err = NewCache(1 * time.Hour)
+	UserCache, err = NewCache(workspaceTTL)
 	if err != nil {
 		return fmt.Errorf("failed to create user cache: %w", err)
 	}
 
-	TaskCache, err = NewCache(5 * time.Minute)
+	TaskCache, err = NewCache(taskTTL)
 	if err != nil {
 		return fmt.Errorf("failed to create task cache: %w", err)
 	}
 
+	if config.GetBool("cache_encrypt") {
+		key, err := LoadEncryptionKey()
+		if err != nil {
+			return err
+		}
+		for _, c := range []*Cache{WorkspaceCache, UserCache, TaskCache} {
+			if err := c.SetEncryptionKey(key); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
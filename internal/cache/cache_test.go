@@ -1,11 +1,15 @@
 package cache
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/timimsms/cu/internal/config"
 )
 
 func TestCache(t *testing.T) {
@@ -186,3 +190,327 @@ func TestCacheSafety(t *testing.T) {
 		filenames[filename] = true
 	}
 }
+
+func TestCacheConcurrentWritesSameKey(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Two instances sharing a directory, like the global caches
+	caches := []*Cache{
+		{dir: tmpDir, ttl: time.Hour},
+		{dir: tmpDir, ttl: time.Hour},
+	}
+
+	type payload struct {
+		Writer int      `json:"writer"`
+		Items  []string `json:"items"`
+	}
+
+	const writers = 20
+	const iterations = 25
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*iterations*2)
+
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+
+		go func(w int) {
+			defer wg.Done()
+			// Writers use payloads of different sizes so interleaved writes
+			// would produce invalid JSON
+			items := make([]string, w*10+1)
+			for i := range items {
+				items[i] = fmt.Sprintf("writer-%d-item-%d", w, i)
+			}
+			for i := 0; i < iterations; i++ {
+				if err := caches[w%2].Set("shared", payload{Writer: w, Items: items}); err != nil {
+					errs <- fmt.Errorf("set: %w", err)
+				}
+			}
+		}(w)
+
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				var got payload
+				err := caches[w%2].Get("shared", &got)
+				if err != nil {
+					if strings.Contains(err.Error(), "cache miss") {
+						continue
+					}
+					errs <- fmt.Errorf("get: %w", err)
+					continue
+				}
+				if len(got.Items) != got.Writer*10+1 {
+					errs <- fmt.Errorf("get: entry from writer %d has %d items", got.Writer, len(got.Items))
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// No temporary files are left behind
+	files, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to read cache directory: %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected 1 cache file, got %d", len(files))
+	}
+}
+
+func TestCacheSetReplacesAtomically(t *testing.T) {
+	tmpDir := t.TempDir()
+	c := &Cache{dir: tmpDir, ttl: time.Hour}
+
+	if err := c.Set("key", "original"); err != nil {
+		t.Fatalf("Failed to set cache: %v", err)
+	}
+
+	// A value that can't be encoded leaves the existing entry intact
+	if err := c.Set("key", make(chan int)); err == nil {
+		t.Fatal("Expected Set to fail for an unencodable value")
+	}
+
+	var got string
+	if err := c.Get("key", &got); err != nil {
+		t.Fatalf("Failed to get original entry: %v", err)
+	}
+	if got != "original" {
+		t.Errorf("Expected original entry, got %q", got)
+	}
+
+	// A truncated entry from an old interrupted write is replaced
+	if err := os.WriteFile(c.filename("key"), []byte(`{"data": "trunc`), 0600); err != nil {
+		t.Fatalf("Failed to write truncated entry: %v", err)
+	}
+	if err := c.Set("key", "replacement"); err != nil {
+		t.Fatalf("Failed to set cache: %v", err)
+	}
+	if err := c.Get("key", &got); err != nil {
+		t.Fatalf("Failed to get replaced entry: %v", err)
+	}
+	if got != "replacement" {
+		t.Errorf("Expected replacement entry, got %q", got)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(tmpDir, "*.tmp-*"))
+	if err != nil {
+		t.Fatalf("Failed to glob: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("Expected no temporary files, got %v", matches)
+	}
+}
+
+func TestCacheMemoryLayer(t *testing.T) {
+	t.Run("second get is served from memory", func(t *testing.T) {
+		c := &Cache{dir: t.TempDir(), ttl: time.Hour}
+
+		if err := c.Set("key", "value"); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+
+		// Remove the file behind the cache's back
+		if err := os.Remove(c.filename("key")); err != nil {
+			t.Fatalf("Failed to remove cache file: %v", err)
+		}
+
+		var got string
+		if err := c.Get("key", &got); err != nil {
+			t.Fatalf("Expected value from memory, got error: %v", err)
+		}
+		if got != "value" {
+			t.Errorf("Expected %q, got %q", "value", got)
+		}
+	})
+
+	t.Run("get populates memory", func(t *testing.T) {
+		dir := t.TempDir()
+		writer := &Cache{dir: dir, ttl: time.Hour}
+		if err := writer.Set("key", "value"); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+
+		reader := &Cache{dir: dir, ttl: time.Hour}
+		var got string
+		if err := reader.Get("key", &got); err != nil {
+			t.Fatalf("Failed to get from cache: %v", err)
+		}
+
+		if err := os.Remove(reader.filename("key")); err != nil {
+			t.Fatalf("Failed to remove cache file: %v", err)
+		}
+		got = ""
+		if err := reader.Get("key", &got); err != nil || got != "value" {
+			t.Errorf("Expected value from memory, got %q, %v", got, err)
+		}
+	})
+
+	t.Run("delete and clear remove memory entries", func(t *testing.T) {
+		c := &Cache{dir: t.TempDir(), ttl: time.Hour}
+		var got string
+
+		if err := c.Set("a", "value"); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+		if err := c.Delete("a"); err != nil {
+			t.Fatalf("Failed to delete: %v", err)
+		}
+		if err := c.Get("a", &got); err == nil {
+			t.Error("Expected miss after Delete")
+		}
+
+		if err := c.Set("b", "value"); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+		if err := c.Clear(); err != nil {
+			t.Fatalf("Failed to clear: %v", err)
+		}
+		if err := c.Get("b", &got); err == nil {
+			t.Error("Expected miss after Clear")
+		}
+	})
+
+	t.Run("expired memory entries are not returned", func(t *testing.T) {
+		c := &Cache{dir: t.TempDir(), ttl: 50 * time.Millisecond}
+		if err := c.Set("key", "value"); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+
+		var got string
+		if err := c.Get("key", &got); err == nil {
+			t.Error("Expected expired entry to miss")
+		}
+	})
+
+	t.Run("memory values are independent copies", func(t *testing.T) {
+		c := &Cache{dir: t.TempDir(), ttl: time.Hour}
+		original := []string{"a", "b"}
+		if err := c.Set("key", original); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+		original[0] = "changed"
+
+		var got []string
+		if err := c.Get("key", &got); err != nil {
+			t.Fatalf("Failed to get from cache: %v", err)
+		}
+		if got[0] != "a" {
+			t.Errorf("Expected cached value to be unaffected, got %v", got)
+		}
+	})
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30s", 30 * time.Second, false},
+		{"10m", 10 * time.Minute, false},
+		{"2h", 2 * time.Hour, false},
+		{"0s", 0, false},
+		{"-1m", 0, true},
+		{"soon", 0, true},
+		{"10", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTTL(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTTL(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTTL(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestInitCachesTTL(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+
+	keys := []string{"cache_ttl", "cache_ttl_tasks", "cache_ttl_workspaces"}
+	reset := func() {
+		for _, key := range keys {
+			config.Set(key, "")
+		}
+	}
+	defer reset()
+
+	tests := []struct {
+		name          string
+		settings      map[string]string
+		wantWorkspace time.Duration
+		wantTask      time.Duration
+		wantErr       bool
+	}{
+		{
+			name:          "defaults",
+			wantWorkspace: DefaultWorkspaceTTL,
+			wantTask:      DefaultTaskTTL,
+		},
+		{
+			name:          "per-cache keys",
+			settings:      map[string]string{"cache_ttl_tasks": "30s", "cache_ttl_workspaces": "6h"},
+			wantWorkspace: 6 * time.Hour,
+			wantTask:      30 * time.Second,
+		},
+		{
+			name:          "flag overrides every cache",
+			settings:      map[string]string{"cache_ttl": "2m", "cache_ttl_tasks": "30s"},
+			wantWorkspace: 2 * time.Minute,
+			wantTask:      2 * time.Minute,
+		},
+		{
+			name:     "invalid duration",
+			settings: map[string]string{"cache_ttl_tasks": "often"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			for key, value := range tt.settings {
+				config.Set(key, value)
+			}
+
+			err := InitCaches()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error for invalid TTL")
+				}
+				if !strings.Contains(err.Error(), "cache_ttl_tasks") {
+					t.Errorf("Expected error to name the key, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to init caches: %v", err)
+			}
+
+			if WorkspaceCache.TTL() != tt.wantWorkspace {
+				t.Errorf("WorkspaceCache TTL = %v, want %v", WorkspaceCache.TTL(), tt.wantWorkspace)
+			}
+			if UserCache.TTL() != tt.wantWorkspace {
+				t.Errorf("UserCache TTL = %v, want %v", UserCache.TTL(), tt.wantWorkspace)
+			}
+			if TaskCache.TTL() != tt.wantTask {
+				t.Errorf("TaskCache TTL = %v, want %v", TaskCache.TTL(), tt.wantTask)
+			}
+		})
+	}
+}
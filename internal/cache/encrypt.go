@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/fsutil"
+)
+
+// KeyFileName is the name of the file holding the cache encryption secret
+const KeyFileName = "cache.key"
+
+// errEncryptedEntry is returned when an encrypted entry is read by a cache
+// without an encryption key
+var errEncryptedEntry = errors.New("cache entry is encrypted")
+
+// SetEncryptionKey enables AES-GCM encryption of entry data with a 32-byte key.
+// A nil key disables encryption.
+func (c *Cache) SetEncryptionKey(key []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key == nil {
+		c.aead = nil
+		return nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("invalid cache encryption key: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("failed to create cache cipher: %w", err)
+	}
+
+	c.aead = aead
+	return nil
+}
+
+// encrypt seals data, binding it to the cache key so entries can't be swapped
+func (c *Cache) encrypt(key string, data []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return c.aead.Seal(nonce, nonce, data, []byte(key)), nil
+}
+
+// decrypt opens data sealed by encrypt
+func (c *Cache) decrypt(key string, data []byte) ([]byte, error) {
+	if c.aead == nil {
+		return nil, errEncryptedEntry
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("encrypted cache entry is too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cache entry: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// LoadEncryptionKey derives the cache encryption key from a per-user secret
+// stored in the config directory, creating the secret on first use. The
+// secret file is only readable by the current user.
+func LoadEncryptionKey() ([]byte, error) {
+	path := filepath.Join(config.DefaultConfigDir, KeyFileName)
+
+	data, err := os.ReadFile(path) // #nosec G304 - path is within the config directory
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read cache key: %w", err)
+		}
+
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate cache key: %w", err)
+		}
+		data = []byte(hex.EncodeToString(secret))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return nil, fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := fsutil.WriteFileAtomic(path, data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to save cache key: %w", err)
+		}
+	}
+
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return nil, fmt.Errorf("cache key file %s is empty", path)
+	}
+
+	key := sha256.Sum256([]byte("cu-cache:" + secret))
+	return key[:], nil
+}
@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/timimsms/cu/internal/config"
+)
+
+func testKey() []byte {
+	return bytes.Repeat([]byte{7}, 32)
+}
+
+func TestCacheEncryption(t *testing.T) {
+	type secretData struct {
+		Email string `json:"email"`
+	}
+
+	t.Run("entry is not stored in plaintext", func(t *testing.T) {
+		c := &Cache{dir: t.TempDir(), ttl: time.Hour}
+		if err := c.SetEncryptionKey(testKey()); err != nil {
+			t.Fatalf("Failed to set key: %v", err)
+		}
+
+		original := secretData{Email: "user@example.com"}
+		if err := c.Set("users", original); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+
+		raw, err := os.ReadFile(c.filename("users"))
+		if err != nil {
+			t.Fatalf("Failed to read cache file: %v", err)
+		}
+		if bytes.Contains(raw, []byte("user@example.com")) {
+			t.Errorf("Cache file contains plaintext data: %s", raw)
+		}
+
+		var retrieved secretData
+		if err := c.Get("users", &retrieved); err != nil {
+			t.Fatalf("Failed to get from cache: %v", err)
+		}
+		if retrieved != original {
+			t.Errorf("Retrieved data doesn't match: got %+v, want %+v", retrieved, original)
+		}
+
+		// Expiry stays readable for stats and cleanup
+		stats, err := c.GetStats()
+		if err != nil {
+			t.Fatalf("Failed to get stats: %v", err)
+		}
+		if stats.ValidEntries != 1 {
+			t.Errorf("Expected 1 valid entry, got %d", stats.ValidEntries)
+		}
+	})
+
+	t.Run("encrypted entry without key is a miss", func(t *testing.T) {
+		dir := t.TempDir()
+		encrypted := &Cache{dir: dir, ttl: time.Hour}
+		if err := encrypted.SetEncryptionKey(testKey()); err != nil {
+			t.Fatalf("Failed to set key: %v", err)
+		}
+		if err := encrypted.Set("users", secretData{Email: "user@example.com"}); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+
+		plain := &Cache{dir: dir, ttl: time.Hour}
+		var retrieved secretData
+		if err := plain.Get("users", &retrieved); err == nil {
+			t.Error("Expected Get without key to fail")
+		}
+
+		wrongKey := &Cache{dir: dir, ttl: time.Hour}
+		if err := wrongKey.SetEncryptionKey(bytes.Repeat([]byte{9}, 32)); err != nil {
+			t.Fatalf("Failed to set key: %v", err)
+		}
+		if err := wrongKey.Get("users", &retrieved); err == nil {
+			t.Error("Expected Get with wrong key to fail")
+		}
+	})
+
+	t.Run("entries are bound to their key", func(t *testing.T) {
+		c := &Cache{dir: t.TempDir(), ttl: time.Hour}
+		if err := c.SetEncryptionKey(testKey()); err != nil {
+			t.Fatalf("Failed to set key: %v", err)
+		}
+		if err := c.Set("a", "value"); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+
+		// Copy entry a over entry b
+		raw, err := os.ReadFile(c.filename("a"))
+		if err != nil {
+			t.Fatalf("Failed to read cache file: %v", err)
+		}
+		if err := os.WriteFile(c.filename("b"), raw, 0600); err != nil {
+			t.Fatalf("Failed to write cache file: %v", err)
+		}
+
+		var got string
+		if err := c.Get("b", &got); err == nil {
+			t.Error("Expected swapped entry to fail decryption")
+		}
+	})
+
+	t.Run("plaintext entries remain readable", func(t *testing.T) {
+		dir := t.TempDir()
+		plain := &Cache{dir: dir, ttl: time.Hour}
+		if err := plain.Set("key", "value"); err != nil {
+			t.Fatalf("Failed to set cache: %v", err)
+		}
+
+		encrypted := &Cache{dir: dir, ttl: time.Hour}
+		if err := encrypted.SetEncryptionKey(testKey()); err != nil {
+			t.Fatalf("Failed to set key: %v", err)
+		}
+		var got string
+		if err := encrypted.Get("key", &got); err != nil || got != "value" {
+			t.Errorf("Expected plaintext entry to be readable, got %q, %v", got, err)
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		c := &Cache{dir: t.TempDir(), ttl: time.Hour}
+		if err := c.SetEncryptionKey([]byte("short")); err == nil {
+			t.Error("Expected error for invalid key length")
+		}
+	})
+}
+
+func TestLoadEncryptionKey(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+
+	key, err := LoadEncryptionKey()
+	if err != nil {
+		t.Fatalf("Failed to load key: %v", err)
+	}
+	if len(key) != 32 {
+		t.Errorf("Expected 32-byte key, got %d bytes", len(key))
+	}
+
+	info, err := os.Stat(filepath.Join(config.DefaultConfigDir, KeyFileName))
+	if err != nil {
+		t.Fatalf("Key file not created: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected key file mode 0600, got %v", info.Mode().Perm())
+	}
+
+	// The same secret yields the same key
+	again, err := LoadEncryptionKey()
+	if err != nil {
+		t.Fatalf("Failed to reload key: %v", err)
+	}
+	if !bytes.Equal(key, again) {
+		t.Error("Expected the same key on reload")
+	}
+}
+
+func TestInitCachesEncryption(t *testing.T) {
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+	defer config.Set("cache_encrypt", nil)
+
+	config.Set("cache_encrypt", false)
+	if err := InitCaches(); err != nil {
+		t.Fatalf("Failed to init caches: %v", err)
+	}
+	if WorkspaceCache.aead != nil {
+		t.Error("Expected encryption to be disabled by default")
+	}
+
+	config.Set("cache_encrypt", true)
+	if err := InitCaches(); err != nil {
+		t.Fatalf("Failed to init caches: %v", err)
+	}
+	for _, c := range []*Cache{WorkspaceCache, UserCache, TaskCache} {
+		if c.aead == nil {
+			t.Error("Expected encryption to be enabled")
+		}
+	}
+}
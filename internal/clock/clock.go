@@ -0,0 +1,44 @@
+// Package clock provides the current time to date filtering and relative time
+// formatting, so tests can freeze it.
+package clock
+
+import "time"
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// System is the clock of the operating system
+var System Clock = systemClock{}
+
+// Fixed is a clock frozen at a point in time
+type Fixed time.Time
+
+// Now returns the frozen time
+func (f Fixed) Now() time.Time {
+	return time.Time(f)
+}
+
+// current is the clock returned by Now
+var current = System
+
+// Now returns the current time of the clock in use, the system clock unless a
+// test has replaced it with Set
+func Now() time.Time {
+	return current.Now()
+}
+
+// Set makes Now use c until the returned function is called, which restores
+// the previous clock. It is meant for tests.
+func Set(c Clock) (restore func()) {
+	previous := current
+	current = c
+	return func() { current = previous }
+}
@@ -0,0 +1,19 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	frozen := time.Date(2026, 3, 14, 23, 59, 30, 0, time.UTC)
+
+	restore := Set(Fixed(frozen))
+	assert.Equal(t, frozen, Now())
+	assert.Equal(t, frozen, Now(), "a fixed clock does not advance")
+
+	restore()
+	assert.WithinDuration(t, time.Now(), Now(), time.Second)
+}
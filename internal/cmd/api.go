@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/auth"
+	"github.com/timimsms/cu/internal/cache"
+	"github.com/timimsms/cu/internal/config"
 	"github.com/timimsms/cu/internal/output"
 )
 
@@ -19,6 +22,7 @@ var (
 	apiMethod  string
 	apiData    string
 	apiHeaders []string
+	apiCache   bool
 )
 
 var apiCmd = &cobra.Command{
@@ -55,8 +59,15 @@ Examples:
   # Pass custom headers
   cu api /team -H "X-Custom-Header: value"
 
+  # Reuse GET responses for ten minutes while scripting
+  cu api /team --cache --cache-ttl 10m
+
 The endpoint should be the path after https://api.clickup.com/api/v2/
-For example, use "/team" for https://api.clickup.com/api/v2/team`,
+For example, use "/team" for https://api.clickup.com/api/v2/team
+
+With --cache, successful GET responses are stored in the task cache and reused
+until they expire (5 minutes by default, see --cache-ttl). Other methods are
+never cached.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		endpoint := args[0]
@@ -109,32 +120,33 @@ For example, use "/team" for https://api.clickup.com/api/v2/team`,
 			}
 		}
 
+		// Only GET responses are cached
+		var respCache apiResponseCache
+		if apiCache && req.Method == http.MethodGet {
+			if cache.TaskCache == nil {
+				if err := cache.InitCaches(); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: response cache unavailable: %v\n", err)
+				}
+			}
+			if cache.TaskCache != nil {
+				respCache = cache.TaskCache
+			}
+		}
+
 		// Make request
 		client := &http.Client{
 			Timeout: 30 * time.Second,
 		}
 
-		resp, err := client.Do(req)
+		status, respBody, err := doAPIRequest(client, req, respCache, token.CacheScope())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Request failed: %v\n", err)
-			os.Exit(1)
-		}
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error closing response body: %v\n", err)
-			}
-		}()
-
-		// Read response
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to read response: %v\n", err)
+			fmt.Fprintf(os.Stderr, "%v\n", err)
 			os.Exit(1)
 		}
 
 		// Check for non-2xx status codes
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			fmt.Fprintf(os.Stderr, "API request failed with status %d: %s\n", resp.StatusCode, resp.Status)
+		if status < 200 || status >= 300 {
+			fmt.Fprintf(os.Stderr, "API request failed with status %d: %d %s\n", status, status, http.StatusText(status))
 
 			// Try to parse error response
 			var errResp map[string]interface{}
@@ -172,14 +184,80 @@ For example, use "/team" for https://api.clickup.com/api/v2/team`,
 	},
 }
 
-// getAuthToken is a variable to make auth testable
+// apiResponseCache stores raw API responses
+type apiResponseCache interface {
+	Get(key string, dest interface{}) error
+	Set(key string, value interface{}) error
+}
+
+// cachedAPIResponse is a successful response body kept in the cache
+type cachedAPIResponse struct {
+	Body string `json:"body"`
+}
+
+// doAPIRequest sends req and returns the response status and body. When
+// respCache is set, GET requests with a fresh cached body are answered
+// without being sent and successful GET responses are stored for later calls.
+// scope keeps the cached responses of different accounts apart.
+func doAPIRequest(client *http.Client, req *http.Request, respCache apiResponseCache, scope string) (int, []byte, error) {
+	if req.Method != http.MethodGet {
+		respCache = nil
+	}
+
+	var key string
+	if respCache != nil {
+		key = apiCacheKey(scope, req.URL)
+		var cached cachedAPIResponse
+		if err := respCache.Get(key, &cached); err == nil {
+			return http.StatusOK, []byte(cached.Body), nil
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error closing response body: %v\n", err)
+		}
+	}()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if respCache != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_ = respCache.Set(key, cachedAPIResponse{Body: string(body)})
+	}
+
+	return resp.StatusCode, body, nil
+}
+
+// apiCacheKey identifies a GET request by the account's cache scope and the
+// request's path and query, with query parameters sorted so equivalent
+// requests share an entry
+func apiCacheKey(scope string, u *url.URL) string {
+	key := "api:" + scope + ":" + u.Path
+	if query := u.Query().Encode(); query != "" {
+		key += "?" + query
+	}
+	return key
+}
+
+// getAuthToken is a variable to make auth testable. It resolves the token
+// the same way the API client does, so CU_TOKEN and default_workspace apply.
 var getAuthToken = func() (*auth.Token, error) {
 	authMgr := auth.NewManager()
-	return authMgr.GetCurrentToken()
+	token, _, err := authMgr.ResolveToken(config.GetString("default_workspace"))
+	return token, err
 }
 
 func init() {
 	apiCmd.Flags().StringVarP(&apiMethod, "method", "X", "GET", "HTTP method (GET, POST, PUT, PATCH, DELETE)")
 	apiCmd.Flags().StringVarP(&apiData, "data", "d", "", "Request body data (JSON)")
+	apiCmd.Flags().BoolVar(&apiCache, "cache", false, "Serve GET requests from the cache when a fresh response is available")
 	apiCmd.Flags().StringArrayVarP(&apiHeaders, "header", "H", []string{}, "Custom headers (format: 'Header: value')")
 }
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+
+	"github.com/timimsms/cu/internal/auth"
 )
 
 func TestAPICommand(t *testing.T) {
@@ -130,3 +136,107 @@ func TestHeaderParsing(t *testing.T) {
 		})
 	}
 }
+
+// memoryAPICache is an in-memory apiResponseCache
+type memoryAPICache struct {
+	entries map[string]cachedAPIResponse
+}
+
+func (c *memoryAPICache) Get(key string, dest interface{}) error {
+	entry, ok := c.entries[key]
+	if !ok {
+		return fmt.Errorf("cache miss: %s", key)
+	}
+	*dest.(*cachedAPIResponse) = entry
+	return nil
+}
+
+func (c *memoryAPICache) Set(key string, value interface{}) error {
+	c.entries[key] = value.(cachedAPIResponse)
+	return nil
+}
+
+func TestDoAPIRequestCache(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprintf(w, `{"hit":%d}`, hits)
+	}))
+	defer server.Close()
+
+	t.Run("second GET is served from cache", func(t *testing.T) {
+		hits = 0
+		respCache := &memoryAPICache{entries: map[string]cachedAPIResponse{}}
+
+		for i := 0; i < 2; i++ {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/team?b=2&a=1", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			status, body, err := doAPIRequest(server.Client(), req, respCache, "work:abc")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != http.StatusOK || string(body) != `{"hit":1}` {
+				t.Errorf("request %d: got %d %s, want 200 {\"hit\":1}", i, status, body)
+			}
+		}
+
+		if hits != 1 {
+			t.Errorf("expected 1 request to reach the server, got %d", hits)
+		}
+		if _, ok := respCache.entries["api:work:abc:/team?a=1&b=2"]; !ok {
+			t.Errorf("expected normalized cache key, got %v", respCache.entries)
+		}
+	})
+
+	t.Run("POST is never cached", func(t *testing.T) {
+		hits = 0
+		respCache := &memoryAPICache{entries: map[string]cachedAPIResponse{}}
+
+		for i := 0; i < 2; i++ {
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/list/1/task", strings.NewReader(`{}`))
+			if _, _, err := doAPIRequest(server.Client(), req, respCache, "work:abc"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if hits != 2 {
+			t.Errorf("expected 2 requests to reach the server, got %d", hits)
+		}
+		if len(respCache.entries) != 0 {
+			t.Errorf("expected nothing cached, got %v", respCache.entries)
+		}
+	})
+}
+
+func TestAPICacheKey(t *testing.T) {
+	a, _ := url.Parse("https://api.clickup.com/api/v2/list/1/task?page=0&archived=false")
+	b, _ := url.Parse("https://api.clickup.com/api/v2/list/1/task?archived=false&page=0")
+	if apiCacheKey("work:abc", a) != apiCacheKey("work:abc", b) {
+		t.Errorf("expected equal keys, got %q and %q", apiCacheKey("work:abc", a), apiCacheKey("work:abc", b))
+	}
+
+	c, _ := url.Parse("https://api.clickup.com/api/v2/team")
+	if got := apiCacheKey("work:abc", c); got != "api:work:abc:/api/v2/team" {
+		t.Errorf("unexpected key %q", got)
+	}
+
+	// Another account never shares an entry
+	first := &auth.Token{Value: "pk_first", Workspace: "work"}
+	second := &auth.Token{Value: "pk_second", Workspace: "work"}
+	if apiCacheKey(first.CacheScope(), c) == apiCacheKey(second.CacheScope(), c) {
+		t.Errorf("expected different keys for different tokens, got %q", apiCacheKey(first.CacheScope(), c))
+	}
+}
+
+func TestGetAuthTokenFromEnv(t *testing.T) {
+	t.Setenv(auth.TokenEnv, "pk_from_env")
+
+	token, err := getAuthToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Value != "pk_from_env" {
+		t.Errorf("expected the CU_TOKEN value, got %q", token.Value)
+	}
+}
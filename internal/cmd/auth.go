@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/api"
 	"github.com/timimsms/cu/internal/auth"
 	"github.com/timimsms/cu/internal/config"
 )
@@ -24,6 +29,7 @@ var authLoginCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		token, _ := cmd.Flags().GetString("token")
 		workspace, _ := cmd.Flags().GetString("workspace")
+		expiresIn, _ := cmd.Flags().GetDuration("expires-in")
 
 		authMgr := auth.NewManager()
 
@@ -32,12 +38,16 @@ var authLoginCmd = &cobra.Command{
 			authToken := &auth.Token{
 				Value:     token,
 				Workspace: workspace,
+				ExpiresAt: tokenExpiry(expiresIn),
 			}
 
 			if err := authMgr.SaveToken(workspace, authToken); err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to save token: %v\n", err)
 				os.Exit(1)
 			}
+			if err := auth.RememberWorkspace(workspace); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to record workspace: %v\n", err)
+			}
 
 			fmt.Println("Successfully authenticated!")
 			return
@@ -65,12 +75,16 @@ var authLoginCmd = &cobra.Command{
 		authToken := &auth.Token{
 			Value:     tokenInput,
 			Workspace: workspace,
+			ExpiresAt: tokenExpiry(expiresIn),
 		}
 
 		if err := authMgr.SaveToken(workspace, authToken); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to save token: %v\n", err)
 			os.Exit(1)
 		}
+		if err := auth.RememberWorkspace(workspace); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record workspace: %v\n", err)
+		}
 
 		// Save workspace as default if it's the first one
 		if workspace != "" && workspace != auth.DefaultWorkspace {
@@ -89,27 +103,95 @@ var authLoginCmd = &cobra.Command{
 var authStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show authentication status",
-	Long:  `Display the current authentication status and user information.`,
+	Long: `Display the current authentication status and user information.
+
+The workspace whose token is used and where that token comes from are shown:
+the CU_TOKEN environment variable, the system keychain or the credentials
+file. The token itself is never printed.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		authMgr := auth.NewManager()
-		workspace := config.GetString("default_workspace")
+		status, err := runAuthStatus(auth.NewManager(), config.GetString("default_workspace"))
+		if err != nil {
+			fmt.Println("Not authenticated")
+			fmt.Println("\nRun 'cu auth login' to authenticate")
+			os.Exit(1)
+		}
+
+		printAuthStatus(os.Stdout, status)
+	},
+}
+
+var authRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Re-validate the stored token",
+	Long: `Re-validate the stored token against the ClickUp API and update its metadata.
+
+The email of the authenticated user is refreshed and the expiry is reset. ClickUp
+personal tokens do not expire on their own; use --expires-in to have cu ask you to
+re-validate the token after a period of time.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		workspace, _ := cmd.Flags().GetString("workspace")
+		expiresIn, _ := cmd.Flags().GetDuration("expires-in")
 		if workspace == "" {
-			workspace = auth.DefaultWorkspace
+			workspace = config.GetString("default_workspace")
+			if workspace == "" {
+				workspace = auth.DefaultWorkspace
+			}
 		}
 
-		token, err := authMgr.GetToken(workspace)
+		authMgr := auth.NewManager()
+		token, err := refreshToken(cmd.Context(), authMgr, workspace, expiresIn)
 		if err != nil {
-			fmt.Println("Not authenticated")
-			fmt.Println("\nRun 'cu auth login' to authenticate")
+			fmt.Fprintf(os.Stderr, "Failed to refresh token: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Token for workspace %s is valid\n", workspace)
+		if token.Email != "" {
+			fmt.Printf("Email: %s\n", token.Email)
+		}
+		if !token.ExpiresAt.IsZero() {
+			fmt.Printf("Expires: %s\n", token.ExpiresAt.Local().Format("2006-01-02 15:04"))
+		}
+	},
+}
+
+var authImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a token from an environment variable or file",
+	Long: `Import an existing personal API token from an environment variable or a file.
+
+The token is validated against the ClickUp API before it is stored. It is never
+printed.`,
+	Example: `  cu auth import --from-env CU_TOKEN
+  cu auth import --file token.txt --workspace work`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fromEnv, _ := cmd.Flags().GetString("from-env")
+		file, _ := cmd.Flags().GetString("file")
+		workspace, _ := cmd.Flags().GetString("workspace")
+
+		value, err := readImportToken(fromEnv, file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		authMgr := auth.NewManager()
+		token, err := importToken(cmd.Context(), authMgr, workspace, value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to import token: %v\n", err)
 			os.Exit(1)
 		}
+		if err := auth.RememberWorkspace(workspace); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record workspace: %v\n", err)
+		}
 
-		fmt.Println("Authenticated")
-		fmt.Printf("Workspace: %s\n", workspace)
+		if workspace == "" {
+			workspace = auth.DefaultWorkspace
+		}
+		fmt.Printf("Token imported for workspace: %s\n", workspace)
 		if token.Email != "" {
 			fmt.Printf("Email: %s\n", token.Email)
 		}
-		fmt.Println("\nToken stored securely in system keychain")
 	},
 }
 
@@ -131,18 +213,174 @@ var authLogoutCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Failed to logout: %v\n", err)
 			os.Exit(1)
 		}
+		if err := auth.ForgetWorkspace(workspace); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update recorded workspaces: %v\n", err)
+		}
 
 		fmt.Printf("Successfully logged out from workspace: %s\n", workspace)
 	},
 }
 
+// validateToken checks a token against the ClickUp API and returns the
+// authenticated user. It is a variable so tests can avoid network calls.
+var validateToken = func(ctx context.Context, token *auth.Token) (*clickup.User, error) {
+	return api.NewClientWithToken(token).GetCurrentUser(ctx)
+}
+
+// refreshToken re-validates the stored token for a workspace and saves its
+// updated metadata. The expiry is cleared unless expiresIn is positive.
+func refreshToken(ctx context.Context, authMgr *auth.Manager, workspace string, expiresIn time.Duration) (*auth.Token, error) {
+	token, err := authMgr.GetToken(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := validateToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("token is no longer valid, run 'cu auth login': %w", err)
+	}
+
+	if user != nil && user.Email != "" {
+		token.Email = user.Email
+	}
+	token.ExpiresAt = tokenExpiry(expiresIn)
+
+	if err := authMgr.SaveToken(workspace, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// readImportToken reads a token from exactly one of an environment variable
+// or a file
+func readImportToken(fromEnv, file string) (string, error) {
+	switch {
+	case fromEnv != "" && file != "":
+		return "", fmt.Errorf("--from-env and --file cannot be used together")
+	case fromEnv != "":
+		value := strings.TrimSpace(os.Getenv(fromEnv))
+		if value == "" {
+			return "", fmt.Errorf("environment variable %s is not set or empty", fromEnv)
+		}
+		return value, nil
+	case file != "":
+		data, err := os.ReadFile(file) // #nosec G304 - user-specified token file
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "" {
+			return "", fmt.Errorf("token file %s is empty", file)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("specify a token source with --from-env or --file")
+	}
+}
+
+// importToken validates a token and stores it for a workspace
+func importToken(ctx context.Context, authMgr *auth.Manager, workspace, value string) (*auth.Token, error) {
+	token := &auth.Token{
+		Value:     value,
+		Workspace: workspace,
+	}
+
+	user, err := validateToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("token validation failed: %w", err)
+	}
+	if user != nil {
+		token.Email = user.Email
+	}
+
+	if err := authMgr.SaveToken(workspace, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// authStatus describes the token cu uses for API calls
+type authStatus struct {
+	Workspace string
+	Source    auth.TokenSource
+	Email     string
+	ExpiresAt time.Time
+	Expired   bool
+}
+
+// runAuthStatus reports the token cu uses for API calls without exposing it.
+// workspace is the default_workspace setting.
+func runAuthStatus(authMgr *auth.Manager, workspace string) (*authStatus, error) {
+	token, source, err := authMgr.ResolveToken(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authStatus{
+		Workspace: token.Workspace,
+		Source:    source,
+		Email:     token.Email,
+		ExpiresAt: token.ExpiresAt,
+		Expired:   token.IsExpired(),
+	}, nil
+}
+
+func printAuthStatus(w io.Writer, status *authStatus) {
+	fmt.Fprintln(w, "Authenticated")
+	if status.Workspace != "" {
+		fmt.Fprintf(w, "Workspace: %s\n", status.Workspace)
+	}
+	if status.Email != "" {
+		fmt.Fprintf(w, "Email: %s\n", status.Email)
+	}
+	if !status.ExpiresAt.IsZero() {
+		expiry := status.ExpiresAt.Local().Format("2006-01-02 15:04")
+		if status.Expired {
+			fmt.Fprintf(w, "Expired: %s (run 'cu auth refresh')\n", expiry)
+		} else {
+			fmt.Fprintf(w, "Expires: %s\n", expiry)
+		}
+	}
+
+	switch status.Source {
+	case auth.SourceEnv:
+		fmt.Fprintf(w, "\nToken read from the %s environment variable\n", auth.TokenEnv)
+	case auth.SourceKeychain:
+		fmt.Fprintln(w, "\nToken stored securely in system keychain")
+	case auth.SourceFile:
+		fmt.Fprintf(w, "\nToken stored in credentials file (%s)\n", auth.DefaultCredentialsPath())
+	}
+}
+
+// tokenExpiry returns the expiry for a token valid for d, or the zero time
+// when d is not positive
+func tokenExpiry(d time.Duration) time.Time {
+	if d <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(d)
+}
+
 func init() {
 	authLoginCmd.Flags().StringP("token", "t", "", "Personal API token")
 	authLoginCmd.Flags().StringP("workspace", "w", "", "Workspace name")
 
+	authLoginCmd.Flags().Duration("expires-in", 0, "Require re-validation after this duration (e.g. 720h)")
+
+	authRefreshCmd.Flags().StringP("workspace", "w", "", "Workspace to refresh")
+	authRefreshCmd.Flags().Duration("expires-in", 0, "Require re-validation after this duration (e.g. 720h)")
+
+	authImportCmd.Flags().String("from-env", "", "Environment variable holding the token")
+	authImportCmd.Flags().String("file", "", "File containing the token")
+	authImportCmd.Flags().StringP("workspace", "w", "", "Workspace name")
+
 	authLogoutCmd.Flags().StringP("workspace", "w", "", "Workspace to logout from")
 
 	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authRefreshCmd)
+	authCmd.AddCommand(authImportCmd)
 	authCmd.AddCommand(authLogoutCmd)
 }
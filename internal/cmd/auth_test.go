@@ -1,9 +1,19 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/auth"
+	"github.com/timimsms/cu/internal/auth/mock"
 )
 
 func TestAuthCommand_Structure(t *testing.T) {
@@ -27,7 +37,7 @@ func TestAuthCommand_Structure(t *testing.T) {
 		}
 
 		// Check for expected subcommands
-		expectedSubcommands := []string{"login", "logout", "status"}
+		expectedSubcommands := []string{"login", "logout", "status", "refresh", "import"}
 		for _, expected := range expectedSubcommands {
 			assert.True(t, subcommandNames[expected], "Expected subcommand '%s' to exist", expected)
 		}
@@ -68,3 +78,205 @@ func TestAuthCommand_Structure(t *testing.T) {
 		assert.NotNil(t, cmd.Run)
 	})
 }
+
+func TestRefreshToken(t *testing.T) {
+	oldValidate := validateToken
+	defer func() { validateToken = oldValidate }()
+
+	t.Run("valid token updates metadata", func(t *testing.T) {
+		validateToken = func(ctx context.Context, token *auth.Token) (*clickup.User, error) {
+			return &clickup.User{Email: "user@example.com"}, nil
+		}
+
+		authMgr := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+		require.NoError(t, authMgr.SaveToken("prod", &auth.Token{
+			Value:     mock.ValidToken,
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}))
+
+		token, err := refreshToken(context.Background(), authMgr, "prod", 0)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", token.Email)
+		assert.False(t, token.IsExpired())
+		assert.True(t, token.ExpiresAt.IsZero())
+
+		stored, err := authMgr.GetToken("prod")
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", stored.Email)
+		assert.False(t, stored.IsExpired())
+	})
+
+	t.Run("expires in sets new expiry", func(t *testing.T) {
+		validateToken = func(ctx context.Context, token *auth.Token) (*clickup.User, error) {
+			return &clickup.User{}, nil
+		}
+
+		authMgr := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+		require.NoError(t, authMgr.SaveToken("prod", &auth.Token{Value: mock.ValidToken, Email: "old@example.com"}))
+
+		token, err := refreshToken(context.Background(), authMgr, "prod", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, "old@example.com", token.Email)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+	})
+
+	t.Run("invalid token is left untouched", func(t *testing.T) {
+		validateToken = func(ctx context.Context, token *auth.Token) (*clickup.User, error) {
+			return nil, errors.New("401 unauthorized")
+		}
+
+		expires := time.Now().Add(-time.Hour).Truncate(time.Second)
+		authMgr := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+		require.NoError(t, authMgr.SaveToken("prod", &auth.Token{Value: mock.ValidToken, ExpiresAt: expires}))
+
+		_, err := refreshToken(context.Background(), authMgr, "prod", 0)
+		assert.ErrorContains(t, err, "cu auth login")
+
+		stored, err := authMgr.GetToken("prod")
+		require.NoError(t, err)
+		assert.True(t, stored.IsExpired())
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		authMgr := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+
+		_, err := refreshToken(context.Background(), authMgr, "prod", 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestTokenExpiry(t *testing.T) {
+	assert.True(t, tokenExpiry(0).IsZero())
+	assert.True(t, tokenExpiry(-time.Hour).IsZero())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), tokenExpiry(time.Hour), time.Minute)
+}
+
+func TestReadImportToken(t *testing.T) {
+	t.Run("from env", func(t *testing.T) {
+		t.Setenv("CU_TEST_IMPORT_TOKEN", "  "+mock.ValidToken+"\n")
+
+		value, err := readImportToken("CU_TEST_IMPORT_TOKEN", "")
+		require.NoError(t, err)
+		assert.Equal(t, mock.ValidToken, value)
+	})
+
+	t.Run("empty env", func(t *testing.T) {
+		t.Setenv("CU_TEST_IMPORT_TOKEN", "")
+
+		_, err := readImportToken("CU_TEST_IMPORT_TOKEN", "")
+		assert.ErrorContains(t, err, "CU_TEST_IMPORT_TOKEN")
+	})
+
+	t.Run("from file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token.txt")
+		require.NoError(t, os.WriteFile(path, []byte(mock.ValidToken+"\n"), 0600))
+
+		value, err := readImportToken("", path)
+		require.NoError(t, err)
+		assert.Equal(t, mock.ValidToken, value)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token.txt")
+		require.NoError(t, os.WriteFile(path, []byte("\n"), 0600))
+
+		_, err := readImportToken("", path)
+		assert.ErrorContains(t, err, "empty")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readImportToken("", filepath.Join(t.TempDir(), "missing.txt"))
+		assert.ErrorContains(t, err, "failed to read token file")
+	})
+
+	t.Run("no source", func(t *testing.T) {
+		_, err := readImportToken("", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("both sources", func(t *testing.T) {
+		_, err := readImportToken("CU_TOKEN", "token.txt")
+		assert.Error(t, err)
+	})
+}
+
+func TestImportToken(t *testing.T) {
+	oldValidate := validateToken
+	defer func() { validateToken = oldValidate }()
+
+	t.Run("valid token is stored", func(t *testing.T) {
+		validateToken = func(ctx context.Context, token *auth.Token) (*clickup.User, error) {
+			assert.Equal(t, mock.ValidToken, token.Value)
+			return &clickup.User{Email: "user@example.com"}, nil
+		}
+
+		authMgr := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+
+		token, err := importToken(context.Background(), authMgr, "work", mock.ValidToken)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", token.Email)
+
+		stored, err := authMgr.GetToken("work")
+		require.NoError(t, err)
+		assert.Equal(t, mock.ValidToken, stored.Value)
+		assert.Equal(t, "work", stored.Workspace)
+		assert.Equal(t, "user@example.com", stored.Email)
+	})
+
+	t.Run("invalid token is not stored", func(t *testing.T) {
+		validateToken = func(ctx context.Context, token *auth.Token) (*clickup.User, error) {
+			return nil, errors.New("401 unauthorized")
+		}
+
+		authMgr := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+
+		_, err := importToken(context.Background(), authMgr, "work", mock.InvalidToken)
+		assert.ErrorContains(t, err, "token validation failed")
+		assert.NotContains(t, err.Error(), mock.InvalidToken)
+		assert.False(t, authMgr.IsAuthenticated("work"))
+	})
+}
+
+func TestAuthStatus(t *testing.T) {
+	t.Run("stored token", func(t *testing.T) {
+		t.Setenv(auth.TokenEnv, "")
+		authMgr := auth.NewManagerWithKeyring(auth.NewFileKeyring(filepath.Join(t.TempDir(), auth.CredentialsFileName)))
+		require.NoError(t, authMgr.SaveToken("", &auth.Token{Value: mock.ValidToken, Email: mock.TestEmail}))
+
+		status, err := runAuthStatus(authMgr, "")
+		require.NoError(t, err)
+		assert.Equal(t, auth.SourceFile, status.Source)
+
+		var buf bytes.Buffer
+		printAuthStatus(&buf, status)
+		assert.Contains(t, buf.String(), "Workspace: default")
+		assert.Contains(t, buf.String(), "Email: "+mock.TestEmail)
+		assert.Contains(t, buf.String(), "Token stored in credentials file")
+		assert.NotContains(t, buf.String(), mock.ValidToken)
+	})
+
+	t.Run("environment token", func(t *testing.T) {
+		t.Setenv(auth.TokenEnv, mock.ValidToken)
+		authMgr := auth.NewManagerWithKeyring(mock.NewKeyringMock())
+
+		status, err := runAuthStatus(authMgr, "")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		printAuthStatus(&buf, status)
+		assert.Contains(t, buf.String(), "Token read from the CU_TOKEN environment variable")
+		assert.NotContains(t, buf.String(), mock.ValidToken)
+	})
+
+	t.Run("keychain", func(t *testing.T) {
+		var buf bytes.Buffer
+		printAuthStatus(&buf, &authStatus{Workspace: "work", Source: auth.SourceKeychain})
+		assert.Equal(t, "Authenticated\nWorkspace: work\n\nToken stored securely in system keychain\n", buf.String())
+	})
+
+	t.Run("not authenticated", func(t *testing.T) {
+		t.Setenv(auth.TokenEnv, "")
+		_, err := runAuthStatus(auth.NewManagerWithKeyring(mock.NewKeyringMock()), "")
+		assert.Error(t, err)
+	})
+}
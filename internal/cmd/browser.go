@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// openURL opens a URL in the default browser. It is a variable so tests can
+// replace it.
+var openURL = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
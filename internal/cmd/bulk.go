@@ -3,19 +3,29 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/config"
 	"github.com/timimsms/cu/internal/output"
 )
 
 var bulkCmd = &cobra.Command{
 	Use:   "bulk",
 	Short: "Perform bulk operations on tasks",
-	Long:  `Perform bulk operations on multiple tasks at once.`,
+	Long: `Perform bulk operations on multiple tasks at once.
+
+With --output json or yaml, bulk commands print a result for each task,
+such as {"results": [{"id": "x", "ok": true}, {"id": "y", "ok": false,
+"error": "..."}], "success": 1, "failed": 1}. Progress lines then go to
+stderr.`,
 }
 
 var bulkUpdateCmd = &cobra.Command{
@@ -33,23 +43,13 @@ Examples:
   # Add assignee to multiple tasks
   cu bulk update task1 task2 --add-assignee @john`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Get task IDs from args or stdin
-		taskIDs := args
-		if len(taskIDs) == 0 {
-			// Read from stdin
-			scanner := bufio.NewScanner(os.Stdin)
-			for scanner.Scan() {
-				line := strings.TrimSpace(scanner.Text())
-				if line != "" {
-					taskIDs = append(taskIDs, line)
-				}
-			}
-			if err := scanner.Err(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
-				os.Exit(1)
-			}
+		taskIDs, err := readBulkTaskIDs(os.Stdin, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
+			os.Exit(1)
 		}
 
 		if len(taskIDs) == 0 {
@@ -80,42 +80,49 @@ Examples:
 			os.Exit(1)
 		}
 
+		format := cmd.Flag("output").Value.String()
+		progress := bulkProgressWriter(format)
+
 		// Show what will be updated
-		fmt.Printf("Updating %d task(s):\n", len(taskIDs))
+		fmt.Fprintf(progress, "Updating %d task(s):\n", len(taskIDs))
 		if status != "" {
-			fmt.Printf("  Status: %s\n", status)
+			fmt.Fprintf(progress, "  Status: %s\n", status)
 		}
 		if priority != "" {
-			fmt.Printf("  Priority: %s\n", priority)
+			fmt.Fprintf(progress, "  Priority: %s\n", priority)
 		}
 		if len(tags) > 0 {
-			fmt.Printf("  Tags: %s\n", strings.Join(tags, ", "))
+			fmt.Fprintf(progress, "  Tags: %s\n", strings.Join(tags, ", "))
 		}
 		if len(addAssignees) > 0 {
-			fmt.Printf("  Add assignees: %s\n", strings.Join(addAssignees, ", "))
+			fmt.Fprintf(progress, "  Add assignees: %s\n", strings.Join(addAssignees, ", "))
 		}
 		if len(removeAssignees) > 0 {
-			fmt.Printf("  Remove assignees: %s\n", strings.Join(removeAssignees, ", "))
+			fmt.Fprintf(progress, "  Remove assignees: %s\n", strings.Join(removeAssignees, ", "))
 		}
 
 		if dryRun {
-			fmt.Println("\nDry run - no changes will be made")
-			fmt.Printf("Would update tasks: %s\n", strings.Join(taskIDs, ", "))
+			fmt.Fprintln(progress, "\nDry run - no changes will be made")
+			fmt.Fprintf(progress, "Would update tasks: %s\n", strings.Join(taskIDs, ", "))
 			return
 		}
 
 		// Confirmation prompt unless --yes flag is set
 		yes, _ := cmd.Flags().GetBool("yes")
 		if !yes {
-			fmt.Printf("\nAre you sure you want to update %d task(s)? [y/N] ", len(taskIDs))
-			var response string
-			_, _ = fmt.Scanln(&response)
-			if strings.ToLower(response) != "y" {
+			confirmed, err := output.Confirm(fmt.Sprintf("\nAre you sure you want to update %d task(s)?", len(taskIDs)))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			if !confirmed {
 				fmt.Println("Cancelled")
 				return
 			}
 		}
 
+		initCaches()
+
 		// Create API client
 		client, err := api.NewClient()
 		if err != nil {
@@ -123,27 +130,31 @@ Examples:
 			os.Exit(1)
 		}
 
+		// Resolve assignee names once rather than for every task
+		if updateOpts.AddAssignees, err = client.ResolveAssignees(ctx, addAssignees); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid assignee: %v\n", err)
+			os.Exit(1)
+		}
+		if updateOpts.RemoveAssignees, err = client.ResolveAssignees(ctx, removeAssignees); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid assignee: %v\n", err)
+			os.Exit(1)
+		}
+
 		// Update tasks
-		var successCount, errorCount int
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
 
-		fmt.Println("\nUpdating tasks...")
-		for _, taskID := range taskIDs {
+		fmt.Fprintln(progress, "\nUpdating tasks...")
+		result := runBulkTasks(ctx, progress, taskIDs, failFast, func(ctx context.Context, taskID string) error {
 			_, err := client.UpdateTask(ctx, taskID, updateOpts)
-			if err != nil {
-				errorCount++
-				fmt.Printf("  ✗ %s: %v\n", taskID, err)
-			} else {
-				successCount++
-				fmt.Printf("  ✓ %s\n", taskID)
-			}
-		}
+			return err
+		})
 
-		// Summary
-		fmt.Printf("\nSummary:\n")
-		fmt.Printf("  Success: %d\n", successCount)
-		fmt.Printf("  Failed:  %d\n", errorCount)
+		if err := printBulkSummary(os.Stdout, format, "Success", result); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			os.Exit(1)
+		}
 
-		if errorCount > 0 {
+		if result.Failed > 0 || result.Cancelled {
 			os.Exit(1)
 		}
 	},
@@ -161,23 +172,13 @@ Examples:
   # Close tasks from a file
   cat completed-tasks.txt | cu bulk close`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Get task IDs from args or stdin
-		taskIDs := args
-		if len(taskIDs) == 0 {
-			// Read from stdin
-			scanner := bufio.NewScanner(os.Stdin)
-			for scanner.Scan() {
-				line := strings.TrimSpace(scanner.Text())
-				if line != "" {
-					taskIDs = append(taskIDs, line)
-				}
-			}
-			if err := scanner.Err(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
-				os.Exit(1)
-			}
+		taskIDs, err := readBulkTaskIDs(os.Stdin, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
+			os.Exit(1)
 		}
 
 		if len(taskIDs) == 0 {
@@ -188,10 +189,12 @@ Examples:
 		// Confirmation prompt unless --yes flag is set
 		yes, _ := cmd.Flags().GetBool("yes")
 		if !yes {
-			fmt.Printf("Are you sure you want to close %d task(s)? [y/N] ", len(taskIDs))
-			var response string
-			_, _ = fmt.Scanln(&response)
-			if strings.ToLower(response) != "y" {
+			confirmed, err := output.Confirm(fmt.Sprintf("Are you sure you want to close %d task(s)?", len(taskIDs)))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			if !confirmed {
 				fmt.Println("Cancelled")
 				return
 			}
@@ -209,26 +212,96 @@ Examples:
 			Status: "complete",
 		}
 
-		var successCount, errorCount int
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		format := cmd.Flag("output").Value.String()
+		progress := bulkProgressWriter(format)
 
-		fmt.Println("Closing tasks...")
-		for _, taskID := range taskIDs {
+		fmt.Fprintln(progress, "Closing tasks...")
+		result := runBulkTasks(ctx, progress, taskIDs, failFast, func(ctx context.Context, taskID string) error {
 			_, err := client.UpdateTask(ctx, taskID, updateOpts)
+			return err
+		})
+
+		if err := printBulkSummary(os.Stdout, format, "Success", result); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			os.Exit(1)
+		}
+
+		if result.Failed > 0 || result.Cancelled {
+			os.Exit(1)
+		}
+	},
+}
+
+var bulkReopenCmd = &cobra.Command{
+	Use:   "reopen [task-ids...]",
+	Short: "Reopen multiple tasks",
+	Long: `Reopen multiple tasks at once. Each task moves to --status, which must be
+one of its list's open statuses, or to its list's first open status.
+
+Examples:
+  # Reopen multiple tasks
+  cu bulk reopen task1 task2 task3
+
+  # Preview the status each task would move to
+  cat reopen.txt | cu bulk reopen --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		taskIDs, err := readBulkTaskIDs(os.Stdin, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(taskIDs) == 0 {
+			fmt.Fprintln(os.Stderr, "No task IDs provided")
+			os.Exit(1)
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// Confirmation prompt unless --yes or --dry-run is set
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes && !dryRun {
+			confirmed, err := output.Confirm(fmt.Sprintf("Are you sure you want to reopen %d task(s)?", len(taskIDs)))
 			if err != nil {
-				errorCount++
-				fmt.Printf("  ✗ %s: %v\n", taskID, err)
-			} else {
-				successCount++
-				fmt.Printf("  ✓ %s\n", taskID)
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			if !confirmed {
+				fmt.Println("Cancelled")
+				return
 			}
 		}
 
-		// Summary
-		fmt.Printf("\nSummary:\n")
-		fmt.Printf("  Success: %d\n", successCount)
-		fmt.Printf("  Failed:  %d\n", errorCount)
+		// Create API client
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		format := cmd.Flag("output").Value.String()
+		progress := bulkProgressWriter(format)
 
-		if errorCount > 0 {
+		label := "Success"
+		if dryRun {
+			label = "Would reopen"
+			fmt.Fprintln(progress, "Dry run - no changes will be made")
+		} else {
+			fmt.Fprintln(progress, "Reopening tasks...")
+		}
+		result := runBulkReopen(ctx, progress, client, taskIDs, status, dryRun, failFast)
+
+		if err := printBulkSummary(os.Stdout, format, label, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			os.Exit(1)
+		}
+
+		if result.Failed > 0 || result.Cancelled {
 			os.Exit(1)
 		}
 	},
@@ -246,23 +319,13 @@ Examples:
   # Delete tasks from a file
   cat obsolete-tasks.txt | cu bulk delete --yes`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Get task IDs from args or stdin
-		taskIDs := args
-		if len(taskIDs) == 0 {
-			// Read from stdin
-			scanner := bufio.NewScanner(os.Stdin)
-			for scanner.Scan() {
-				line := strings.TrimSpace(scanner.Text())
-				if line != "" {
-					taskIDs = append(taskIDs, line)
-				}
-			}
-			if err := scanner.Err(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
-				os.Exit(1)
-			}
+		taskIDs, err := readBulkTaskIDs(os.Stdin, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
+			os.Exit(1)
 		}
 
 		if len(taskIDs) == 0 {
@@ -274,10 +337,12 @@ Examples:
 		yes, _ := cmd.Flags().GetBool("yes")
 		if !yes {
 			fmt.Printf("⚠️  WARNING: This will permanently delete %d task(s).\n", len(taskIDs))
-			fmt.Printf("Are you absolutely sure? Type 'delete' to confirm: ")
-			var response string
-			_, _ = fmt.Scanln(&response)
-			if response != "delete" {
+			confirmed, err := output.ConfirmExact("Are you absolutely sure? Type 'delete' to confirm:", "delete")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			if !confirmed {
 				fmt.Println("Cancelled")
 				return
 			}
@@ -291,44 +356,394 @@ Examples:
 		}
 
 		// Delete tasks
-		var successCount, errorCount int
-		var deletedTasks []string
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		format := cmd.Flag("output").Value.String()
+		progress := bulkProgressWriter(format)
 
-		fmt.Println("Deleting tasks...")
-		for _, taskID := range taskIDs {
-			err := client.DeleteTask(ctx, taskID)
-			if err != nil {
-				errorCount++
-				fmt.Printf("  ✗ %s: %v\n", taskID, err)
-			} else {
-				successCount++
-				deletedTasks = append(deletedTasks, taskID)
-				fmt.Printf("  ✓ %s\n", taskID)
+		fmt.Fprintln(progress, "Deleting tasks...")
+		result := runBulkTasks(ctx, progress, taskIDs, failFast, client.DeleteTask)
+
+		if err := printBulkSummary(os.Stdout, format, "Deleted", result); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			os.Exit(1)
+		}
+
+		if result.Failed > 0 || result.Cancelled {
+			os.Exit(1)
+		}
+	},
+}
+
+var bulkCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create multiple tasks",
+	Long: `Create multiple tasks at once from stdin, one task per line.
+
+Each line is a task name. With --ndjson each line is a JSON object with a
+"name" and optionally "description", "status", "priority", "due", "assignees"
+and "tags".
+
+Examples:
+  # Create a task for each line of a file
+  cat todo.txt | cu bulk create --list 123456
+
+  # Create tasks from NDJSON, skipping malformed lines
+  cat tasks.ndjson | cu bulk create --list 123456 --ndjson --skip-errors`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		listID, _ := cmd.Flags().GetString("list")
+		ndjson, _ := cmd.Flags().GetBool("ndjson")
+		skipErrors, _ := cmd.Flags().GetBool("skip-errors")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		if listID == "" {
+			listID = config.GetString("default_list")
+			if listID == "" {
+				fmt.Fprintln(os.Stderr, "No list specified. Use --list flag or set a default list with 'cu list default'")
+				os.Exit(1)
 			}
 		}
 
-		// Summary
-		fmt.Printf("\nSummary:\n")
-		fmt.Printf("  Deleted: %d\n", successCount)
-		fmt.Printf("  Failed:  %d\n", errorCount)
+		lines, lineErrs, err := parseBulkCreateInput(cmd.InOrStdin(), ndjson)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, lineErr := range lineErrs {
+			fmt.Fprintf(os.Stderr, "  ✗ line %d: %v\n", lineErr.Line, lineErr.Err)
+		}
+		if len(lineErrs) > 0 && !skipErrors {
+			fmt.Fprintf(os.Stderr, "%d malformed line(s); fix them or use --skip-errors to skip them\n", len(lineErrs))
+			os.Exit(1)
+		}
+
+		if len(lines) == 0 {
+			fmt.Fprintln(os.Stderr, "No tasks provided")
+			os.Exit(1)
+		}
+
+		initCaches()
+
+		// Create API client
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
 
-		// Output deleted task IDs for potential recovery scripts
 		format := cmd.Flag("output").Value.String()
-		if format != "table" && len(deletedTasks) > 0 {
-			if err := output.Format(format, deletedTasks); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-			}
+		progress := bulkProgressWriter(format)
+
+		fmt.Fprintf(progress, "Creating %d task(s)...\n", len(lines))
+		result := runBulkCreate(ctx, client, progress, listID, lines, concurrency)
+		for _, lineErr := range lineErrs {
+			result.add(bulkTaskResult{Line: lineErr.Line, Error: lineErr.Err.Error()})
+		}
+
+		if err := printBulkSummary(os.Stdout, format, "Created", result); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			os.Exit(1)
 		}
 
-		if errorCount > 0 {
+		if result.Failed > 0 || result.Cancelled {
 			os.Exit(1)
 		}
 	},
 }
 
+// bulkCreateInput is one NDJSON line read by bulk create
+type bulkCreateInput struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Priority    string   `json:"priority"`
+	Due         string   `json:"due"`
+	Assignees   []string `json:"assignees"`
+	Tags        []string `json:"tags"`
+}
+
+// bulkCreateLine is a task to create and the input line it came from
+type bulkCreateLine struct {
+	Line int
+	Opts *api.TaskCreateOptions
+}
+
+// bulkLineError reports an input line that could not be parsed
+type bulkLineError struct {
+	Line int
+	Err  error
+}
+
+// parseBulkCreateInput reads one task per non-empty line, either as a plain
+// task name or, with ndjson, as a JSON object. Malformed lines are returned
+// separately so the caller can decide whether to continue.
+func parseBulkCreateInput(r io.Reader, ndjson bool) ([]bulkCreateLine, []bulkLineError, error) {
+	var lines []bulkCreateLine
+	var lineErrs []bulkLineError
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		if !ndjson {
+			lines = append(lines, bulkCreateLine{Line: lineNum, Opts: &api.TaskCreateOptions{Name: text}})
+			continue
+		}
+
+		var input bulkCreateInput
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&input); err != nil {
+			lineErrs = append(lineErrs, bulkLineError{Line: lineNum, Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+		if input.Name == "" {
+			lineErrs = append(lineErrs, bulkLineError{Line: lineNum, Err: fmt.Errorf("missing \"name\"")})
+			continue
+		}
+
+		lines = append(lines, bulkCreateLine{
+			Line: lineNum,
+			Opts: &api.TaskCreateOptions{
+				Name:        input.Name,
+				Description: input.Description,
+				Status:      input.Status,
+				Priority:    input.Priority,
+				DueDate:     input.Due,
+				Assignees:   input.Assignees,
+				Tags:        input.Tags,
+			},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return lines, lineErrs, nil
+}
+
+// taskCreator is the part of the API client used by bulk create and the
+// interactive create flow
+type taskCreator interface {
+	CreateTask(ctx context.Context, listID string, options *api.TaskCreateOptions) (*clickup.Task, error)
+}
+
+// runBulkCreate creates tasks with up to concurrency requests in flight and
+// prints one result per input line, in input order. The client's rate
+// limiter still applies to every request.
+func runBulkCreate(ctx context.Context, client taskCreator, w io.Writer, listID string, lines []bulkCreateLine, concurrency int) bulkResult {
+	concurrency = max(concurrency, 1)
+
+	type result struct {
+		task *clickup.Task
+		err  error
+	}
+	results := make([]result, len(lines))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	started := 0
+launch:
+	for i, line := range lines {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break launch
+		case sem <- struct{}{}:
+		}
+		started++
+		wg.Add(1)
+		go func(i int, line bulkCreateLine) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task, err := client.CreateTask(ctx, listID, line.Opts)
+			results[i] = result{task: task, err: err}
+		}(i, line)
+	}
+	wg.Wait()
+
+	var outcome bulkResult
+	for i, res := range results[:started] {
+		if res.err != nil {
+			outcome.add(bulkTaskResult{Line: lines[i].Line, Error: res.err.Error()})
+			fmt.Fprintf(w, "  ✗ line %d: %s: %v\n", lines[i].Line, lines[i].Opts.Name, res.err)
+		} else {
+			outcome.add(bulkTaskResult{ID: res.task.ID, Line: lines[i].Line, OK: true})
+			fmt.Fprintf(w, "  ✓ line %d: %s %s\n", lines[i].Line, res.task.ID, res.task.Name)
+		}
+	}
+	if started < len(lines) {
+		outcome.Skipped = len(lines) - started
+		outcome.Cancelled = true
+	}
+
+	return outcome
+}
+
+// bulkResult records the outcome of a bulk operation
+type bulkResult struct {
+	Succeeded []string
+	Failed    int
+	// Skipped counts tasks not attempted because --fail-fast stopped early
+	// or the operation was cancelled
+	Skipped   int
+	Cancelled bool
+	// Results has the outcome of each attempted task in order
+	Results []bulkTaskResult
+}
+
+// bulkTaskResult is the outcome of one task of a bulk operation. Line is the
+// input line of bulk create; ID is empty when a task could not be created.
+type bulkTaskResult struct {
+	ID    string `json:"id,omitempty"`
+	Line  int    `json:"line,omitempty"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// add records the outcome of one task
+func (r *bulkResult) add(task bulkTaskResult) {
+	r.Results = append(r.Results, task)
+	if task.OK {
+		r.Succeeded = append(r.Succeeded, task.ID)
+	} else {
+		r.Failed++
+	}
+}
+
+// bulkReport is the structured output of a bulk operation
+type bulkReport struct {
+	Results []bulkTaskResult `json:"results"`
+	Success int              `json:"success"`
+	Failed  int              `json:"failed"`
+	Skipped int              `json:"skipped,omitempty"`
+}
+
+func (r bulkResult) report() bulkReport {
+	results := r.Results
+	if results == nil {
+		results = []bulkTaskResult{}
+	}
+	return bulkReport{
+		Results: results,
+		Success: len(r.Succeeded),
+		Failed:  r.Failed,
+		Skipped: r.Skipped,
+	}
+}
+
+// runBulkTasks applies fn to each task in order, printing a line per task.
+// With failFast it stops at the first failure and counts the remaining tasks
+// as skipped. It also stops when ctx is cancelled.
+func runBulkTasks(ctx context.Context, w io.Writer, taskIDs []string, failFast bool, fn func(ctx context.Context, taskID string) error) bulkResult {
+	var result bulkResult
+	for i, taskID := range taskIDs {
+		if ctx.Err() != nil {
+			result.Skipped = len(taskIDs) - i
+			result.Cancelled = true
+			break
+		}
+		if err := fn(ctx, taskID); err != nil {
+			result.add(bulkTaskResult{ID: taskID, Error: err.Error()})
+			fmt.Fprintf(w, "  ✗ %s: %v\n", taskID, err)
+			if failFast {
+				result.Skipped = len(taskIDs) - i - 1
+				break
+			}
+			continue
+		}
+		result.add(bulkTaskResult{ID: taskID, OK: true})
+		fmt.Fprintf(w, "  ✓ %s\n", taskID)
+	}
+	return result
+}
+
+// readBulkTaskIDs returns args, or the non-blank lines of r when there are
+// no args
+func readBulkTaskIDs(r io.Reader, args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	var taskIDs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			taskIDs = append(taskIDs, line)
+		}
+	}
+	return taskIDs, scanner.Err()
+}
+
+// runBulkReopen moves each task to an open status of its list like task
+// reopen. With dryRun it only looks up and prints the status each task would
+// move to.
+func runBulkReopen(ctx context.Context, w io.Writer, client taskStatusUpdater, taskIDs []string, status string, dryRun, failFast bool) bulkResult {
+	if !dryRun {
+		return runBulkTasks(ctx, w, taskIDs, failFast, func(ctx context.Context, taskID string) error {
+			_, err := runTaskReopen(ctx, client, taskID, status)
+			return err
+		})
+	}
+
+	// runBulkTasks only reports failures here; planned moves are printed
+	// in their place
+	return runBulkTasks(ctx, io.Discard, taskIDs, failFast, func(ctx context.Context, taskID string) error {
+		target, err := taskReopenStatus(ctx, client, taskID, status)
+		if err != nil {
+			fmt.Fprintf(w, "  ✗ %s: %v\n", taskID, err)
+			return err
+		}
+		fmt.Fprintf(w, "  %s → %s\n", taskID, target)
+		return nil
+	})
+}
+
+// bulkProgressWriter returns where bulk commands print per-task lines:
+// stdout for table output and stderr otherwise, keeping stdout for the
+// structured result
+func bulkProgressWriter(format string) io.Writer {
+	if format == "table" {
+		return os.Stdout
+	}
+	return os.Stderr
+}
+
+// printBulkSummary prints the summary of a bulk operation, label naming the
+// succeeded count. Formats other than table get the structured bulkReport.
+func printBulkSummary(w io.Writer, format, label string, result bulkResult) error {
+	if format != "table" {
+		return output.FormatTo(w, format, result.report())
+	}
+
+	fmt.Fprintf(w, "\nSummary:\n")
+	fmt.Fprintf(w, "  %s: %d\n", label, len(result.Succeeded))
+	fmt.Fprintf(w, "  Failed:  %d\n", result.Failed)
+	// Add the skipped count when --fail-fast or cancellation stopped early
+	switch {
+	case result.Cancelled:
+		fmt.Fprintf(w, "  Skipped: %d (cancelled)\n", result.Skipped)
+	case result.Skipped > 0:
+		fmt.Fprintf(w, "  Skipped: %d (stopped at first failure)\n", result.Skipped)
+	}
+	return nil
+}
+
 func init() {
+	bulkCmd.AddCommand(bulkCreateCmd)
 	bulkCmd.AddCommand(bulkUpdateCmd)
 	bulkCmd.AddCommand(bulkCloseCmd)
+	bulkCmd.AddCommand(bulkReopenCmd)
 	bulkCmd.AddCommand(bulkDeleteCmd)
 
 	// Bulk update flags
@@ -339,10 +754,25 @@ func init() {
 	bulkUpdateCmd.Flags().StringSlice("remove-assignee", []string{}, "Remove assignees (username or ID)")
 	bulkUpdateCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
 	bulkUpdateCmd.Flags().Bool("dry-run", false, "Show what would be updated without making changes")
+	bulkUpdateCmd.Flags().Bool("fail-fast", false, "Stop at the first task that fails")
+
+	// Bulk create flags
+	bulkCreateCmd.Flags().StringP("list", "l", "", "List ID to create tasks in")
+	bulkCreateCmd.Flags().Bool("ndjson", false, "Read one JSON task object per line")
+	bulkCreateCmd.Flags().Bool("skip-errors", false, "Skip malformed lines instead of aborting")
+	bulkCreateCmd.Flags().Int("concurrency", 4, "Number of tasks to create at once")
 
 	// Bulk close flags
 	bulkCloseCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	bulkCloseCmd.Flags().Bool("fail-fast", false, "Stop at the first task that fails")
+
+	// Bulk reopen flags
+	bulkReopenCmd.Flags().StringP("status", "s", "", "Open status to move the tasks to (default: each list's first open status)")
+	bulkReopenCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	bulkReopenCmd.Flags().Bool("dry-run", false, "Show the status each task would move to without making changes")
+	bulkReopenCmd.Flags().Bool("fail-fast", false, "Stop at the first task that fails")
 
 	// Bulk delete flags
 	bulkDeleteCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	bulkDeleteCmd.Flags().Bool("fail-fast", false, "Stop at the first task that fails")
 }
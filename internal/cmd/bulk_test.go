@@ -1,11 +1,20 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/api"
 )
 
 func TestBulkCommand_Structure(t *testing.T) {
@@ -31,7 +40,7 @@ func TestBulkCommand_Structure(t *testing.T) {
 		}
 
 		// Check for expected subcommands
-		expectedSubcommands := []string{"update", "close", "delete"}
+		expectedSubcommands := []string{"create", "update", "close", "reopen", "delete"}
 		for _, expected := range expectedSubcommands {
 			assert.True(t, subcommandNames[expected], "Expected subcommand '%s' to exist", expected)
 		}
@@ -88,3 +97,299 @@ func TestBulkCommand_Structure(t *testing.T) {
 		}
 	})
 }
+
+func TestParseBulkCreateInput(t *testing.T) {
+	t.Run("ndjson with valid and malformed lines", func(t *testing.T) {
+		input := strings.Join([]string{
+			`{"name": "Write docs", "priority": "high", "tags": ["docs"]}`,
+			``,
+			`{"name": "Broken"`,
+			`{"description": "no name"}`,
+			`{"name": "Typo", "stauts": "done"}`,
+			`{"name": "Ship it", "status": "to do", "due": "2026-01-02", "assignees": ["42"]}`,
+		}, "\n")
+
+		lines, lineErrs, err := parseBulkCreateInput(strings.NewReader(input), true)
+		require.NoError(t, err)
+
+		require.Len(t, lines, 2)
+		assert.Equal(t, 1, lines[0].Line)
+		assert.Equal(t, "Write docs", lines[0].Opts.Name)
+		assert.Equal(t, "high", lines[0].Opts.Priority)
+		assert.Equal(t, []string{"docs"}, lines[0].Opts.Tags)
+		assert.Equal(t, 6, lines[1].Line)
+		assert.Equal(t, "2026-01-02", lines[1].Opts.DueDate)
+		assert.Equal(t, []string{"42"}, lines[1].Opts.Assignees)
+
+		require.Len(t, lineErrs, 3)
+		assert.Equal(t, 3, lineErrs[0].Line)
+		assert.Contains(t, lineErrs[0].Err.Error(), "invalid JSON")
+		assert.Equal(t, 4, lineErrs[1].Line)
+		assert.Contains(t, lineErrs[1].Err.Error(), "missing \"name\"")
+		assert.Equal(t, 5, lineErrs[2].Line)
+		assert.Contains(t, lineErrs[2].Err.Error(), "stauts")
+	})
+
+	t.Run("plain lines are task names", func(t *testing.T) {
+		lines, lineErrs, err := parseBulkCreateInput(strings.NewReader("First\n\n  Second  \n"), false)
+		require.NoError(t, err)
+		assert.Empty(t, lineErrs)
+		require.Len(t, lines, 2)
+		assert.Equal(t, "First", lines[0].Opts.Name)
+		assert.Equal(t, 3, lines[1].Line)
+		assert.Equal(t, "Second", lines[1].Opts.Name)
+	})
+}
+
+// fakeTaskCreator records created tasks and fails for names in failNames
+type fakeTaskCreator struct {
+	mu        sync.Mutex
+	created   []string
+	failNames map[string]bool
+}
+
+func (f *fakeTaskCreator) CreateTask(ctx context.Context, listID string, options *api.TaskCreateOptions) (*clickup.Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failNames[options.Name] {
+		return nil, fmt.Errorf("status not found")
+	}
+	f.created = append(f.created, listID+"/"+options.Name)
+	return &clickup.Task{ID: fmt.Sprintf("t%d", len(f.created)), Name: options.Name}, nil
+}
+
+func TestRunBulkCreate(t *testing.T) {
+	client := &fakeTaskCreator{failNames: map[string]bool{"Bad": true}}
+	lines := []bulkCreateLine{
+		{Line: 1, Opts: &api.TaskCreateOptions{Name: "One"}},
+		{Line: 2, Opts: &api.TaskCreateOptions{Name: "Bad"}},
+		{Line: 4, Opts: &api.TaskCreateOptions{Name: "Three"}},
+	}
+
+	var buf bytes.Buffer
+	result := runBulkCreate(context.Background(), client, &buf, "list1", lines, 2)
+
+	assert.Len(t, result.Succeeded, 2)
+	assert.Equal(t, 1, result.Failed)
+	assert.False(t, result.Cancelled)
+	assert.ElementsMatch(t, []string{"list1/One", "list1/Three"}, client.created)
+
+	out := buf.String()
+	assert.Contains(t, out, "✗ line 2: Bad: status not found")
+	assert.Less(t, strings.Index(out, "line 1:"), strings.Index(out, "line 2:"))
+	assert.Less(t, strings.Index(out, "line 2:"), strings.Index(out, "line 4:"))
+
+	t.Run("cancelled context starts no more tasks", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := &fakeTaskCreator{}
+		result := runBulkCreate(ctx, client, &bytes.Buffer{}, "list1", lines, 1)
+
+		assert.True(t, result.Cancelled)
+		assert.Equal(t, len(lines), result.Skipped)
+		assert.Empty(t, client.created)
+	})
+}
+
+func TestRunBulkTasks(t *testing.T) {
+	taskIDs := []string{"t1", "t2", "t3", "t4"}
+	failing := func(calls *[]string) func(context.Context, string) error {
+		return func(ctx context.Context, taskID string) error {
+			*calls = append(*calls, taskID)
+			if taskID == "t2" {
+				return fmt.Errorf("task not found")
+			}
+			return nil
+		}
+	}
+
+	t.Run("continues past failures by default", func(t *testing.T) {
+		var calls []string
+		var buf bytes.Buffer
+		result := runBulkTasks(context.Background(), &buf, taskIDs, false, failing(&calls))
+
+		assert.Equal(t, taskIDs, calls)
+		assert.Equal(t, []string{"t1", "t3", "t4"}, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		assert.Zero(t, result.Skipped)
+	})
+
+	t.Run("fail fast stops after the first failure", func(t *testing.T) {
+		var calls []string
+		var buf bytes.Buffer
+		result := runBulkTasks(context.Background(), &buf, taskIDs, true, failing(&calls))
+
+		assert.Equal(t, []string{"t1", "t2"}, calls)
+		assert.Equal(t, []string{"t1"}, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, 2, result.Skipped)
+		assert.Contains(t, buf.String(), "✗ t2: task not found")
+		assert.NotContains(t, buf.String(), "t3")
+	})
+
+	t.Run("cancellation stops mid-loop", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls []string
+		var buf bytes.Buffer
+		result := runBulkTasks(ctx, &buf, taskIDs, false, func(ctx context.Context, taskID string) error {
+			calls = append(calls, taskID)
+			if taskID == "t2" {
+				cancel()
+			}
+			return nil
+		})
+
+		assert.Equal(t, []string{"t1", "t2"}, calls)
+		assert.Equal(t, []string{"t1", "t2"}, result.Succeeded)
+		assert.True(t, result.Cancelled)
+		assert.Equal(t, 2, result.Skipped)
+	})
+}
+
+func TestReadBulkTaskIDs(t *testing.T) {
+	t.Run("reads non-blank stdin lines", func(t *testing.T) {
+		taskIDs, err := readBulkTaskIDs(strings.NewReader("t1\n\n  t2  \nt3"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"t1", "t2", "t3"}, taskIDs)
+	})
+
+	t.Run("args take precedence over stdin", func(t *testing.T) {
+		taskIDs, err := readBulkTaskIDs(strings.NewReader("t1\n"), []string{"a1", "a2"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a1", "a2"}, taskIDs)
+	})
+}
+
+// fakeBulkReopener serves tasks in testStatusList, records every update and
+// fails to find tasks in missing
+type fakeBulkReopener struct {
+	list    *clickup.List
+	missing map[string]bool
+	updates []string
+}
+
+func (f *fakeBulkReopener) GetTask(ctx context.Context, taskID string) (*clickup.Task, error) {
+	if f.missing[taskID] {
+		return nil, fmt.Errorf("task not found")
+	}
+	return &clickup.Task{ID: taskID, List: clickup.ListOfTaskBelonging{ID: f.list.ID}}, nil
+}
+
+func (f *fakeBulkReopener) GetList(ctx context.Context, listID string) (*clickup.List, error) {
+	return f.list, nil
+}
+
+func (f *fakeBulkReopener) UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error) {
+	f.updates = append(f.updates, taskID+"="+options.Status)
+	return &clickup.Task{ID: taskID, Status: clickup.TaskStatus{Status: options.Status}}, nil
+}
+
+func TestRunBulkReopen(t *testing.T) {
+	taskIDs, err := readBulkTaskIDs(strings.NewReader("t1\nt2\nt3\n"), nil)
+	require.NoError(t, err)
+
+	t.Run("reopens each task", func(t *testing.T) {
+		client := &fakeBulkReopener{list: testStatusList(t), missing: map[string]bool{"t2": true}}
+
+		var buf bytes.Buffer
+		result := runBulkReopen(context.Background(), &buf, client, taskIDs, "", false, false)
+
+		assert.Equal(t, []string{"t1=backlog", "t3=backlog"}, client.updates)
+		assert.Equal(t, []string{"t1", "t3"}, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, "  ✓ t1\n  ✗ t2: failed to get task: task not found\n  ✓ t3\n", buf.String())
+	})
+
+	t.Run("given status", func(t *testing.T) {
+		client := &fakeBulkReopener{list: testStatusList(t)}
+
+		result := runBulkReopen(context.Background(), &bytes.Buffer{}, client, taskIDs, "in review", false, false)
+
+		assert.Equal(t, []string{"t1=In Review", "t2=In Review", "t3=In Review"}, client.updates)
+		assert.Len(t, result.Succeeded, 3)
+	})
+
+	t.Run("dry run reports statuses without updating", func(t *testing.T) {
+		client := &fakeBulkReopener{list: testStatusList(t), missing: map[string]bool{"t2": true}}
+
+		var buf bytes.Buffer
+		result := runBulkReopen(context.Background(), &buf, client, taskIDs, "", true, false)
+
+		assert.Empty(t, client.updates)
+		assert.Equal(t, []string{"t1", "t3"}, result.Succeeded)
+		assert.Equal(t, "  t1 → backlog\n  ✗ t2: failed to get task: task not found\n  t3 → backlog\n", buf.String())
+	})
+
+	t.Run("closed status fails every task", func(t *testing.T) {
+		client := &fakeBulkReopener{list: testStatusList(t)}
+
+		result := runBulkReopen(context.Background(), &bytes.Buffer{}, client, taskIDs, "shipped", false, true)
+
+		assert.Empty(t, client.updates)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, 2, result.Skipped)
+	})
+}
+
+func TestPrintBulkSummary(t *testing.T) {
+	mixed := func() bulkResult {
+		return runBulkTasks(context.Background(), io.Discard, []string{"x", "y", "z"}, false, func(ctx context.Context, taskID string) error {
+			if taskID == "y" {
+				return fmt.Errorf("task not found")
+			}
+			return nil
+		})
+	}
+
+	t.Run("json reports each task", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printBulkSummary(&buf, "json", "Success", mixed()))
+
+		var report map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+		assert.Equal(t, map[string]interface{}{
+			"results": []interface{}{
+				map[string]interface{}{"id": "x", "ok": true},
+				map[string]interface{}{"id": "y", "ok": false, "error": "task not found"},
+				map[string]interface{}{"id": "z", "ok": true},
+			},
+			"success": float64(2),
+			"failed":  float64(1),
+		}, report)
+	})
+
+	t.Run("json reports skipped tasks", func(t *testing.T) {
+		result := runBulkTasks(context.Background(), io.Discard, []string{"x", "y", "z"}, true, func(ctx context.Context, taskID string) error {
+			return fmt.Errorf("forbidden")
+		})
+
+		var buf bytes.Buffer
+		require.NoError(t, printBulkSummary(&buf, "json", "Success", result))
+		assert.JSONEq(t, `{"results": [{"id": "x", "ok": false, "error": "forbidden"}], "success": 0, "failed": 1, "skipped": 2}`, buf.String())
+	})
+
+	t.Run("json bulk create results carry input lines", func(t *testing.T) {
+		client := &fakeTaskCreator{failNames: map[string]bool{"Bad": true}}
+		lines := []bulkCreateLine{
+			{Line: 1, Opts: &api.TaskCreateOptions{Name: "One"}},
+			{Line: 3, Opts: &api.TaskCreateOptions{Name: "Bad"}},
+		}
+		result := runBulkCreate(context.Background(), client, io.Discard, "list1", lines, 1)
+
+		var buf bytes.Buffer
+		require.NoError(t, printBulkSummary(&buf, "json", "Created", result))
+		assert.JSONEq(t, `{"results": [
+			{"id": "t1", "line": 1, "ok": true},
+			{"line": 3, "ok": false, "error": "status not found"}
+		], "success": 1, "failed": 1}`, buf.String())
+	})
+
+	t.Run("table keeps the human summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printBulkSummary(&buf, "table", "Deleted", mixed()))
+		assert.Equal(t, "\nSummary:\n  Deleted: 2\n  Failed:  1\n", buf.String())
+	})
+}
@@ -1,19 +1,33 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"time"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/api"
 	"github.com/timimsms/cu/internal/cache"
+	"github.com/timimsms/cu/internal/errors"
 	"github.com/timimsms/cu/internal/output"
 )
 
 var cacheCmd = &cobra.Command{
 	Use:   "cache",
 	Short: "Manage local cache",
-	Long:  `Manage the local cache used to improve performance.`,
+	Long: `Manage the local cache used to improve performance.
+
+Cached data is stored as JSON under the config directory. Set the cache_encrypt
+config key to true to encrypt cached data at rest with a per-user key.
+
+Workspace and user data is cached for 1 hour and tasks for 5 minutes. Override
+these with the cache_ttl_workspaces and cache_ttl_tasks config keys, or use
+--cache-ttl to override every cache for a single command. Durations use Go
+syntax such as 30s, 10m or 2h.`,
 }
 
 var cacheInfoCmd = &cobra.Command{
@@ -37,11 +51,167 @@ var cacheCleanCmd = &cobra.Command{
 	RunE:  cleanCache,
 }
 
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm",
+	Short: "Pre-populate the cache",
+	Long: `Fetch and cache the spaces and members of each workspace up front, for
+example before a batch run. space list reads the cached spaces, and assignee
+lookups read the cached members. Requests go through the usual rate limiter.
+
+Use --workspace to only fetch the spaces and members of one workspace.`,
+	Args: cobra.NoArgs,
+	RunE: warmCache,
+}
+
+var cacheInvalidateCmd = &cobra.Command{
+	Use:   "invalidate",
+	Short: "Drop cached task list results",
+	Long: `Drop the cached results of 'cu task list --cache' for a list, so the next
+cached read fetches fresh tasks from ClickUp. Changes made to tasks are not
+seen by cached reads until their entry expires or is invalidated.`,
+	Example: `  cu cache invalidate --list 901234`,
+	Args:    cobra.NoArgs,
+	RunE:    invalidateCache,
+}
+
 func init() {
 	rootCmd.AddCommand(cacheCmd)
 	cacheCmd.AddCommand(cacheInfoCmd)
 	cacheCmd.AddCommand(cacheClearCmd)
 	cacheCmd.AddCommand(cacheCleanCmd)
+	cacheCmd.AddCommand(cacheWarmCmd)
+	cacheCmd.AddCommand(cacheInvalidateCmd)
+
+	cacheWarmCmd.Flags().StringP("workspace", "w", "", "Only fetch the spaces and members of this workspace ID")
+
+	cacheInvalidateCmd.Flags().StringP("list", "l", "", "List ID whose cached tasks to drop")
+	_ = cacheInvalidateCmd.MarkFlagRequired("list")
+}
+
+// spacesCacheKey is the workspace cache key of a workspace's spaces
+func spacesCacheKey(workspaceID string) string {
+	return fmt.Sprintf("spaces_%s", workspaceID)
+}
+
+// cacheWarmer is the part of the API client used by cache warm
+type cacheWarmer interface {
+	GetWorkspaces(ctx context.Context) ([]clickup.Team, error)
+	GetSpaces(ctx context.Context, workspaceID string) ([]clickup.Space, error)
+	GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]clickup.TeamUser, error)
+}
+
+// warmedEntry is a cache entry written by cache warm
+type warmedEntry struct {
+	Key         string `json:"key" yaml:"key"`
+	Description string `json:"description" yaml:"description"`
+}
+
+func warmCache(cmd *cobra.Command, args []string) error {
+	workspaceID, _ := cmd.Flags().GetString("workspace")
+
+	initCaches()
+	if cache.WorkspaceCache == nil {
+		return fmt.Errorf("cache is unavailable")
+	}
+
+	client, err := api.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	entries, err := runCacheWarm(cmd.Context(), os.Stderr, client, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	format := cmd.Flag("output").Value.String()
+	if format != "table" {
+		return output.Format(format, entries)
+	}
+	for _, entry := range entries {
+		fmt.Printf("✓ Cached %s\n", entry.Description)
+	}
+	return nil
+}
+
+// runCacheWarm fetches the spaces and members of each workspace and stores
+// them under the keys space list and assignee lookups read. Only workspaceID
+// is warmed when it is set. A workspace whose spaces or members cannot be
+// fetched is reported on w and skipped.
+func runCacheWarm(ctx context.Context, w io.Writer, client cacheWarmer, workspaceID string) ([]warmedEntry, error) {
+	workspaces, err := client.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+	if workspaceID != "" && !slices.ContainsFunc(workspaces, func(ws clickup.Team) bool { return ws.ID == workspaceID }) {
+		return nil, errors.NewUserError(
+			fmt.Sprintf("Workspace %s not found", workspaceID),
+			"Run 'cu cache warm' without --workspace to cache every workspace",
+			errors.ErrNotFound,
+		)
+	}
+
+	var entries []warmedEntry
+	for _, workspace := range workspaces {
+		if workspaceID != "" && workspace.ID != workspaceID {
+			continue
+		}
+
+		spaces, err := client.GetSpaces(ctx, workspace.ID)
+		if err != nil {
+			fmt.Fprintf(w, "Warning: failed to get spaces for workspace %s: %v\n", workspace.Name, err)
+		} else {
+			key := spacesCacheKey(workspace.ID)
+			if err := cache.WorkspaceCache.Set(key, spaces); err != nil {
+				return nil, fmt.Errorf("failed to cache spaces: %w", err)
+			}
+			entries = append(entries, warmedEntry{key, fmt.Sprintf("%d spaces in %s", len(spaces), workspace.Name)})
+		}
+
+		members, err := client.GetWorkspaceMembers(ctx, workspace.ID)
+		if err != nil {
+			fmt.Fprintf(w, "Warning: failed to get members of workspace %s: %v\n", workspace.Name, err)
+			continue
+		}
+		key := api.UserCacheKey(workspace.ID)
+		if err := cache.UserCache.Set(key, members); err != nil {
+			return nil, fmt.Errorf("failed to cache members: %w", err)
+		}
+		entries = append(entries, warmedEntry{key, fmt.Sprintf("%d members of %s", len(members), workspace.Name)})
+	}
+
+	return entries, nil
+}
+
+func invalidateCache(cmd *cobra.Command, args []string) error {
+	listID, _ := cmd.Flags().GetString("list")
+
+	initCaches()
+	if cache.TaskCache == nil {
+		return fmt.Errorf("cache is unavailable")
+	}
+
+	token, err := getAuthToken()
+	if err != nil {
+		return errors.ErrNotAuthenticated
+	}
+
+	if err := cache.TaskCache.Delete(taskListCacheKey(token.CacheScope(), listID)); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Invalidated cached tasks of list %s\n", listID)
+	return nil
+}
+
+// initCaches initializes the caches unless a command already has. Commands
+// still work without them, so a failure is only a warning.
+func initCaches() {
+	if cache.UserCache != nil {
+		return
+	}
+	if err := cache.InitCaches(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to initialize cache: %v\n", err)
+	}
 }
 
 func showCacheInfo(cmd *cobra.Command, args []string) error {
@@ -70,11 +240,10 @@ func showCacheInfo(cmd *cobra.Command, args []string) error {
 	caches := []struct {
 		name  string
 		cache *cache.Cache
-		ttl   time.Duration
 	}{
-		{"Workspace", cache.WorkspaceCache, 1 * time.Hour},
-		{"User", cache.UserCache, 1 * time.Hour},
-		{"Task", cache.TaskCache, 5 * time.Minute},
+		{"Workspace", cache.WorkspaceCache},
+		{"User", cache.UserCache},
+		{"Task", cache.TaskCache},
 	}
 
 	for _, c := range caches {
@@ -86,7 +255,7 @@ func showCacheInfo(cmd *cobra.Command, args []string) error {
 
 		info := cacheInfo{
 			Name:           c.name,
-			TTL:            c.ttl,
+			TTL:            c.cache.TTL(),
 			TotalEntries:   stats.TotalEntries,
 			ValidEntries:   stats.ValidEntries,
 			ExpiredEntries: stats.ExpiredEntries,
@@ -1,12 +1,23 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"io"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/auth"
+	"github.com/timimsms/cu/internal/cache"
+	"github.com/timimsms/cu/internal/clock"
+	"github.com/timimsms/cu/internal/config"
 )
 
 func TestCacheCmd_Structure(t *testing.T) {
@@ -501,3 +512,202 @@ func TestCacheCommands_RunEExecution(t *testing.T) {
 		}
 	})
 }
+
+// fakeCacheWarmer serves two workspaces; workspaces in failSpaces error
+type fakeCacheWarmer struct {
+	failSpaces map[string]bool
+	spaceCalls []string
+}
+
+func (f *fakeCacheWarmer) GetWorkspaces(ctx context.Context) ([]clickup.Team, error) {
+	return []clickup.Team{{ID: "w1", Name: "Acme"}, {ID: "w2", Name: "Side"}}, nil
+}
+
+func (f *fakeCacheWarmer) GetSpaces(ctx context.Context, workspaceID string) ([]clickup.Space, error) {
+	f.spaceCalls = append(f.spaceCalls, workspaceID)
+	if f.failSpaces[workspaceID] {
+		return nil, stderrors.New("forbidden")
+	}
+	return []clickup.Space{{ID: workspaceID + "-s1", Name: "Engineering"}}, nil
+}
+
+func (f *fakeCacheWarmer) GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]clickup.TeamUser, error) {
+	return []clickup.TeamUser{{ID: 1, Username: "alice"}}, nil
+}
+
+func TestRunCacheWarm(t *testing.T) {
+	setup := func(t *testing.T) {
+		oldConfigDir := config.DefaultConfigDir
+		oldWorkspace, oldUser, oldTask := cache.WorkspaceCache, cache.UserCache, cache.TaskCache
+		config.DefaultConfigDir = t.TempDir()
+		t.Cleanup(func() {
+			config.DefaultConfigDir = oldConfigDir
+			cache.WorkspaceCache, cache.UserCache, cache.TaskCache = oldWorkspace, oldUser, oldTask
+		})
+		require.NoError(t, cache.InitCaches())
+	}
+
+	t.Run("caches the spaces and members of each workspace", func(t *testing.T) {
+		setup(t)
+
+		var warnings bytes.Buffer
+		entries, err := runCacheWarm(context.Background(), &warnings, &fakeCacheWarmer{}, "")
+		require.NoError(t, err)
+		assert.Empty(t, warnings.String())
+
+		var keys []string
+		for _, entry := range entries {
+			keys = append(keys, entry.Key)
+		}
+		assert.Equal(t, []string{"spaces_w1", "users_w1", "spaces_w2", "users_w2"}, keys)
+		assert.Equal(t, "1 spaces in Acme", entries[0].Description)
+
+		var spaces []clickup.Space
+		require.NoError(t, cache.WorkspaceCache.Get(spacesCacheKey("w2"), &spaces))
+		assert.Equal(t, "w2-s1", spaces[0].ID)
+
+		var members []clickup.TeamUser
+		require.NoError(t, cache.UserCache.Get(api.UserCacheKey("w1"), &members))
+		assert.Equal(t, "alice", members[0].Username)
+	})
+
+	t.Run("one workspace", func(t *testing.T) {
+		setup(t)
+		client := &fakeCacheWarmer{}
+
+		_, err := runCacheWarm(context.Background(), io.Discard, client, "w2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"w2"}, client.spaceCalls)
+
+		var spaces []clickup.Space
+		assert.Error(t, cache.WorkspaceCache.Get(spacesCacheKey("w1"), &spaces))
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		setup(t)
+
+		_, err := runCacheWarm(context.Background(), io.Discard, &fakeCacheWarmer{}, "w9")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Workspace w9 not found")
+	})
+
+	t.Run("failed spaces are skipped", func(t *testing.T) {
+		setup(t)
+
+		var warnings bytes.Buffer
+		entries, err := runCacheWarm(context.Background(), &warnings, &fakeCacheWarmer{failSpaces: map[string]bool{"w1": true}}, "")
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+		assert.Contains(t, warnings.String(), "failed to get spaces for workspace Acme: forbidden")
+	})
+}
+
+func TestCachedTaskFetcher(t *testing.T) {
+	setup := func(t *testing.T) {
+		oldConfigDir := config.DefaultConfigDir
+		oldWorkspace, oldUser, oldTask := cache.WorkspaceCache, cache.UserCache, cache.TaskCache
+		config.DefaultConfigDir = t.TempDir()
+		t.Cleanup(func() {
+			config.DefaultConfigDir = oldConfigDir
+			cache.WorkspaceCache, cache.UserCache, cache.TaskCache = oldWorkspace, oldUser, oldTask
+		})
+		require.NoError(t, cache.InitCaches())
+	}
+	ctx := context.Background()
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("serves a repeated query from the cache within the TTL", func(t *testing.T) {
+		setup(t)
+		defer clock.Set(clock.Fixed(now))()
+		client := &fakeTaskPager{lists: map[string]int{"l1": 3}}
+		fetcher := &cachedTaskFetcher{fetcher: client, cache: cache.TaskCache, scope: "work:abc"}
+
+		first, err := fetcher.GetTasks(ctx, "l1", &api.TaskQueryOptions{})
+		require.NoError(t, err)
+		clock.Set(clock.Fixed(now.Add(cache.TaskCache.TTL() - time.Second)))
+		second, err := fetcher.GetTasks(ctx, "l1", &api.TaskQueryOptions{})
+		require.NoError(t, err)
+
+		assert.Len(t, client.requests, 1)
+		require.Len(t, second, len(first))
+		assert.Equal(t, first[2].ID, second[2].ID)
+
+		_, err = fetcher.GetTasks(ctx, "l1", &api.TaskQueryOptions{Statuses: []string{"open"}})
+		require.NoError(t, err)
+		assert.Len(t, client.requests, 2, "a different query is fetched")
+	})
+
+	t.Run("refetches after the TTL", func(t *testing.T) {
+		setup(t)
+		defer clock.Set(clock.Fixed(now))()
+		client := &fakeTaskPager{lists: map[string]int{"l1": 3}}
+		fetcher := &cachedTaskFetcher{fetcher: client, cache: cache.TaskCache, scope: "work:abc"}
+
+		_, err := fetcher.GetTasks(ctx, "l1", &api.TaskQueryOptions{})
+		require.NoError(t, err)
+		clock.Set(clock.Fixed(now.Add(cache.TaskCache.TTL())))
+		_, err = fetcher.GetTasks(ctx, "l1", &api.TaskQueryOptions{})
+		require.NoError(t, err)
+		assert.Len(t, client.requests, 2)
+	})
+
+	t.Run("invalidate forces a refetch of the list only", func(t *testing.T) {
+		setup(t)
+		token := &auth.Token{Value: "pk_work", Workspace: "work"}
+		oldGetAuthToken := getAuthToken
+		getAuthToken = func() (*auth.Token, error) { return token, nil }
+		t.Cleanup(func() { getAuthToken = oldGetAuthToken })
+
+		client := &fakeTaskPager{lists: map[string]int{"l1": 3, "l2": 2}}
+		fetcher := &cachedTaskFetcher{fetcher: client, cache: cache.TaskCache, scope: token.CacheScope()}
+
+		for _, listID := range []string{"l1", "l2"} {
+			_, err := fetcher.GetTasks(ctx, listID, &api.TaskQueryOptions{})
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, cacheInvalidateCmd.Flags().Set("list", "l1"))
+		t.Cleanup(func() { _ = cacheInvalidateCmd.Flags().Set("list", "") })
+		require.NoError(t, invalidateCache(cacheInvalidateCmd, nil))
+
+		for _, listID := range []string{"l1", "l2"} {
+			_, err := fetcher.GetTasks(ctx, listID, &api.TaskQueryOptions{})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []string{
+			`l1 page 0 order ""`,
+			`l2 page 0 order ""`,
+			`l1 page 0 order ""`,
+		}, client.requests)
+	})
+
+	t.Run("another account does not share cached results", func(t *testing.T) {
+		setup(t)
+		client := &fakeTaskPager{lists: map[string]int{"l1": 3}}
+		work := &cachedTaskFetcher{fetcher: client, cache: cache.TaskCache, scope: "work:abc"}
+		personal := &cachedTaskFetcher{fetcher: client, cache: cache.TaskCache, scope: "personal:def"}
+
+		_, err := work.GetTasks(ctx, "l1", &api.TaskQueryOptions{})
+		require.NoError(t, err)
+		_, err = personal.GetTasks(ctx, "l1", &api.TaskQueryOptions{})
+		require.NoError(t, err)
+		assert.Len(t, client.requests, 2)
+	})
+
+	t.Run("expired queries are dropped from the entry", func(t *testing.T) {
+		setup(t)
+		defer clock.Set(clock.Fixed(now))()
+		client := &fakeTaskPager{lists: map[string]int{"l1": 3}}
+		fetcher := &cachedTaskFetcher{fetcher: client, cache: cache.TaskCache, scope: "work:abc"}
+
+		_, err := fetcher.GetTasks(ctx, "l1", &api.TaskQueryOptions{Page: 0})
+		require.NoError(t, err)
+		clock.Set(clock.Fixed(now.Add(cache.TaskCache.TTL())))
+		_, err = fetcher.GetTasks(ctx, "l1", &api.TaskQueryOptions{Page: 1})
+		require.NoError(t, err)
+
+		pages := map[string]cachedTaskPage{}
+		require.NoError(t, cache.TaskCache.Get(taskListCacheKey("work:abc", "l1"), &pages))
+		assert.Len(t, pages, 1)
+	})
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
+)
+
+var taskChecklistCmd = &cobra.Command{
+	Use:   "checklist",
+	Short: "Manage task checklists",
+	Long:  `Add checklists and checklist items to tasks. Use 'cu task view' to see existing checklists.`,
+}
+
+var taskChecklistAddCmd = &cobra.Command{
+	Use:   "add [task-id]",
+	Short: "Add a checklist to a task",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		name, _ := cmd.Flags().GetString("name")
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		checklist, err := client.CreateChecklist(ctx, args[0], name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create checklist: %v\n", err)
+			os.Exit(1)
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			fmt.Printf("✓ Added checklist %s to task %s\n", checklist.Name, args[0])
+			fmt.Printf("  Checklist ID: %s\n", checklist.ID)
+		} else {
+			if err := output.Format(format, checklist); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+var taskChecklistItemCmd = &cobra.Command{
+	Use:   "item [task-id]",
+	Short: "Add an item to a task checklist",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		checklistID, _ := cmd.Flags().GetString("checklist")
+		text, _ := cmd.Flags().GetString("text")
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		checklist, err := runChecklistItem(ctx, client, args[0], checklistID, text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			fmt.Printf("✓ Added item to checklist %s\n", checklist.Name)
+			printChecklists(os.Stdout, []clickup.Checklist{*checklist})
+		} else {
+			if err := output.Format(format, checklist); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(taskChecklistCmd)
+	taskChecklistCmd.AddCommand(taskChecklistAddCmd)
+	taskChecklistCmd.AddCommand(taskChecklistItemCmd)
+
+	taskChecklistAddCmd.Flags().StringP("name", "n", "", "Checklist name")
+	_ = taskChecklistAddCmd.MarkFlagRequired("name")
+
+	taskChecklistItemCmd.Flags().String("checklist", "", "Checklist ID")
+	taskChecklistItemCmd.Flags().StringP("text", "t", "", "Item text")
+	_ = taskChecklistItemCmd.MarkFlagRequired("checklist")
+	_ = taskChecklistItemCmd.MarkFlagRequired("text")
+}
+
+// checklistItemCreator is the part of the API client used by task checklist item
+type checklistItemCreator interface {
+	GetTask(ctx context.Context, taskID string) (*clickup.Task, error)
+	CreateChecklistItem(ctx context.Context, checklistID, name string) (*clickup.Checklist, error)
+}
+
+// runChecklistItem adds an item to one of the task's checklists. The task is
+// fetched first so a checklist ID from another task is rejected before
+// anything is written.
+func runChecklistItem(ctx context.Context, client checklistItemCreator, taskID, checklistID, text string) (*clickup.Checklist, error) {
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if !hasChecklist(task, checklistID) {
+		return nil, errors.NewUserError(
+			fmt.Sprintf("Task %s has no checklist '%s'", taskID, checklistID),
+			fmt.Sprintf("Run 'cu task view %s' to see its checklists", taskID),
+			errors.ErrNotFound,
+		)
+	}
+
+	checklist, err := client.CreateChecklistItem(ctx, checklistID, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add checklist item: %w", err)
+	}
+
+	return checklist, nil
+}
+
+// hasChecklist reports whether the task has a checklist with the given ID
+func hasChecklist(task *clickup.Task, checklistID string) bool {
+	for _, checklist := range task.Checklists {
+		if checklist.ID == checklistID {
+			return true
+		}
+	}
+	return false
+}
+
+// printChecklists renders checklists with the completion state of each item
+func printChecklists(w io.Writer, checklists []clickup.Checklist) {
+	for _, checklist := range checklists {
+		done := 0
+		for _, item := range checklist.Items {
+			if item.Resolved {
+				done++
+			}
+		}
+
+		fmt.Fprintf(w, "  %s (%d/%d done, ID %s)\n", checklist.Name, done, len(checklist.Items), checklist.ID)
+		for _, item := range checklist.Items {
+			mark := " "
+			if item.Resolved {
+				mark = "x"
+			}
+			fmt.Fprintf(w, "    [%s] %s\n", mark, item.Name)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/errors"
+)
+
+// fakeChecklistClient serves one task and records created items
+type fakeChecklistClient struct {
+	task  *clickup.Task
+	items []string
+}
+
+func (f *fakeChecklistClient) GetTask(ctx context.Context, taskID string) (*clickup.Task, error) {
+	return f.task, nil
+}
+
+func (f *fakeChecklistClient) CreateChecklistItem(ctx context.Context, checklistID, name string) (*clickup.Checklist, error) {
+	f.items = append(f.items, checklistID+":"+name)
+	return &clickup.Checklist{
+		ID:    checklistID,
+		Name:  "Release",
+		Items: []clickup.Item{{ID: "i1", Name: name}},
+	}, nil
+}
+
+func TestPrintChecklists(t *testing.T) {
+	checklists := []clickup.Checklist{
+		{
+			ID:   "cl1",
+			Name: "Release",
+			Items: []clickup.Item{
+				{Name: "Write notes", Resolved: true},
+				{Name: "Tag release"},
+			},
+		},
+		{ID: "cl2", Name: "Empty"},
+	}
+
+	var buf bytes.Buffer
+	printChecklists(&buf, checklists)
+
+	expected := "  Release (1/2 done, ID cl1)\n" +
+		"    [x] Write notes\n" +
+		"    [ ] Tag release\n" +
+		"  Empty (0/0 done, ID cl2)\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRunChecklistItem(t *testing.T) {
+	ctx := context.Background()
+	task := &clickup.Task{ID: "abc", Checklists: []clickup.Checklist{{ID: "cl1", Name: "Release"}}}
+
+	t.Run("adds item to the task's checklist", func(t *testing.T) {
+		client := &fakeChecklistClient{task: task}
+
+		checklist, err := runChecklistItem(ctx, client, "abc", "cl1", "Tag release")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cl1:Tag release"}, client.items)
+		assert.Equal(t, "Tag release", checklist.Items[0].Name)
+	})
+
+	t.Run("rejects checklist from another task", func(t *testing.T) {
+		client := &fakeChecklistClient{task: task}
+
+		_, err := runChecklistItem(ctx, client, "abc", "cl9", "Tag release")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		assert.Contains(t, err.Error(), "cu task view abc")
+		assert.Empty(t, client.items)
+	})
+
+	t.Run("commands are registered", func(t *testing.T) {
+		for _, name := range []string{"add", "item"} {
+			cmd, _, err := taskChecklistCmd.Find([]string{name})
+			require.NoError(t, err)
+			assert.Equal(t, name, cmd.Name())
+		}
+	})
+}
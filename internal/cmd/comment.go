@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"bufio"
-	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -11,6 +10,7 @@ import (
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/clock"
 	"github.com/timimsms/cu/internal/output"
 )
 
@@ -31,6 +31,8 @@ var (
 	listComments    bool
 	deleteComment   string
 	yesFlag         bool
+	commentLimit    int
+	commentOffset   int
 )
 
 func init() {
@@ -51,6 +53,9 @@ func init() {
 	commentCmd.AddCommand(listCommentsCmd)
 	commentCmd.AddCommand(deleteCommentCmd)
 
+	listCommentsCmd.Flags().IntVar(&commentLimit, "limit", 0, "Maximum number of comments to show (0 for no limit)")
+	listCommentsCmd.Flags().IntVar(&commentOffset, "offset", 0, "Number of comments to skip")
+
 	// Add yes flag to delete subcommand
 	deleteCommentCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Skip confirmation prompt")
 }
@@ -104,13 +109,15 @@ func addComment(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	initCaches()
+
 	// Create API client
 	client, err := api.NewClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Create comment
 	comment, err := client.CreateTaskComment(ctx, taskID, text, commentAssignee, notifyAll)
@@ -146,19 +153,24 @@ var listCommentsCmd = &cobra.Command{
 func listTaskComments(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 
+	if commentOffset < 0 {
+		return fmt.Errorf("--offset cannot be negative")
+	}
+
 	// Create API client
 	client, err := api.NewClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Get comments
-	comments, err := client.GetTaskComments(ctx, taskID)
+	all, err := client.GetTaskComments(ctx, taskID)
 	if err != nil {
 		return fmt.Errorf("failed to get comments: %w", err)
 	}
+	comments := applyOffsetLimit(all, commentOffset, commentLimit)
 
 	// Display results
 	if outputFormat == "json" || outputFormat == "yaml" || outputFormat == "csv" {
@@ -213,7 +225,11 @@ func listTaskComments(cmd *cobra.Command, args []string) error {
 		fmt.Println("No comments found")
 	}
 
-	fmt.Printf("\nTotal comments: %d\n", len(comments))
+	if len(comments) < len(all) {
+		fmt.Printf("\nShowing %d of %d comments\n", len(comments), len(all))
+	} else {
+		fmt.Printf("\nTotal comments: %d\n", len(comments))
+	}
 
 	return nil
 }
@@ -250,7 +266,7 @@ func deleteTaskComment(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Delete comment
 	if err := client.DeleteTaskComment(ctx, commentID); err != nil {
@@ -315,7 +331,7 @@ func formatCommentDate(dateStr string) string {
 	}
 
 	// Format relative time
-	now := time.Now()
+	now := clock.Now()
 	diff := now.Sub(t)
 
 	switch {
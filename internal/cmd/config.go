@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/timimsms/cu/internal/auth"
 	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/fsutil"
 	"github.com/timimsms/cu/internal/output"
 )
 
@@ -21,18 +27,17 @@ var configCmd = &cobra.Command{
 var configListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all configuration settings",
-	Long:  `Display all current configuration settings.`,
+	Long: `Display all current configuration settings.
+
+Use --known to list every key cu understands with its description and
+default, including keys that are not set.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		settings := viper.AllSettings()
-		keys := make([]string, 0, len(settings))
-		for k := range settings {
-			keys = append(keys, k)
+		if known, _ := cmd.Flags().GetBool("known"); known {
+			printKnownKeys(os.Stdout, config.Get)
+			return
 		}
-		sort.Strings(keys)
 
-		for _, key := range keys {
-			fmt.Printf("%s=%v\n", key, settings[key])
-		}
+		printConfigSettings(os.Stdout, viper.AllSettings())
 	},
 }
 
@@ -55,31 +60,54 @@ var configGetCmd = &cobra.Command{
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Set a configuration value",
-	Long:  `Set the value of a specific configuration setting.`,
-	Args:  cobra.ExactArgs(2),
+	Long: `Set the value of a specific configuration setting.
+
+Only keys listed by 'cu config list --known' are accepted. Use --force to set
+any other key. Use --project to write to the project config (.cu.yml)
+instead of the global config.`,
+	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
 		value := args[1]
 
-		// Handle boolean values
-		if strings.ToLower(value) == "true" || strings.ToLower(value) == "false" {
-			config.Set(key, strings.ToLower(value) == "true")
-		} else {
-			config.Set(key, value)
+		force, _ := cmd.Flags().GetBool("force")
+		if err := validateConfigKey(key, force); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		project, _ := cmd.Flags().GetBool("project")
+		if err := setConfigValue(os.Stdout, key, value, project); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
 		}
+	},
+}
 
-		if err := config.Save(); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to save configuration: %v\n", err)
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value",
+	Long: `Remove a configuration setting so its default applies again.
+
+Use --project to remove it from the project config (.cu.yml) instead of the
+global config.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project, _ := cmd.Flags().GetBool("project")
+		if err := unsetConfigValue(os.Stdout, args[0], project); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("Set %s to %s\n", key, value)
 	},
 }
 
 var configInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize project configuration",
-	Long:  `Initialize a project-specific configuration file (.cu.yml) in the current directory.`,
+	Long: `Initialize a project-specific configuration file (.cu.yml) in the current directory.
+
+Use --workspace, --space and --list to seed the file so a fresh clone of the
+project is already configured.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Check if config already exists
 		if config.HasProjectConfig() {
@@ -88,7 +116,12 @@ var configInitCmd = &cobra.Command{
 		}
 
 		// Initialize project config
-		if err := config.InitProjectConfig(); err != nil {
+		space, _ := cmd.Flags().GetString("space")
+		list, _ := cmd.Flags().GetString("list")
+		workspace, _ := cmd.Flags().GetString("workspace")
+		tmpl := config.ProjectTemplate{Space: space, List: list, Workspace: workspace}
+
+		if err := config.InitProjectConfigWith(tmpl); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to initialize project config: %v\n", err)
 			os.Exit(1)
 		}
@@ -117,6 +150,10 @@ var configShowCmd = &cobra.Command{
 				"default_list":   config.GetString("default_list"),
 				"output":         config.GetString("output"),
 				"debug":          config.GetBool("debug"),
+				"no_color":       config.GetBool("no_color"),
+				"compact_json":   config.GetBool("compact_json"),
+				"time_format":    config.GetString("time_format"),
+				"rate_limit":     config.GetInt("rate_limit"),
 			},
 		}
 
@@ -138,10 +175,313 @@ var configShowCmd = &cobra.Command{
 	},
 }
 
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export configuration settings",
+	Long: `Write the current configuration as YAML to stdout or a file.
+
+Keys that may hold credentials, such as tokens, are never exported.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+
+		if file == "" {
+			if err := config.ExportSettings(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to export configuration: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := config.ExportSettings(&buf); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to export configuration: %v\n", err)
+			os.Exit(1)
+		}
+		if err := fsutil.WriteFileAtomic(file, buf.Bytes(), 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", file, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "✓ Exported configuration to %s\n", file)
+	},
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import configuration settings",
+	Long: `Load settings from a YAML file, such as one written by 'cu config export',
+and save them to the global configuration.
+
+Keys that may hold credentials, such as tokens, are skipped.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		defer func() { _ = f.Close() }()
+
+		imported, skipped, err := config.ImportSettings(f)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to import configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✓ Imported %d setting(s)\n", len(imported))
+		for _, key := range skipped {
+			fmt.Printf("  Skipped secret key: %s\n", key)
+		}
+	},
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade stored tokens and configuration to the current format",
+	Long: `Rewrite tokens and configuration saved by older versions of cu in the
+current format, and print what changed:
+
+  - tokens stored as a plain string are stored as structured tokens
+  - an api_token key in the config file is moved to the token store
+
+Running it again after a successful migration changes nothing.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		changes, err := migrateConfig(auth.NewManager(), migrationWorkspaces())
+		for _, change := range changes {
+			fmt.Printf("✓ %s\n", change)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if len(changes) == 0 {
+			fmt.Println("Nothing to migrate")
+		}
+	},
+}
+
+// tokenMigrator is the part of the auth manager used by config migrate
+type tokenMigrator interface {
+	GetToken(workspace string) (*auth.Token, error)
+	SaveToken(workspace string, token *auth.Token) error
+	MigrateToken(workspace string) (bool, error)
+}
+
+// migrationWorkspaces returns the workspaces whose stored tokens config
+// migrate checks. The keyring can't list its entries, so these are the
+// default workspace and the one set as default_workspace.
+func migrationWorkspaces() []string {
+	workspaces := []string{auth.DefaultWorkspace}
+	if workspace := config.GetString("default_workspace"); workspace != "" && workspace != auth.DefaultWorkspace {
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces
+}
+
+// migrateConfig upgrades legacy stored tokens and config keys and returns a
+// description of each change
+func migrateConfig(authMgr tokenMigrator, workspaces []string) ([]string, error) {
+	var changes []string
+
+	for _, workspace := range workspaces {
+		migrated, err := authMgr.MigrateToken(workspace)
+		if err != nil {
+			return changes, fmt.Errorf("failed to migrate token for workspace %s: %w", workspace, err)
+		}
+		if migrated {
+			changes = append(changes, fmt.Sprintf("Converted the token for workspace %s to the current format", workspace))
+		}
+	}
+
+	legacyToken := config.GetString("api_token")
+	if legacyToken == "" {
+		return changes, nil
+	}
+
+	stored, err := authMgr.GetToken(auth.DefaultWorkspace)
+	switch {
+	case err == errors.ErrNotAuthenticated:
+		token := &auth.Token{Value: legacyToken, Workspace: auth.DefaultWorkspace}
+		if err := authMgr.SaveToken(auth.DefaultWorkspace, token); err != nil {
+			return changes, err
+		}
+		changes = append(changes, "Moved api_token from the config file to the token store")
+	case err != nil:
+		return changes, err
+	case stored.Value != legacyToken:
+		return changes, errors.NewUserError(
+			"The api_token in the config file differs from the stored token",
+			"Remove api_token from the config file, or run 'cu auth login' to replace the stored token",
+			errors.ErrInvalidInput,
+		)
+	default:
+		changes = append(changes, "Removed api_token from the config file; the same token is already stored")
+	}
+
+	if err := config.Unset("api_token"); err != nil {
+		return changes, fmt.Errorf("failed to update config: %w", err)
+	}
+	return changes, nil
+}
+
+// validateConfigKey rejects keys that cu does not read, suggesting the
+// closest known key. force skips the check.
+func validateConfigKey(key string, force bool) error {
+	if force {
+		return nil
+	}
+	if _, ok := config.LookupKey(key); ok {
+		return nil
+	}
+
+	suggestion := "Run 'cu config list --known' to see valid keys, or use --force to set it anyway"
+	if known, ok := config.SuggestKey(key); ok {
+		suggestion = fmt.Sprintf("Did you mean '%s'? Use --force to set '%s' anyway", known, key)
+	}
+	return errors.NewUserError(
+		fmt.Sprintf("Unknown configuration key '%s'", key),
+		suggestion,
+		errors.ErrInvalidInput,
+	)
+}
+
+// errNoProjectConfig is returned by --project writes outside a project
+func errNoProjectConfig() error {
+	return errors.NewUserError(
+		"No project config found",
+		"Run 'cu config init' to create .cu.yml in this directory",
+		errors.ErrNotFound,
+	)
+}
+
+// setConfigValue saves key in the project config when project is set,
+// otherwise in the global config. "true" and "false" are saved as booleans.
+func setConfigValue(w io.Writer, key, value string, project bool) error {
+	var typed interface{} = value
+	if strings.EqualFold(value, "true") || strings.EqualFold(value, "false") {
+		typed = strings.EqualFold(value, "true")
+	}
+
+	if project {
+		if !config.HasProjectConfig() {
+			return errNoProjectConfig()
+		}
+		if err := config.SaveProjectConfig(map[string]interface{}{key: typed}); err != nil {
+			return fmt.Errorf("failed to save project configuration: %w", err)
+		}
+		if err := dropDefaultListName(key, true); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Set %s to %s in project config: %s\n", key, value, config.GetProjectConfigPath())
+		return nil
+	}
+
+	config.Set(key, typed)
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	if err := dropDefaultListName(key, false); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Set %s to %s\n", key, value)
+	return nil
+}
+
+// dropDefaultListName removes the list name saved by list default once
+// default_list is changed by hand, since it names the previous list
+func dropDefaultListName(key string, project bool) error {
+	if key != "default_list" {
+		return nil
+	}
+	unset := config.Unset
+	if project {
+		unset = config.UnsetProjectConfig
+	}
+	if err := unset("default_list_name"); err != nil {
+		return fmt.Errorf("failed to remove default_list_name: %w", err)
+	}
+	return nil
+}
+
+// unsetConfigValue removes key from the project config when project is set,
+// otherwise from the global config
+func unsetConfigValue(w io.Writer, key string, project bool) error {
+	if project {
+		if !config.HasProjectConfig() {
+			return errNoProjectConfig()
+		}
+		if err := config.UnsetProjectConfig(key); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", key, err)
+		}
+		if err := dropDefaultListName(key, true); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Unset %s in project config: %s\n", key, config.GetProjectConfigPath())
+		return nil
+	}
+
+	if err := config.Unset(key); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", key, err)
+	}
+	if err := dropDefaultListName(key, false); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Unset %s\n", key)
+	return nil
+}
+
+// printConfigSettings prints settings as key=value lines sorted by key, so
+// the output is the same on every run. Nested maps print sorted by fmt.
+func printConfigSettings(w io.Writer, settings map[string]interface{}) {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s=%v\n", key, settings[key])
+	}
+}
+
+// printKnownKeys writes every known configuration key with its current
+// value, default and description
+func printKnownKeys(w io.Writer, get func(string) interface{}) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer func() { _ = tw.Flush() }()
+
+	_, _ = fmt.Fprintln(tw, "KEY\tVALUE\tDEFAULT\tDESCRIPTION")
+	for _, key := range config.KnownKeys() {
+		value := "(unset)"
+		if v := get(key.Name); v != nil && fmt.Sprint(v) != "" {
+			value = fmt.Sprint(v)
+		}
+		def := key.Default
+		if def == "" {
+			def = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", key.Name, value, def, key.Description)
+	}
+}
+
 func init() {
+	configInitCmd.Flags().String("workspace", "", "Default workspace to write into .cu.yml")
+	configInitCmd.Flags().String("space", "", "Default space to write into .cu.yml")
+	configInitCmd.Flags().String("list", "", "Default list to write into .cu.yml")
+	configSetCmd.Flags().Bool("force", false, "Set the key even if cu does not recognize it")
+	configSetCmd.Flags().BoolP("project", "p", false, "Save to project config instead of global config")
+	configUnsetCmd.Flags().BoolP("project", "p", false, "Remove from project config instead of global config")
+	configExportCmd.Flags().StringP("file", "f", "", "Write to this file instead of stdout")
+	configListCmd.Flags().Bool("known", false, "List all known keys with descriptions, including unset ones")
+
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+	configCmd.AddCommand(configMigrateCmd)
 }
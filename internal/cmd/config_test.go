@@ -1,15 +1,42 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/auth"
+	"github.com/timimsms/cu/internal/auth/mock"
+	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
 )
 
 // Simple tests that don't involve os.Exit
 
+// writeGlobalConfig points the global config at a temporary directory,
+// writes content to its config file and reads it in the way cu does on
+// startup. It returns the file's path.
+func writeGlobalConfig(t *testing.T, content string) string {
+	t.Helper()
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	t.Cleanup(func() { config.DefaultConfigDir = oldConfigDir })
+
+	path := filepath.Join(config.DefaultConfigDir, config.ConfigFileName+"."+config.ConfigType)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	config.Reset()
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+	return path
+}
+
 func TestConfigCommand_Basic(t *testing.T) {
 	cmd := configCmd
 	assert.NotNil(t, cmd)
@@ -18,11 +45,13 @@ func TestConfigCommand_Basic(t *testing.T) {
 
 	// Verify subcommands
 	subcommands := map[string]bool{
-		"list": false,
-		"get":  false,
-		"set":  false,
-		"init": false,
-		"show": false,
+		"list":   false,
+		"get":    false,
+		"set":    false,
+		"init":   false,
+		"show":   false,
+		"export": false,
+		"import": false,
 	}
 
 	for _, child := range cmd.Commands() {
@@ -87,7 +116,7 @@ func TestConfigValueHandling(t *testing.T) {
 		originalViper.Set(key, viper.Get(key))
 	}
 	defer func() {
-		viper.Reset()
+		config.Reset()
 		for _, key := range originalViper.AllKeys() {
 			viper.Set(key, originalViper.Get(key))
 		}
@@ -127,7 +156,7 @@ func TestConfigValueHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			viper.Reset()
+			config.Reset()
 			tt.setup()
 
 			value := viper.Get(tt.key)
@@ -135,3 +164,259 @@ func TestConfigValueHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintConfigSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"rate_limit":   250,
+		"default_list": "901",
+		"output":       "json",
+		"aliases":      map[string]interface{}{"me": "7", "bob": "8"},
+		"debug":        false,
+	}
+
+	var buf bytes.Buffer
+	printConfigSettings(&buf, settings)
+	assert.Equal(t, "aliases=map[bob:8 me:7]\ndebug=false\ndefault_list=901\noutput=json\nrate_limit=250\n", buf.String())
+}
+
+func TestSetConfigValue(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	setup := func(t *testing.T) string {
+		oldConfigDir := config.DefaultConfigDir
+		config.DefaultConfigDir = t.TempDir()
+		t.Cleanup(func() { config.DefaultConfigDir = oldConfigDir })
+
+		projectDir := t.TempDir()
+		t.Chdir(projectDir)
+		config.Reset()
+		return projectDir
+	}
+
+	t.Run("project writes to .cu.yml", func(t *testing.T) {
+		projectDir := setup(t)
+		projectFile := filepath.Join(projectDir, config.ProjectConfigFileName)
+		require.NoError(t, os.WriteFile(projectFile, []byte("default_space: space1\n"), 0600))
+		require.NoError(t, config.Init(""))
+
+		var buf bytes.Buffer
+		require.NoError(t, setConfigValue(&buf, "default_list", "list123", true))
+		require.NoError(t, setConfigValue(&buf, "no_color", "true", true))
+		assert.Contains(t, buf.String(), "Set default_list to list123 in project config")
+		assert.Equal(t, "list123", config.GetString("default_list"))
+
+		data, err := os.ReadFile(projectFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "default_list: list123")
+		assert.Contains(t, string(data), "no_color: true")
+		assert.Contains(t, string(data), "default_space: space1")
+		assert.NoFileExists(t, filepath.Join(config.DefaultConfigDir, config.ConfigFileName+"."+config.ConfigType))
+
+		buf.Reset()
+		require.NoError(t, unsetConfigValue(&buf, "default_list", true))
+		assert.Contains(t, buf.String(), "Unset default_list in project config")
+		data, err = os.ReadFile(projectFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "default_list")
+	})
+
+	t.Run("project without a project config", func(t *testing.T) {
+		projectDir := setup(t)
+		require.NoError(t, config.Init(""))
+
+		err := setConfigValue(io.Discard, "default_list", "list123", true)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		assert.Contains(t, err.Error(), "cu config init")
+		assert.NoFileExists(t, filepath.Join(projectDir, config.ProjectConfigFileName))
+
+		err = unsetConfigValue(io.Discard, "default_list", true)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		setup(t)
+		require.NoError(t, config.Init(""))
+
+		var buf bytes.Buffer
+		require.NoError(t, setConfigValue(&buf, "default_list", "list123", false))
+		assert.Equal(t, "Set default_list to list123\n", buf.String())
+
+		data, err := os.ReadFile(filepath.Join(config.DefaultConfigDir, config.ConfigFileName+"."+config.ConfigType))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "default_list: list123")
+	})
+
+	t.Run("changing default_list drops the saved list name", func(t *testing.T) {
+		setup(t)
+		path := writeGlobalConfig(t, "default_list: list123\ndefault_list_name: Sprint 12\n")
+
+		require.NoError(t, setConfigValue(io.Discard, "default_list", "list456", false))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.Equal(t, "default_list: list456\n", string(data))
+	})
+
+	t.Run("global unset removes the key from the file", func(t *testing.T) {
+		setup(t)
+		path := writeGlobalConfig(t, "default_list: list123\ndefault_space: space1\n")
+		require.NoError(t, config.Init(""))
+
+		var buf bytes.Buffer
+		require.NoError(t, unsetConfigValue(&buf, "default_list", false))
+		assert.Equal(t, "Unset default_list\n", buf.String())
+		assert.Empty(t, config.GetString("default_list"))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.Equal(t, "default_space: space1\n", string(data))
+	})
+
+	t.Run("global set inside a project keeps project keys out", func(t *testing.T) {
+		projectDir := setup(t)
+		path := writeGlobalConfig(t, "default_space: space1\n")
+		projectFile := filepath.Join(projectDir, config.ProjectConfigFileName)
+		require.NoError(t, os.WriteFile(projectFile, []byte("project_name: proj\ndefault_list: list9\n"), 0600))
+		require.NoError(t, config.Init(""))
+		require.Equal(t, "list9", config.GetString("default_list"))
+
+		require.NoError(t, setConfigValue(io.Discard, "output", "json", false))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.Equal(t, "default_space: space1\noutput: json\n", string(data))
+	})
+}
+
+func TestPrintKnownKeys(t *testing.T) {
+	values := map[string]interface{}{
+		"default_list": "901",
+		"rate_limit":   250,
+	}
+	get := func(key string) interface{} { return values[key] }
+
+	var buf bytes.Buffer
+	printKnownKeys(&buf, get)
+	out := buf.String()
+
+	for _, key := range []string{
+		"default_list", "default_space", "default_folder", "default_workspace",
+		"output", "debug", "no_color", "rate_limit",
+		"cache_encrypt", "cache_ttl", "cache_ttl_tasks", "cache_ttl_workspaces",
+	} {
+		assert.Regexp(t, `(?m)^`+key+`\s`, out, key)
+	}
+
+	assert.Regexp(t, `(?m)^default_list\s+901\s+-\s+List used`, out)
+	assert.Regexp(t, `(?m)^rate_limit\s+250\s+100\s+Maximum API requests`, out)
+	assert.Regexp(t, `(?m)^default_space\s+\(unset\)\s+-\s`, out)
+
+	t.Run("known flag is registered", func(t *testing.T) {
+		assert.NotNil(t, configListCmd.Flags().Lookup("known"))
+	})
+}
+
+func TestValidateConfigKey(t *testing.T) {
+	t.Run("known key", func(t *testing.T) {
+		assert.NoError(t, validateConfigKey("default_list", false))
+	})
+
+	t.Run("typo suggests known key", func(t *testing.T) {
+		err := validateConfigKey("deafult_list", false)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "Unknown configuration key 'deafult_list'")
+		assert.Contains(t, err.Error(), "Did you mean 'default_list'?")
+	})
+
+	t.Run("unrelated key points to known list", func(t *testing.T) {
+		err := validateConfigKey("favorite_color", false)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "cu config list --known")
+	})
+
+	t.Run("force bypasses validation", func(t *testing.T) {
+		assert.NoError(t, validateConfigKey("deafult_list", true))
+		assert.NotNil(t, configSetCmd.Flags().Lookup("force"))
+	})
+}
+
+func TestMigrateConfig(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	setup := func(t *testing.T, content string) (*auth.Manager, *mock.KeyringMock, string) {
+		path := writeGlobalConfig(t, content)
+		k := mock.NewKeyringMock()
+		return auth.NewManagerWithKeyring(k), k, path
+	}
+
+	t.Run("legacy token and config key", func(t *testing.T) {
+		authMgr, k, path := setup(t, "api_token: "+mock.ValidToken+"\ndefault_list: list1\n")
+		require.NoError(t, k.Set(auth.ServiceName, "work", mock.LegacyToken))
+
+		changes, err := migrateConfig(authMgr, []string{auth.DefaultWorkspace, "work"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"Converted the token for workspace work to the current format",
+			"Moved api_token from the config file to the token store",
+		}, changes)
+
+		raw, err := k.Get(auth.ServiceName, "work")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"value": "`+mock.LegacyToken+`", "workspace": "work"}`, raw)
+
+		token, err := authMgr.GetToken(auth.DefaultWorkspace)
+		require.NoError(t, err)
+		assert.Equal(t, mock.ValidToken, token.Value)
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "api_token")
+		assert.NotContains(t, string(data), mock.ValidToken)
+		assert.Contains(t, string(data), "default_list: list1")
+
+		// A second run, even after cu restarts and rereads the file, finds
+		// nothing to do
+		changes, err = migrateConfig(authMgr, []string{auth.DefaultWorkspace, "work"})
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+
+		config.Reset()
+		viper.SetConfigFile(path)
+		require.NoError(t, viper.ReadInConfig())
+		changes, err = migrateConfig(authMgr, []string{auth.DefaultWorkspace, "work"})
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("config key matching the stored token is removed", func(t *testing.T) {
+		authMgr, _, path := setup(t, "api_token: "+mock.ValidToken+"\n")
+		require.NoError(t, authMgr.SaveToken(auth.DefaultWorkspace, &auth.Token{Value: mock.ValidToken}))
+
+		changes, err := migrateConfig(authMgr, []string{auth.DefaultWorkspace})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Removed api_token from the config file; the same token is already stored"}, changes)
+		assert.Empty(t, config.GetString("api_token"))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "api_token")
+	})
+
+	t.Run("conflicting config key is kept", func(t *testing.T) {
+		authMgr, _, path := setup(t, "api_token: "+mock.LegacyToken+"\n")
+		require.NoError(t, authMgr.SaveToken(auth.DefaultWorkspace, &auth.Token{Value: mock.ValidToken}))
+
+		changes, err := migrateConfig(authMgr, []string{auth.DefaultWorkspace})
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Empty(t, changes)
+		assert.Equal(t, mock.LegacyToken, config.GetString("api_token"))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "api_token: "+mock.LegacyToken)
+
+		token, err := authMgr.GetToken(auth.DefaultWorkspace)
+		require.NoError(t, err)
+		assert.Equal(t, mock.ValidToken, token.Value)
+	})
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/output"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose problems talking to ClickUp",
+	Long:  `Run checks that help explain failing or slow commands.`,
+}
+
+var doctorPingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Measure the round-trip time to ClickUp",
+	Long: `Time a request for the current user and report the latency, along with
+the requests per minute cu's rate limiter allows and how many requests
+ClickUp has left for your token. When ClickUp is throttling the token, the
+time its limit resets is shown.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		result, err := runDoctorPing(ctx, client)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "✗ Could not reach ClickUp: %v\n", err)
+			os.Exit(1)
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			printPingResult(os.Stdout, result)
+		} else {
+			if err := output.Format(format, result); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.AddCommand(doctorPingCmd)
+}
+
+// pinger is the part of the API client used by doctor ping
+type pinger interface {
+	GetCurrentUserRate(ctx context.Context) (*clickup.User, *clickup.Rate, error)
+	RateLimit() int
+}
+
+// pingResult is the outcome of doctor ping
+type pingResult struct {
+	User      string        `json:"user"`
+	Latency   time.Duration `json:"-"`
+	LatencyMS int64         `json:"latency_ms"`
+	RateLimit int           `json:"rate_limit"`
+	// Throttled is set when ClickUp has no requests left for the token
+	Throttled bool `json:"throttled"`
+	// Remaining, Limit and Reset are ClickUp's rate limit headers for the
+	// request, left empty when it sent none
+	Remaining *int       `json:"remaining,omitempty"`
+	Limit     int        `json:"limit,omitempty"`
+	Reset     *time.Time `json:"reset,omitempty"`
+}
+
+// runDoctorPing times a request for the current user and reads ClickUp's
+// rate limit state from its response. A 429 from ClickUp is reported as
+// throttled rather than as a failure, since it still reached ClickUp.
+func runDoctorPing(ctx context.Context, client pinger) (*pingResult, error) {
+	start := time.Now()
+	user, rate, err := client.GetCurrentUserRate(ctx)
+	latency := time.Since(start)
+
+	result := &pingResult{
+		Latency:   latency,
+		LatencyMS: latency.Milliseconds(),
+		RateLimit: client.RateLimit(),
+	}
+
+	var rateErr *clickup.RateLimitError
+	switch {
+	case stderrors.As(err, &rateErr):
+		rate = &rateErr.Rate
+	case err != nil:
+		return nil, err
+	default:
+		result.User = user.Username
+	}
+
+	if rate != nil {
+		remaining := rate.Remaining
+		result.Remaining = &remaining
+		result.Limit = rate.Limit
+		result.Throttled = remaining == 0
+		if !rate.Reset.IsZero() {
+			reset := rate.Reset.Time
+			result.Reset = &reset
+		}
+	}
+	return result, nil
+}
+
+func printPingResult(w io.Writer, result *pingResult) {
+	if result.User != "" {
+		fmt.Fprintf(w, "✓ Reached ClickUp as %s in %s\n", result.User, result.Latency.Round(time.Millisecond))
+	} else {
+		fmt.Fprintf(w, "✓ Reached ClickUp in %s\n", result.Latency.Round(time.Millisecond))
+	}
+	fmt.Fprintf(w, "  Rate limit: %d requests/minute\n", result.RateLimit)
+
+	if result.Remaining == nil {
+		return
+	}
+	until := ""
+	if result.Reset != nil {
+		until = " until " + result.Reset.Format("15:04:05")
+	}
+	if result.Throttled {
+		fmt.Fprintf(w, "✗ ClickUp is throttling this token%s\n", until)
+		return
+	}
+	fmt.Fprintf(w, "  ClickUp rate limit: %d of %d requests left%s\n", *result.Remaining, result.Limit, until)
+}
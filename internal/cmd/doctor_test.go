@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePinger answers GetCurrentUserRate after delay
+type fakePinger struct {
+	delay time.Duration
+	rate  *clickup.Rate
+	err   error
+}
+
+func (f *fakePinger) GetCurrentUserRate(ctx context.Context) (*clickup.User, *clickup.Rate, error) {
+	time.Sleep(f.delay)
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	return &clickup.User{ID: 1, Username: "alice"}, f.rate, nil
+}
+
+func (f *fakePinger) RateLimit() int {
+	return 100
+}
+
+func TestRunDoctorPing(t *testing.T) {
+	reset := time.Date(2026, 10, 17, 10, 32, 5, 0, time.Local)
+
+	t.Run("reports latency of the request", func(t *testing.T) {
+		client := &fakePinger{delay: 20 * time.Millisecond}
+
+		result, err := runDoctorPing(context.Background(), client)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", result.User)
+		assert.GreaterOrEqual(t, result.Latency, 20*time.Millisecond)
+		assert.GreaterOrEqual(t, result.LatencyMS, int64(20))
+		assert.Equal(t, 100, result.RateLimit)
+		assert.Nil(t, result.Remaining)
+		assert.False(t, result.Throttled)
+	})
+
+	t.Run("reports the requests ClickUp has left", func(t *testing.T) {
+		client := &fakePinger{rate: &clickup.Rate{Limit: 100, Remaining: 87, Reset: clickup.Timestamp{Time: reset}}}
+
+		result, err := runDoctorPing(context.Background(), client)
+		require.NoError(t, err)
+		require.NotNil(t, result.Remaining)
+		assert.Equal(t, 87, *result.Remaining)
+		assert.Equal(t, 100, result.Limit)
+		assert.False(t, result.Throttled)
+		assert.Equal(t, reset, *result.Reset)
+	})
+
+	t.Run("rate limit error is reported as throttled", func(t *testing.T) {
+		client := &fakePinger{err: &clickup.RateLimitError{
+			Rate:    clickup.Rate{Limit: 100, Remaining: 0, Reset: clickup.Timestamp{Time: reset}},
+			Message: "Rate limit reached",
+		}}
+
+		result, err := runDoctorPing(context.Background(), client)
+		require.NoError(t, err)
+		assert.True(t, result.Throttled)
+		assert.Empty(t, result.User)
+		assert.Equal(t, reset, *result.Reset)
+	})
+
+	t.Run("request failure", func(t *testing.T) {
+		_, err := runDoctorPing(context.Background(), &fakePinger{err: stderrors.New("dial tcp: timeout")})
+		assert.EqualError(t, err, "dial tcp: timeout")
+	})
+
+	t.Run("human output", func(t *testing.T) {
+		var buf bytes.Buffer
+		printPingResult(&buf, &pingResult{User: "alice", Latency: 182400 * time.Microsecond, RateLimit: 100})
+		assert.Equal(t, "✓ Reached ClickUp as alice in 182ms\n  Rate limit: 100 requests/minute\n", buf.String())
+	})
+
+	t.Run("human output with ClickUp's rate", func(t *testing.T) {
+		remaining := 87
+		var buf bytes.Buffer
+		printPingResult(&buf, &pingResult{User: "alice", Latency: 182400 * time.Microsecond, RateLimit: 100, Remaining: &remaining, Limit: 100, Reset: &reset})
+		assert.Equal(t, "✓ Reached ClickUp as alice in 182ms\n  Rate limit: 100 requests/minute\n  ClickUp rate limit: 87 of 100 requests left until 10:32:05\n", buf.String())
+	})
+
+	t.Run("human output when throttled", func(t *testing.T) {
+		remaining := 0
+		var buf bytes.Buffer
+		printPingResult(&buf, &pingResult{Latency: 182400 * time.Microsecond, RateLimit: 100, Throttled: true, Remaining: &remaining, Limit: 100, Reset: &reset})
+		assert.Equal(t, "✓ Reached ClickUp in 182ms\n  Rate limit: 100 requests/minute\n✗ ClickUp is throttling this token until 10:32:05\n", buf.String())
+	})
+}
@@ -2,17 +2,21 @@ package cmd
 
 import (
 	"context"
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
 )
 
 var exportCmd = &cobra.Command{
@@ -34,9 +38,24 @@ Examples:
   cu export tasks --list mylist --status open --format json > open-tasks.json
   
   # Generate a Markdown report of high priority tasks
-  cu export tasks --priority high --format markdown --output report.md`,
+  cu export tasks --priority high --format markdown --output report.md
+
+  # Export only some columns, in the given order
+  cu export tasks --list mylist --select id,name,status,due --output tasks.csv
+
+  # Preview how many tasks a space export would write, by status
+  cu export tasks --space Engineering --output tasks.csv --dry-run
+
+Columns for --select: id, name, status, priority, assignees, due, created,
+updated, url. With --select, markdown exports are a table of those columns
+instead of a report.
+
+Lists that cannot be read are skipped and reported on stderr, and the
+command then exits with status 1 after writing the tasks it could read. With
+--with-errors, json exports are an object with the tasks and an errors array
+listing the skipped parts of the workspace.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Get flags
 		listID, _ := cmd.Flags().GetString("list")
@@ -46,6 +65,10 @@ Examples:
 		status, _ := cmd.Flags().GetString("status")
 		priority, _ := cmd.Flags().GetString("priority")
 		assignee, _ := cmd.Flags().GetString("assignee")
+		customFields, _ := cmd.Flags().GetBool("custom-fields")
+		selectKeys, _ := cmd.Flags().GetStringSlice("select")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		withErrors, _ := cmd.Flags().GetBool("with-errors")
 
 		// Validate format
 		format = strings.ToLower(format)
@@ -57,6 +80,25 @@ Examples:
 			format = "markdown"
 		}
 
+		if withErrors && format != "json" {
+			fmt.Fprintln(os.Stderr, "--with-errors applies to json exports")
+			os.Exit(1)
+		}
+
+		var columns []exportColumn
+		if len(selectKeys) > 0 {
+			if format == "json" {
+				fmt.Fprintln(os.Stderr, "--select applies to csv and markdown exports")
+				os.Exit(1)
+			}
+			selected, err := selectExportColumns(selectKeys)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			columns = selected
+		}
+
 		// Create API client
 		client, err := api.NewClient()
 		if err != nil {
@@ -64,125 +106,293 @@ Examples:
 			os.Exit(1)
 		}
 
+		// Resolve space and list names to IDs
+		spaceID, listID, err = resolveSpaceAndList(ctx, client, spaceID, listID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
 		// Get tasks based on parameters
 		var tasks []clickup.Task
+		var fetchErrors []fetchError
 
 		if listID != "" {
 			// Get tasks from specific list
-			queryOpts := &api.TaskQueryOptions{}
+			queryOpts := &api.TaskQueryOptions{IncludeCustomFields: customFields}
 			if status != "" {
 				queryOpts.Statuses = []string{status}
 			}
 			if assignee != "" {
 				queryOpts.Assignees = []string{assignee}
 			}
-			if priority != "" {
-				switch priority {
-				case "urgent":
-					p := 1
-					queryOpts.Priority = &p
-				case "high":
-					p := 2
-					queryOpts.Priority = &p
-				case "normal":
-					p := 3
-					queryOpts.Priority = &p
-				case "low":
-					p := 4
-					queryOpts.Priority = &p
-				}
+			if p, ok := api.ParsePriority(priority); ok {
+				queryOpts.Priority = &p
 			}
 
-			tasks, err = client.GetTasks(ctx, listID, queryOpts)
+			tasks, err = client.GetAllTasks(ctx, listID, queryOpts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to get tasks: %v\n", err)
 				os.Exit(1)
 			}
 		} else {
 			// Get all tasks from workspace or space
-			workspaces, err := client.GetWorkspaces(ctx)
+			tasks, fetchErrors, err = collectExportTasks(ctx, client, spaceID, customFields)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to get workspaces: %v\n", err)
+				fmt.Fprintf(os.Stderr, "%v\n", err)
 				os.Exit(1)
 			}
-
-			for _, workspace := range workspaces {
-				spaces, err := client.GetSpaces(ctx, workspace.ID)
-				if err != nil {
-					continue
-				}
-
-				for _, space := range spaces {
-					if spaceID != "" && space.ID != spaceID && space.Name != spaceID {
-						continue
-					}
-
-					// Get tasks from all lists in space
-					folders, _ := client.GetFolders(ctx, space.ID)
-					for _, folder := range folders {
-						lists, _ := client.GetLists(ctx, folder.ID)
-						for _, list := range lists {
-							listTasks, err := client.GetTasks(ctx, list.ID, &api.TaskQueryOptions{})
-							if err == nil {
-								tasks = append(tasks, listTasks...)
-							}
-						}
-					}
-
-					// Get folderless lists
-					lists, _ := client.GetFolderlessLists(ctx, space.ID)
-					for _, list := range lists {
-						listTasks, err := client.GetTasks(ctx, list.ID, &api.TaskQueryOptions{})
-						if err == nil {
-							tasks = append(tasks, listTasks...)
-						}
-					}
-				}
-			}
+			printFetchErrors(os.Stderr, "export", fetchErrors)
 
 			// Client-side filtering
 			tasks = filterTasksForExport(tasks, status, priority, assignee)
 		}
 
-		// Open output file or use stdout
-		var output *os.File
+		var errs []fetchError
+		if withErrors {
+			errs = nonNilFetchErrors(fetchErrors)
+		}
+		if err := writeExport(os.Stdout, outputFile, format, tasks, columns, errs, dryRun); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		// The export is incomplete
+		if len(fetchErrors) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// writeExport writes tasks in format to outputFile, or to w without one. A
+// non-nil fetchErrors makes json exports an object with the tasks and the
+// errors. With dryRun nothing is written; w gets the task count and a
+// breakdown by status instead.
+func writeExport(w io.Writer, outputFile, format string, tasks []clickup.Task, columns []exportColumn, fetchErrors []fetchError, dryRun bool) error {
+	var cleanPath string
+	if outputFile != "" {
+		// Sanitize the file path to prevent directory traversal
+		cleanPath = filepath.Clean(outputFile)
+		if filepath.IsAbs(cleanPath) || strings.Contains(cleanPath, "..") {
+			return errors.NewUserError(
+				fmt.Sprintf("Invalid output file path: %s", outputFile),
+				"Use a relative path inside the current directory",
+				errors.ErrInvalidInput,
+			)
+		}
+	}
+
+	if dryRun {
+		destination := "stdout"
 		if outputFile != "" {
-			// Sanitize the file path to prevent directory traversal
-			cleanPath := filepath.Clean(outputFile)
-			if filepath.IsAbs(cleanPath) || strings.Contains(cleanPath, "..") {
-				fmt.Fprintf(os.Stderr, "Invalid output file path: %s\n", outputFile)
-				os.Exit(1)
-			}
-			file, err := os.Create(cleanPath)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to create output file: %v\n", err)
-				os.Exit(1)
-			}
-			defer file.Close()
-			output = file
-		} else {
-			output = os.Stdout
+			destination = outputFile
+		}
+		fmt.Fprintf(w, "Would export %d task(s) as %s to %s\n", len(tasks), format, destination)
+		printStatusBreakdown(w, tasks)
+		return nil
+	}
+
+	// Open output file or use stdout
+	out := w
+	if outputFile != "" {
+		file, err := os.Create(cleanPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	// Export based on format
+	var err error
+	switch format {
+	case "csv":
+		err = exportTasksToCSV(out, tasks, columns)
+	case "json":
+		err = exportTasksToJSON(out, tasks, fetchErrors)
+	case "markdown":
+		err = exportTasksToMarkdown(out, tasks, columns)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export tasks: %w", err)
+	}
+
+	if outputFile != "" {
+		fmt.Fprintf(w, "✓ Exported %d task(s) to %s\n", len(tasks), outputFile)
+	}
+	return nil
+}
+
+// printStatusBreakdown writes the number of tasks in each status, most
+// common first
+func printStatusBreakdown(w io.Writer, tasks []clickup.Task) {
+	counts := map[string]int{}
+	for _, task := range tasks {
+		status := getTaskStatus(task)
+		if status == "" {
+			status = "(no status)"
+		}
+		counts[status]++
+	}
+
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if counts[statuses[i]] != counts[statuses[j]] {
+			return counts[statuses[i]] > counts[statuses[j]]
 		}
+		return statuses[i] < statuses[j]
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer func() { _ = tw.Flush() }()
+	for _, status := range statuses {
+		fmt.Fprintf(tw, "  %s\t%d\n", status, counts[status])
+	}
+}
+
+// workspaceClient is the part of the API client used to walk every list in
+// the workspaces
+type workspaceClient interface {
+	GetWorkspaces(ctx context.Context) ([]clickup.Team, error)
+	hierarchyClient
+}
+
+// exportClient is the part of the API client used to collect tasks for export
+type exportClient interface {
+	workspaceClient
+	GetAllTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error)
+}
+
+// fetchError is a part of the workspace hierarchy that could not be fetched
+type fetchError struct {
+	// Type is the kind of node whose children failed: workspace, space,
+	// folder or list
+	Type    string `json:"type" yaml:"type"`
+	ID      string `json:"id" yaml:"id"`
+	Name    string `json:"name" yaml:"name"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// partialTasks is the json and yaml output of --with-errors: the tasks that
+// could be fetched and the parts of the workspace that could not
+type partialTasks struct {
+	Tasks  []clickup.Task `json:"tasks" yaml:"tasks"`
+	Errors []fetchError   `json:"errors" yaml:"errors"`
+}
+
+// newPartialTasks returns the --with-errors output, with empty arrays rather
+// than nulls so the shape is the same whether or not anything failed
+func newPartialTasks(tasks []clickup.Task, fetchErrors []fetchError) partialTasks {
+	if tasks == nil {
+		tasks = []clickup.Task{}
+	}
+	return partialTasks{Tasks: tasks, Errors: nonNilFetchErrors(fetchErrors)}
+}
+
+// nonNilFetchErrors returns fetchErrors, or an empty slice for nil
+func nonNilFetchErrors(fetchErrors []fetchError) []fetchError {
+	if fetchErrors == nil {
+		return []fetchError{}
+	}
+	return fetchErrors
+}
+
+// collectExportTasks fetches every page of tasks from every list in the
+// workspaces, or only in spaceID when set
+func collectExportTasks(ctx context.Context, client exportClient, spaceID string, includeCustomFields bool) ([]clickup.Task, []fetchError, error) {
+	queryOpts := &api.TaskQueryOptions{IncludeCustomFields: includeCustomFields}
+	return collectWorkspaceTasks(ctx, client, spaceID, func(listID string) ([]clickup.Task, error) {
+		return client.GetAllTasks(ctx, listID, queryOpts)
+	})
+}
+
+// collectWorkspaceTasks walks every list in the workspaces, or only in spaceID
+// when set, and gets its tasks with fetch. A node that cannot be fetched is
+// recorded and its siblings are still fetched, so the tasks that could be
+// read are returned alongside the failures. Only a failure to get the
+// workspaces themselves, or having none, is returned as an error.
+func collectWorkspaceTasks(ctx context.Context, client workspaceClient, spaceID string, fetch func(listID string) ([]clickup.Task, error)) ([]clickup.Task, []fetchError, error) {
+	workspaces, err := client.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, nil, errors.NewUserError(fmt.Sprintf("Failed to get workspaces: %v", err), "", err)
+	}
+	if len(workspaces) == 0 {
+		return nil, nil, errors.NewUserError("No workspaces found", "", errors.ErrNotFound)
+	}
+
+	var tasks []clickup.Task
+	var fetchErrors []fetchError
+	fail := func(nodeType, id, name, what string, err error) {
+		fetchErrors = append(fetchErrors, fetchError{
+			Type:    nodeType,
+			ID:      id,
+			Name:    name,
+			Message: fmt.Sprintf("Failed to get %s for %s %s: %v", what, nodeType, name, err),
+		})
+	}
 
-		// Export based on format
-		switch format {
-		case "csv":
-			err = exportTasksToCSV(output, tasks)
-		case "json":
-			err = exportTasksToJSON(output, tasks)
-		case "markdown":
-			err = exportTasksToMarkdown(output, tasks)
+	addListTasks := func(lists []clickup.List) {
+		for _, list := range lists {
+			listTasks, err := fetch(list.ID)
+			if err != nil {
+				fail("list", list.ID, list.Name, "tasks", err)
+				continue
+			}
+			tasks = append(tasks, listTasks...)
 		}
+	}
 
+	for _, workspace := range workspaces {
+		spaces, err := client.GetSpaces(ctx, workspace.ID)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to export tasks: %v\n", err)
-			os.Exit(1)
+			fail("workspace", workspace.ID, workspace.Name, "spaces", err)
+			continue
 		}
 
-		if outputFile != "" {
-			fmt.Printf("✓ Exported %d task(s) to %s\n", len(tasks), outputFile)
+		for _, space := range spaces {
+			if spaceID != "" && space.ID != spaceID {
+				continue
+			}
+
+			folders, err := client.GetFolders(ctx, space.ID)
+			if err != nil {
+				fail("space", space.ID, space.Name, "folders", err)
+			}
+			for _, folder := range folders {
+				lists, err := client.GetLists(ctx, folder.ID)
+				if err != nil {
+					fail("folder", folder.ID, folder.Name, "lists", err)
+					continue
+				}
+				addListTasks(lists)
+			}
+
+			lists, err := client.GetFolderlessLists(ctx, space.ID)
+			if err != nil {
+				fail("space", space.ID, space.Name, "folderless lists", err)
+				continue
+			}
+			addListTasks(lists)
 		}
-	},
+	}
+
+	return tasks, fetchErrors, nil
+}
+
+// printFetchErrors warns that the results of action are incomplete and lists
+// the parts of the hierarchy that could not be fetched
+func printFetchErrors(w io.Writer, action string, fetchErrors []fetchError) {
+	if len(fetchErrors) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Some errors occurred during %s; results may be incomplete:\n", action)
+	for _, e := range fetchErrors {
+		fmt.Fprintf(w, "  - %s\n", e.Message)
+	}
 }
 
 func filterTasksForExport(tasks []clickup.Task, status, priority, assignee string) []clickup.Task {
@@ -222,68 +432,147 @@ func filterTasksForExport(tasks []clickup.Task, status, priority, assignee strin
 	return filtered
 }
 
-func exportTasksToCSV(output *os.File, tasks []clickup.Task) error {
-	writer := csv.NewWriter(output)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{"ID", "Name", "Status", "Priority", "Assignees", "Due Date", "Created", "Updated", "URL"}
-	if err := writer.Write(header); err != nil {
-		return err
-	}
+// exportColumn is a column of a csv export or of a markdown table export
+type exportColumn struct {
+	Key    string
+	Header string
+	Value  func(task clickup.Task) string
+}
 
-	// Write tasks
-	for _, task := range tasks {
+// exportColumns are the columns of a task export in their default order
+var exportColumns = []exportColumn{
+	{"id", "ID", func(task clickup.Task) string { return task.ID }},
+	{"name", "Name", func(task clickup.Task) string { return task.Name }},
+	{"status", "Status", func(task clickup.Task) string { return task.Status.Status }},
+	{"priority", "Priority", getTaskPriority},
+	{"assignees", "Assignees", func(task clickup.Task) string {
 		assignees := make([]string, 0, len(task.Assignees))
 		for _, a := range task.Assignees {
 			assignees = append(assignees, a.Username)
 		}
+		return strings.Join(assignees, ", ")
+	}},
+	{"due", "Due Date", getTaskDueDate},
+	{"created", "Created", func(task clickup.Task) string { return formatTimestamp(task.DateCreated) }},
+	{"updated", "Updated", func(task clickup.Task) string { return formatTimestamp(task.DateUpdated) }},
+	{"url", "URL", func(task clickup.Task) string { return task.URL }},
+}
 
-		row := []string{
-			task.ID,
-			task.Name,
-			task.Status.Status,
-			getTaskPriority(task),
-			strings.Join(assignees, ", "),
-			getTaskDueDate(task),
-			formatTimestamp(task.DateCreated),
-			formatTimestamp(task.DateUpdated),
-			task.URL,
+// selectExportColumns returns the export columns named by keys, in the
+// order given
+func selectExportColumns(keys []string) ([]exportColumn, error) {
+	columns := make([]exportColumn, 0, len(keys))
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		i := slices.IndexFunc(exportColumns, func(c exportColumn) bool { return c.Key == key })
+		if i < 0 {
+			valid := make([]string, 0, len(exportColumns))
+			for _, c := range exportColumns {
+				valid = append(valid, c.Key)
+			}
+			return nil, errors.NewUserError(
+				fmt.Sprintf("Unknown column '%s'", key),
+				fmt.Sprintf("Valid columns: %s", strings.Join(valid, ", ")),
+				errors.ErrInvalidInput,
+			)
 		}
+		columns = append(columns, exportColumns[i])
+	}
+	return columns, nil
+}
+
+// exportRows returns a header row and a row per task for columns
+func exportRows(tasks []clickup.Task, columns []exportColumn) [][]string {
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.Header)
+	}
 
-		if err := writer.Write(row); err != nil {
-			return err
+	rows := [][]string{header}
+	for _, task := range tasks {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, column.Value(task))
 		}
+		rows = append(rows, row)
 	}
+	return rows
+}
 
-	return nil
+// exportTasksToCSV writes the tasks as csv with the given columns, or every
+// column when columns is empty
+func exportTasksToCSV(w io.Writer, tasks []clickup.Task, columns []exportColumn) error {
+	if len(columns) == 0 {
+		columns = exportColumns
+	}
+	return output.FormatTo(w, "csv", exportRows(tasks, columns))
 }
 
-func exportTasksToJSON(output *os.File, tasks []clickup.Task) error {
-	encoder := json.NewEncoder(output)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(tasks)
+// exportTasksToJSON writes the tasks as a json array, or as an object with
+// the tasks and the fetch errors when fetchErrors is not nil
+func exportTasksToJSON(w io.Writer, tasks []clickup.Task, fetchErrors []fetchError) error {
+	if fetchErrors != nil {
+		return output.FormatTo(w, "json", newPartialTasks(tasks, fetchErrors))
+	}
+	return output.FormatTo(w, "json", tasks)
 }
 
-func exportTasksToMarkdown(output *os.File, tasks []clickup.Task) error {
+// exportTasksToMarkdown writes a table of the given columns, or a report
+// grouped by status when columns is empty
+func exportTasksToMarkdown(w io.Writer, tasks []clickup.Task, columns []exportColumn) error {
+	if len(columns) > 0 {
+		return output.FormatTo(w, "markdown", markdownTable(exportRows(tasks, columns)))
+	}
+	return output.FormatTo(w, "markdown", taskReport{tasks: tasks, generated: time.Now()})
+}
+
+// markdownTable renders rows as a markdown table whose first row is the header
+type markdownTable [][]string
+
+// Markdown implements output.Markdowner
+func (t markdownTable) Markdown(w io.Writer) error {
+	var b strings.Builder
+	for i, row := range t {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, strings.ReplaceAll(strings.ReplaceAll(cell, "|", "\\|"), "\n", " "))
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+
+		if i == 0 {
+			fmt.Fprintf(&b, "|%s\n", strings.Repeat(" --- |", len(row)))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// taskReport renders exported tasks as a markdown report grouped by status
+type taskReport struct {
+	tasks     []clickup.Task
+	generated time.Time
+}
+
+// Markdown implements output.Markdowner
+func (r taskReport) Markdown(w io.Writer) error {
 	// Group tasks by status
 	tasksByStatus := make(map[string][]clickup.Task)
-	for _, task := range tasks {
+	for _, task := range r.tasks {
 		status := task.Status.Status
 		tasksByStatus[status] = append(tasksByStatus[status], task)
 	}
 
 	// Write markdown
-	fmt.Fprintf(output, "# Task Report\n\n")
-	fmt.Fprintf(output, "Generated: %s\n", time.Now().Format(time.RFC3339))
-	fmt.Fprintf(output, "Total tasks: %d\n\n", len(tasks))
+	fmt.Fprintf(w, "# Task Report\n\n")
+	fmt.Fprintf(w, "Generated: %s\n", r.generated.Format(time.RFC3339))
+	fmt.Fprintf(w, "Total tasks: %d\n\n", len(r.tasks))
 
 	// Write summary
-	fmt.Fprintf(output, "## Summary by Status\n\n")
+	fmt.Fprintf(w, "## Summary by Status\n\n")
 	for status, statusTasks := range tasksByStatus {
-		fmt.Fprintf(output, "- **%s**: %d tasks\n", status, len(statusTasks))
+		fmt.Fprintf(w, "- **%s**: %d tasks\n", status, len(statusTasks))
 	}
-	fmt.Fprintln(output)
+	fmt.Fprintln(w)
 
 	// Write tasks by status
 	for status, statusTasks := range tasksByStatus {
@@ -292,43 +581,68 @@ func exportTasksToMarkdown(output *os.File, tasks []clickup.Task) error {
 		if len(status) > 0 {
 			titleStatus = strings.ToUpper(string(status[0])) + status[1:]
 		}
-		fmt.Fprintf(output, "## %s (%d)\n\n", titleStatus, len(statusTasks))
+		fmt.Fprintf(w, "## %s (%d)\n\n", titleStatus, len(statusTasks))
 
 		for _, task := range statusTasks {
-			// Task header
-			fmt.Fprintf(output, "### %s\n", task.Name)
-			fmt.Fprintf(output, "- **ID**: %s\n", task.ID)
-			fmt.Fprintf(output, "- **Priority**: %s\n", getTaskPriority(task))
-
-			// Assignees
-			if len(task.Assignees) > 0 {
-				assignees := make([]string, 0, len(task.Assignees))
-				for _, a := range task.Assignees {
-					assignees = append(assignees, a.Username)
-				}
-				fmt.Fprintf(output, "- **Assignees**: %s\n", strings.Join(assignees, ", "))
+			if err := writeTaskMarkdown(w, task, 3, false); err != nil {
+				return err
 			}
+		}
+	}
 
-			// Due date
-			if due := getTaskDueDate(task); due != "" {
-				fmt.Fprintf(output, "- **Due**: %s\n", due)
-			}
+	return nil
+}
 
-			// Description
-			if task.Description != "" {
-				fmt.Fprintf(output, "\n%s\n", task.Description)
-			}
+// writeTaskMarkdown writes a task as a markdown heading at the given level
+// followed by its fields as a bullet list, the description and a ClickUp link
+func writeTaskMarkdown(w io.Writer, task clickup.Task, level int, showStatus bool) error {
+	var b strings.Builder
 
-			// Link
-			if task.URL != "" {
-				fmt.Fprintf(output, "\n[View in ClickUp](%s)\n", task.URL)
-			}
+	// Task header
+	fmt.Fprintf(&b, "%s %s\n", strings.Repeat("#", level), task.Name)
+	fmt.Fprintf(&b, "- **ID**: %s\n", task.ID)
+	if showStatus {
+		fmt.Fprintf(&b, "- **Status**: %s\n", task.Status.Status)
+	}
+	fmt.Fprintf(&b, "- **Priority**: %s\n", getTaskPriority(task))
 
-			fmt.Fprintln(output)
+	// Assignees
+	if len(task.Assignees) > 0 {
+		assignees := make([]string, 0, len(task.Assignees))
+		for _, a := range task.Assignees {
+			assignees = append(assignees, a.Username)
 		}
+		fmt.Fprintf(&b, "- **Assignees**: %s\n", strings.Join(assignees, ", "))
 	}
 
-	return nil
+	// Due date
+	if due := getTaskDueDate(task); due != "" {
+		fmt.Fprintf(&b, "- **Due**: %s\n", due)
+	}
+
+	// Description
+	if task.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", task.Description)
+	}
+
+	// Link
+	if task.URL != "" {
+		fmt.Fprintf(&b, "\n[View in ClickUp](%s)\n", task.URL)
+	}
+
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownTask renders a single task for --output markdown
+type markdownTask struct {
+	task *clickup.Task
+}
+
+// Markdown implements output.Markdowner
+func (m markdownTask) Markdown(w io.Writer) error {
+	return writeTaskMarkdown(w, *m.task, 2, true)
 }
 
 func formatTimestamp(ms string) string {
@@ -347,11 +661,15 @@ func init() {
 	exportCmd.AddCommand(exportTasksCmd)
 
 	// Export tasks flags
-	exportTasksCmd.Flags().StringP("list", "l", "", "List ID to export tasks from")
-	exportTasksCmd.Flags().StringP("space", "s", "", "Space ID to export tasks from")
+	exportTasksCmd.Flags().StringP("list", "l", "", "List ID or name to export tasks from")
+	exportTasksCmd.Flags().StringP("space", "s", "", "Space ID or name to export tasks from")
 	exportTasksCmd.Flags().StringP("format", "f", "csv", "Export format (csv, json, markdown)")
 	exportTasksCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 	exportTasksCmd.Flags().String("status", "", "Filter by status")
 	exportTasksCmd.Flags().String("priority", "", "Filter by priority")
 	exportTasksCmd.Flags().String("assignee", "", "Filter by assignee")
+	exportTasksCmd.Flags().Bool("custom-fields", true, "Include custom field values in JSON exports")
+	exportTasksCmd.Flags().StringSlice("select", nil, "Columns to export, in order (e.g. id,name,status,due)")
+	exportTasksCmd.Flags().Bool("with-errors", false, "Write json as an object with the tasks and an errors array for lists that could not be read")
+	exportTasksCmd.Flags().Bool("dry-run", false, "Fetch and filter the tasks, then print their count by status instead of exporting")
 }
@@ -1,12 +1,20 @@
 package cmd
 
 import (
-	"os"
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/errors"
 )
 
 func TestExportCmd_Structure(t *testing.T) {
@@ -78,42 +86,25 @@ func TestExportTasksCmd_Logic(t *testing.T) {
 		assert.Equal(t, "markdown", format)
 	})
 
-	t.Run("priority mapping works", func(t *testing.T) {
-		priorities := map[string]int{
-			"urgent": 1,
-			"high":   2,
-			"normal": 3,
-			"low":    4,
+	t.Run("priority mapping matches task list", func(t *testing.T) {
+		for _, name := range []string{"urgent", "high", "normal", "low", "High"} {
+			p, ok := api.ParsePriority(name)
+			assert.True(t, ok, name)
+			assert.Equal(t, getPriorityValue(name), p, name)
 		}
 
-		for name, expectedID := range priorities {
-			var p int
-			switch name {
-			case "urgent":
-				p = 1
-			case "high":
-				p = 2
-			case "normal":
-				p = 3
-			case "low":
-				p = 4
-			}
-			assert.Equal(t, expectedID, p)
-		}
+		_, ok := api.ParsePriority("someday")
+		assert.False(t, ok)
 	})
 }
 
-// Note: The actual exportTasksToCSV, exportTasksToJSON, exportTasksToMarkdown
-// functions are complex and depend on the clickup package structure.
-// These tests focus on command structure and logic validation.
-
 func TestExportCmd_FunctionExistence(t *testing.T) {
 	t.Run("export functions exist", func(t *testing.T) {
 		// Test that the functions exist by ensuring they can be referenced
 		// This is a compile-time check
-		var csvFunc func(*os.File, []clickup.Task) error = exportTasksToCSV
-		var jsonFunc func(*os.File, []clickup.Task) error = exportTasksToJSON
-		var mdFunc func(*os.File, []clickup.Task) error = exportTasksToMarkdown
+		var csvFunc func(io.Writer, []clickup.Task, []exportColumn) error = exportTasksToCSV
+		var jsonFunc func(io.Writer, []clickup.Task, []fetchError) error = exportTasksToJSON
+		var mdFunc func(io.Writer, []clickup.Task, []exportColumn) error = exportTasksToMarkdown
 		var filterFunc func([]clickup.Task, string, string, string) []clickup.Task = filterTasksForExport
 		var formatFunc func(string) string = formatTimestamp
 
@@ -125,6 +116,92 @@ func TestExportCmd_FunctionExistence(t *testing.T) {
 	})
 }
 
+func TestExportTasksFormats(t *testing.T) {
+	tasks := []clickup.Task{
+		{
+			ID:        "abc",
+			Name:      "Write docs",
+			Status:    clickup.TaskStatus{Status: "open"},
+			Assignees: []clickup.User{{Username: "alice"}, {Username: "bob"}},
+			URL:       "https://app.clickup.com/t/abc",
+		},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, exportTasksToCSV(&buf, tasks, nil))
+		assert.Equal(t,
+			"ID,Name,Status,Priority,Assignees,Due Date,Created,Updated,URL\n"+
+				"abc,Write docs,open,Normal,\"alice, bob\",,,,https://app.clickup.com/t/abc\n",
+			buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, exportTasksToJSON(&buf, tasks, nil))
+		assert.Contains(t, buf.String(), `"id": "abc"`)
+		assert.Contains(t, buf.String(), `"name": "Write docs"`)
+		assert.True(t, strings.HasPrefix(buf.String(), "["))
+	})
+
+	t.Run("json with errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		fetchErrors := []fetchError{{Type: "space", ID: "s1", Name: "Engineering", Message: "Failed to get folders for space Engineering: server error"}}
+		require.NoError(t, exportTasksToJSON(&buf, tasks, fetchErrors))
+
+		var result partialTasks
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Len(t, result.Tasks, 1)
+		assert.Equal(t, "abc", result.Tasks[0].ID)
+		assert.Equal(t, fetchErrors, result.Errors)
+	})
+
+	t.Run("json with errors keeps its shape when nothing failed", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, exportTasksToJSON(&buf, nil, nonNilFetchErrors(nil)))
+		assert.JSONEq(t, `{"tasks": [], "errors": []}`, buf.String())
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, exportTasksToMarkdown(&buf, tasks, nil))
+		out := buf.String()
+		assert.True(t, strings.HasPrefix(out, "# Task Report\n\n"))
+		assert.Contains(t, out, "Total tasks: 1\n")
+		assert.Contains(t, out, "- **open**: 1 tasks\n")
+		assert.Contains(t, out, "## Open (1)\n")
+		assert.Contains(t, out, "### Write docs\n")
+	})
+
+	t.Run("csv selected columns", func(t *testing.T) {
+		columns, err := selectExportColumns([]string{"status", "id", "Assignees"})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, exportTasksToCSV(&buf, tasks, columns))
+		assert.Equal(t, "Status,ID,Assignees\nopen,abc,\"alice, bob\"\n", buf.String())
+	})
+
+	t.Run("markdown selected columns", func(t *testing.T) {
+		columns, err := selectExportColumns([]string{"id", "name", "due"})
+		require.NoError(t, err)
+
+		piped := append([]clickup.Task{}, tasks...)
+		piped[0].Name = "Docs | API"
+
+		var buf bytes.Buffer
+		require.NoError(t, exportTasksToMarkdown(&buf, piped, columns))
+		assert.Equal(t, "| ID | Name | Due Date |\n| --- | --- | --- |\n| abc | Docs \\| API |  |\n", buf.String())
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		_, err := selectExportColumns([]string{"id", "owner"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Unknown column 'owner'")
+		assert.Contains(t, err.Error(), "Valid columns: id, name, status")
+	})
+}
+
 func TestExportCmd_CommandFlags(t *testing.T) {
 	t.Run("flags have correct properties", func(t *testing.T) {
 		cmd := exportTasksCmd
@@ -154,3 +231,214 @@ func TestExportCmd_Examples(t *testing.T) {
 		assert.Contains(t, cmd.Long, "--format markdown")
 	})
 }
+
+// fakeExportClient serves a populated workspace, or none with noWorkspaces;
+// lists in failLists and the folders of spaces in failFolders error
+type fakeExportClient struct {
+	noWorkspaces    bool
+	spaces          map[string][]clickup.Space
+	folders         map[string][]clickup.Folder
+	folderLists     map[string][]clickup.List
+	folderlessLists map[string][]clickup.List
+	tasks           map[string][]clickup.Task
+	failLists       map[string]bool
+	failFolders     map[string]bool
+	includeFields   []bool
+}
+
+func (f *fakeExportClient) GetWorkspaces(ctx context.Context) ([]clickup.Team, error) {
+	if f.noWorkspaces {
+		return nil, nil
+	}
+	return []clickup.Team{{ID: "w1", Name: "Acme"}}, nil
+}
+
+func (f *fakeExportClient) GetSpaces(ctx context.Context, workspaceID string) ([]clickup.Space, error) {
+	return f.spaces[workspaceID], nil
+}
+
+func (f *fakeExportClient) GetFolders(ctx context.Context, spaceID string) ([]clickup.Folder, error) {
+	if f.failFolders[spaceID] {
+		return nil, stderrors.New("server error")
+	}
+	return f.folders[spaceID], nil
+}
+
+func (f *fakeExportClient) GetLists(ctx context.Context, folderID string) ([]clickup.List, error) {
+	return f.folderLists[folderID], nil
+}
+
+func (f *fakeExportClient) GetFolderlessLists(ctx context.Context, spaceID string) ([]clickup.List, error) {
+	return f.folderlessLists[spaceID], nil
+}
+
+func (f *fakeExportClient) GetAllTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error) {
+	f.includeFields = append(f.includeFields, options.IncludeCustomFields)
+	if f.failLists[listID] {
+		return nil, stderrors.New("server error")
+	}
+	return f.tasks[listID], nil
+}
+
+func newFakeExportClient() *fakeExportClient {
+	// More tasks than fit on one ClickUp page, as GetAllTasks returns them
+	many := make([]clickup.Task, api.TasksPageSize+5)
+	for i := range many {
+		many[i] = clickup.Task{ID: fmt.Sprintf("big-%d", i)}
+	}
+
+	return &fakeExportClient{
+		spaces: map[string][]clickup.Space{
+			"w1": {{ID: "s1", Name: "Engineering"}, {ID: "s2", Name: "Ops"}},
+		},
+		folders: map[string][]clickup.Folder{
+			"s1": {{ID: "f1", Name: "Sprints"}},
+		},
+		folderLists: map[string][]clickup.List{
+			"f1": {{ID: "l1", Name: "Sprint 1"}, {ID: "l2", Name: "Sprint 2"}},
+		},
+		folderlessLists: map[string][]clickup.List{
+			"s1": {{ID: "l3", Name: "Backlog"}},
+			"s2": {{ID: "l4", Name: "Incidents"}},
+		},
+		tasks: map[string][]clickup.Task{
+			"l1": many,
+			"l2": {{ID: "t2"}},
+			"l3": {{ID: "t3"}},
+			"l4": {{ID: "t4"}},
+		},
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	tasks := []clickup.Task{
+		{ID: "1", Name: "Write docs", Status: clickup.TaskStatus{Status: "open"}},
+		{ID: "2", Name: "Fix bug", Status: clickup.TaskStatus{Status: "closed"}},
+		{ID: "3", Name: "Plan", Status: clickup.TaskStatus{Status: "open"}},
+	}
+
+	t.Run("dry run prints counts and creates no file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		var buf bytes.Buffer
+		require.NoError(t, writeExport(&buf, "tasks.csv", "csv", tasks, nil, nil, true))
+		assert.Equal(t, "Would export 3 task(s) as csv to tasks.csv\n  open    2\n  closed  1\n", buf.String())
+		assert.NoFileExists(t, "tasks.csv")
+	})
+
+	t.Run("writes the file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		var buf bytes.Buffer
+		require.NoError(t, writeExport(&buf, "tasks.csv", "csv", tasks, nil, nil, false))
+		assert.Equal(t, "✓ Exported 3 task(s) to tasks.csv\n", buf.String())
+		assert.FileExists(t, "tasks.csv")
+	})
+
+	t.Run("rejects paths outside the directory", func(t *testing.T) {
+		err := writeExport(io.Discard, "../tasks.csv", "csv", tasks, nil, nil, true)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+}
+
+func TestCollectExportTasks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("collects every list in every space", func(t *testing.T) {
+		client := newFakeExportClient()
+
+		tasks, exportErrors, err := collectExportTasks(ctx, client, "", true)
+		require.NoError(t, err)
+		assert.Empty(t, exportErrors)
+		assert.Len(t, tasks, api.TasksPageSize+5+3)
+		assert.Equal(t, []bool{true, true, true, true}, client.includeFields)
+	})
+
+	t.Run("limits to one space", func(t *testing.T) {
+		tasks, _, err := collectExportTasks(ctx, newFakeExportClient(), "s2", false)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "t4", tasks[0].ID)
+	})
+
+	t.Run("no workspaces", func(t *testing.T) {
+		client := newFakeExportClient()
+		client.noWorkspaces = true
+
+		_, _, err := collectExportTasks(ctx, client, "", false)
+		require.Error(t, err)
+		assert.Equal(t, "No workspaces found", err.Error())
+	})
+
+	t.Run("reports failed lists and keeps the rest", func(t *testing.T) {
+		client := newFakeExportClient()
+		client.failLists = map[string]bool{"l1": true}
+
+		tasks, exportErrors, err := collectExportTasks(ctx, client, "", false)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 3)
+		assert.Equal(t, []fetchError{{
+			Type:    "list",
+			ID:      "l1",
+			Name:    "Sprint 1",
+			Message: "Failed to get tasks for list Sprint 1: server error",
+		}}, exportErrors)
+	})
+
+	t.Run("failed folders keep other spaces", func(t *testing.T) {
+		client := newFakeExportClient()
+		client.failFolders = map[string]bool{"s1": true}
+
+		tasks, exportErrors, err := collectExportTasks(ctx, client, "", false)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, []string{"t3", "t4"}, ids, "folderless lists of the failed space and other spaces are still read")
+		require.Len(t, exportErrors, 1)
+		assert.Equal(t, "space", exportErrors[0].Type)
+		assert.Equal(t, "s1", exportErrors[0].ID)
+		assert.Equal(t, "Failed to get folders for space Engineering: server error", exportErrors[0].Message)
+
+		var buf bytes.Buffer
+		printFetchErrors(&buf, "search", exportErrors)
+		assert.Equal(t, "Some errors occurred during search; results may be incomplete:\n  - Failed to get folders for space Engineering: server error\n", buf.String())
+	})
+}
+
+func TestWriteTaskMarkdown(t *testing.T) {
+	task := clickup.Task{
+		ID:          "abc123",
+		Name:        "Fix login redirect",
+		Description: "Users land on a blank page.",
+		URL:         "https://app.clickup.com/t/abc123",
+		Status:      clickup.TaskStatus{Status: "in progress"},
+		Priority:    clickup.TaskPriority{Priority: "high"},
+		Assignees:   []clickup.User{{Username: "alice"}, {Username: "bob"}},
+	}
+
+	t.Run("task view block", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, markdownTask{task: &task}.Markdown(&buf))
+
+		md := buf.String()
+		assert.True(t, strings.HasPrefix(md, "## Fix login redirect\n"))
+		assert.Contains(t, md, "- **ID**: abc123\n")
+		assert.Contains(t, md, "- **Status**: in progress\n")
+		assert.Contains(t, md, "- **Priority**: high\n")
+		assert.Contains(t, md, "- **Assignees**: alice, bob\n")
+		assert.Contains(t, md, "\nUsers land on a blank page.\n")
+		assert.Contains(t, md, "[View in ClickUp](https://app.clickup.com/t/abc123)")
+	})
+
+	t.Run("export block omits status", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, writeTaskMarkdown(&buf, task, 3, false))
+
+		md := buf.String()
+		assert.True(t, strings.HasPrefix(md, "### Fix login redirect\n"))
+		assert.NotContains(t, md, "**Status**")
+	})
+}
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
+)
+
+var taskSetFieldCmd = &cobra.Command{
+	Use:   "set-field [task-id] [field] [value]",
+	Short: "Set a custom field on a task",
+	Long: `Set a custom field on a task. The field can be given by name or ID.
+
+Dropdown fields take the option's label, and label fields take a
+comma-separated list of labels. Number fields take a number and checkbox
+fields take true or false.`,
+	Example: `  cu task set-field abc123 Environment Production
+  cu task set-field abc123 Components "API, CLI"
+  cu task set-field abc123 Estimate 3`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		field, err := runSetField(ctx, client, args[0], args[1], args[2])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✓ Set %s on task %s\n", field.Name, args[0])
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(taskSetFieldCmd)
+}
+
+// customFieldSetter is the part of the API client used by task set-field
+type customFieldSetter interface {
+	GetTask(ctx context.Context, taskID string) (*clickup.Task, error)
+	GetCustomFields(ctx context.Context, listID string) ([]clickup.CustomField, error)
+	SetCustomFieldValue(ctx context.Context, taskID string, fieldID string, value map[string]interface{}) error
+}
+
+// runSetField looks up the field on the task's list, converts value for the
+// field's type and sets it
+func runSetField(ctx context.Context, client customFieldSetter, taskID, nameOrID, value string) (*clickup.CustomField, error) {
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	fields, err := client.GetCustomFields(ctx, task.List.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	field := findCustomField(fields, nameOrID)
+	if field == nil {
+		return nil, errors.NewUserError(
+			fmt.Sprintf("Task %s has no custom field '%s'", taskID, nameOrID),
+			fmt.Sprintf("Available fields: %s", strings.Join(customFieldNames(fields), ", ")),
+			errors.ErrNotFound,
+		)
+	}
+
+	fieldValue, err := customFieldValue(field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.SetCustomFieldValue(ctx, taskID, field.ID, map[string]interface{}{"value": fieldValue}); err != nil {
+		return nil, fmt.Errorf("failed to set custom field: %w", err)
+	}
+
+	return field, nil
+}
+
+// findCustomField returns the field with the given ID or case-insensitive name
+func findCustomField(fields []clickup.CustomField, nameOrID string) *clickup.CustomField {
+	for i := range fields {
+		if fields[i].ID == nameOrID || strings.EqualFold(fields[i].Name, nameOrID) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func customFieldNames(fields []clickup.CustomField) []string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	return names
+}
+
+// customFieldValue converts a command line value to the value ClickUp expects
+// for the field's type. Dropdown and label options are resolved from their
+// labels to option IDs.
+func customFieldValue(field *clickup.CustomField, value string) (interface{}, error) {
+	switch field.Type {
+	case "drop_down":
+		return resolveFieldOption(field, value)
+	case "labels":
+		var ids []string
+		for _, label := range strings.Split(value, ",") {
+			id, err := resolveFieldOption(field, strings.TrimSpace(label))
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	case "number", "currency":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.NewUserError(
+				fmt.Sprintf("Field %s needs a number, got '%s'", field.Name, value),
+				"",
+				errors.ErrInvalidInput,
+			)
+		}
+		return n, nil
+	case "checkbox":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.NewUserError(
+				fmt.Sprintf("Field %s needs true or false, got '%s'", field.Name, value),
+				"",
+				errors.ErrInvalidInput,
+			)
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
+
+// fieldOption is a dropdown or label option of a custom field
+type fieldOption struct {
+	ID    string
+	Label string
+	// OrderIndex is the option's position, which ClickUp uses as the value
+	// of a dropdown field
+	OrderIndex int
+}
+
+// fieldOptions reads the options from a dropdown or labels field's type
+// config. Dropdown options are named by "name" and label options by "label".
+func fieldOptions(field *clickup.CustomField) []fieldOption {
+	config, ok := field.TypeConfig.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	raw, _ := config["options"].([]interface{})
+
+	options := make([]fieldOption, 0, len(raw))
+	for _, item := range raw {
+		option, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := option["id"].(string)
+		label, _ := option["name"].(string)
+		if label == "" {
+			label, _ = option["label"].(string)
+		}
+		orderIndex, _ := option["orderindex"].(float64)
+		options = append(options, fieldOption{ID: id, Label: label, OrderIndex: int(orderIndex)})
+	}
+	return options
+}
+
+// resolveFieldOption returns the ID of the option whose label matches value
+// case-insensitively. An option ID is accepted as is.
+func resolveFieldOption(field *clickup.CustomField, value string) (string, error) {
+	options := fieldOptions(field)
+	for _, option := range options {
+		if option.ID == value || strings.EqualFold(option.Label, value) {
+			return option.ID, nil
+		}
+	}
+
+	labels := make([]string, 0, len(options))
+	for _, option := range options {
+		labels = append(labels, option.Label)
+	}
+	return "", errors.NewUserError(
+		fmt.Sprintf("'%s' is not an option of field %s", value, field.Name),
+		fmt.Sprintf("Valid options: %s", strings.Join(labels, ", ")),
+		errors.ErrInvalidInput,
+	)
+}
+
+// printTaskCustomFields writes a task's custom fields and their formatted
+// values, with "-" for unset fields
+func printTaskCustomFields(w io.Writer, fields []clickup.CustomField) {
+	fmt.Fprintf(w, "\nCustom fields:\n")
+	if len(fields) == 0 {
+		fmt.Fprintln(w, "  (none)")
+		return
+	}
+	for _, field := range fields {
+		value := formatCustomFieldValue(field)
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(w, "  %s: %s\n", field.Name, value)
+	}
+}
+
+// formatCustomFieldValue returns a custom field's value for display.
+// Dropdowns show the chosen option's label, labels fields their labels,
+// dates the time in the current time format and numbers without trailing
+// zeros. Unset fields are empty.
+func formatCustomFieldValue(field clickup.CustomField) string {
+	if field.Value == nil {
+		return ""
+	}
+
+	switch field.Type {
+	case "drop_down":
+		for _, option := range fieldOptions(&field) {
+			if option.ID == fmt.Sprint(field.Value) || fieldNumber(field.Value) == float64(option.OrderIndex) {
+				return option.Label
+			}
+		}
+	case "labels":
+		ids, _ := field.Value.([]interface{})
+		var labels []string
+		for _, option := range fieldOptions(&field) {
+			for _, id := range ids {
+				if option.ID == id {
+					labels = append(labels, option.Label)
+				}
+			}
+		}
+		if len(labels) > 0 {
+			return strings.Join(labels, ", ")
+		}
+	case "date":
+		if ms := fieldNumber(field.Value); !math.IsNaN(ms) {
+			return output.FormatTime(time.UnixMilli(int64(ms)), formatRelativeTime)
+		}
+	case "number", "currency", "emoji":
+		if n := fieldNumber(field.Value); !math.IsNaN(n) {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	}
+
+	return getCustomFieldValue(clickup.Task{CustomFields: []clickup.CustomField{field}}, field.ID)
+}
+
+// fieldNumber reads a custom field value ClickUp sends as a number or a
+// numeric string, returning NaN for anything else
+func fieldNumber(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return math.NaN()
+}
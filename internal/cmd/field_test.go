@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
+)
+
+// testDropdownField is a dropdown field as ClickUp returns it from JSON
+var testDropdownField = clickup.CustomField{
+	ID:   "f1",
+	Name: "Environment",
+	Type: "drop_down",
+	TypeConfig: map[string]interface{}{
+		"options": []interface{}{
+			map[string]interface{}{"id": "opt-dev", "name": "Development", "orderindex": float64(0)},
+			map[string]interface{}{"id": "opt-prod", "name": "Production", "orderindex": float64(1)},
+		},
+	},
+}
+
+var testLabelsField = clickup.CustomField{
+	ID:   "f2",
+	Name: "Components",
+	Type: "labels",
+	TypeConfig: map[string]interface{}{
+		"options": []interface{}{
+			map[string]interface{}{"id": "lbl-api", "label": "API"},
+			map[string]interface{}{"id": "lbl-cli", "label": "CLI"},
+		},
+	},
+}
+
+func TestCustomFieldValue(t *testing.T) {
+	t.Run("dropdown label resolves to option ID", func(t *testing.T) {
+		value, err := customFieldValue(&testDropdownField, "production")
+		require.NoError(t, err)
+		assert.Equal(t, "opt-prod", value)
+	})
+
+	t.Run("dropdown option ID is accepted", func(t *testing.T) {
+		value, err := customFieldValue(&testDropdownField, "opt-dev")
+		require.NoError(t, err)
+		assert.Equal(t, "opt-dev", value)
+	})
+
+	t.Run("invalid dropdown label lists valid labels", func(t *testing.T) {
+		_, err := customFieldValue(&testDropdownField, "Staging")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "'Staging' is not an option of field Environment")
+		assert.Contains(t, err.Error(), "Valid options: Development, Production")
+	})
+
+	t.Run("labels resolve each label", func(t *testing.T) {
+		value, err := customFieldValue(&testLabelsField, "cli, API")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"lbl-cli", "lbl-api"}, value)
+	})
+
+	t.Run("invalid label", func(t *testing.T) {
+		_, err := customFieldValue(&testLabelsField, "API, Web")
+		assert.ErrorContains(t, err, "Valid options: API, CLI")
+	})
+
+	t.Run("number and checkbox", func(t *testing.T) {
+		value, err := customFieldValue(&clickup.CustomField{Type: "number"}, "2.5")
+		require.NoError(t, err)
+		assert.Equal(t, 2.5, value)
+
+		value, err = customFieldValue(&clickup.CustomField{Type: "checkbox"}, "true")
+		require.NoError(t, err)
+		assert.Equal(t, true, value)
+
+		_, err = customFieldValue(&clickup.CustomField{Name: "Estimate", Type: "number"}, "lots")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+
+	t.Run("text is passed through", func(t *testing.T) {
+		value, err := customFieldValue(&clickup.CustomField{Type: "text"}, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", value)
+	})
+}
+
+// fakeCustomFieldSetter serves one task's list fields and records the set call
+type fakeCustomFieldSetter struct {
+	fields  []clickup.CustomField
+	fieldID string
+	value   map[string]interface{}
+}
+
+func (f *fakeCustomFieldSetter) GetTask(ctx context.Context, taskID string) (*clickup.Task, error) {
+	return &clickup.Task{ID: taskID, List: clickup.ListOfTaskBelonging{ID: "list1"}}, nil
+}
+
+func (f *fakeCustomFieldSetter) GetCustomFields(ctx context.Context, listID string) ([]clickup.CustomField, error) {
+	return f.fields, nil
+}
+
+func (f *fakeCustomFieldSetter) SetCustomFieldValue(ctx context.Context, taskID string, fieldID string, value map[string]interface{}) error {
+	f.fieldID = fieldID
+	f.value = value
+	return nil
+}
+
+func TestRunSetField(t *testing.T) {
+	t.Run("sets the resolved option by field name", func(t *testing.T) {
+		client := &fakeCustomFieldSetter{fields: []clickup.CustomField{testDropdownField}}
+
+		field, err := runSetField(context.Background(), client, "abc", "environment", "Production")
+		require.NoError(t, err)
+		assert.Equal(t, "Environment", field.Name)
+		assert.Equal(t, "f1", client.fieldID)
+		assert.Equal(t, map[string]interface{}{"value": "opt-prod"}, client.value)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		client := &fakeCustomFieldSetter{fields: []clickup.CustomField{testDropdownField}}
+
+		_, err := runSetField(context.Background(), client, "abc", "Team", "x")
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		assert.Contains(t, err.Error(), "Available fields: Environment")
+		assert.Nil(t, client.value)
+	})
+
+	t.Run("invalid label does not set", func(t *testing.T) {
+		client := &fakeCustomFieldSetter{fields: []clickup.CustomField{testDropdownField}}
+
+		_, err := runSetField(context.Background(), client, "abc", "f1", "Staging")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Nil(t, client.value)
+	})
+}
+
+func TestFormatCustomFieldValue(t *testing.T) {
+	output.SetTimeFormat(output.TimeISO)
+	defer output.SetTimeFormat(output.TimeRelative)
+
+	withValue := func(field clickup.CustomField, value interface{}) clickup.CustomField {
+		field.Value = value
+		return field
+	}
+	due := time.Date(2026, 3, 20, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		field clickup.CustomField
+		want  string
+	}{
+		{"dropdown by order index", withValue(testDropdownField, float64(1)), "Production"},
+		{"dropdown by order index string", withValue(testDropdownField, "0"), "Development"},
+		{"dropdown by option ID", withValue(testDropdownField, "opt-prod"), "Production"},
+		{"labels", withValue(testLabelsField, []interface{}{"lbl-cli", "lbl-api"}), "API, CLI"},
+		{"date", clickup.CustomField{Type: "date", Value: "1774000000000"}, "2026-03-20T09:46:40Z"},
+		{"date as number", clickup.CustomField{Type: "date", Value: float64(due.UnixMilli())}, "2026-03-20T09:30:00Z"},
+		{"number string", clickup.CustomField{Type: "number", Value: "42.50"}, "42.5"},
+		{"currency", clickup.CustomField{Type: "currency", Value: float64(1200)}, "1200"},
+		{"checkbox", clickup.CustomField{ID: "c", Type: "checkbox", Value: true}, "true"},
+		{"text", clickup.CustomField{ID: "t", Type: "short_text", Value: "hello"}, "hello"},
+		{"unset", clickup.CustomField{Type: "number"}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, formatCustomFieldValue(test.field))
+		})
+	}
+}
+
+func TestPrintTaskCustomFields(t *testing.T) {
+	fields := []clickup.CustomField{
+		{ID: "n", Name: "Points", Type: "number", Value: "3"},
+		func() clickup.CustomField { f := testDropdownField; f.Value = float64(0); return f }(),
+		{ID: "d", Name: "Launch", Type: "date"},
+	}
+
+	var buf bytes.Buffer
+	printTaskCustomFields(&buf, fields)
+	assert.Equal(t, "\nCustom fields:\n  Points: 3\n  Environment: Development\n  Launch: -\n", buf.String())
+
+	buf.Reset()
+	printTaskCustomFields(&buf, nil)
+	assert.Equal(t, "\nCustom fields:\n  (none)\n", buf.String())
+}
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/clock"
+	"github.com/timimsms/cu/internal/errors"
+)
+
+// filterGrammar documents the --filter expression language in the task list
+// help
+const filterGrammar = `A --filter expression compares task fields and combines the comparisons:
+
+  expr       = term { "||" term }
+  term       = factor { "&&" factor }
+  factor     = "!" factor | "(" expr ")" | comparison
+  comparison = field operator value
+
+Fields are status, priority, assignee, due and name. Operators are ==, !=,
+<, <=, >, >= and ~ (contains). Values with spaces are quoted.
+
+  status     ==, != and ~ on the status name
+  priority   all operators but ~; urgent > high > normal > low
+  assignee   ==, != and ~ on any assignee's username, == and != also on
+             the user ID; "none" matches unassigned tasks
+  due        all operators but ~ on the due day: today, tomorrow, week
+             (7 days from today) or a YYYY-MM-DD date; "none" with == and
+             != matches tasks without a due date
+  name       ==, != and ~
+
+Text comparisons ignore case.`
+
+// taskPredicate reports whether a task matches a filter
+type taskPredicate func(task clickup.Task) bool
+
+// parseTaskFilter parses a --filter expression
+func parseTaskFilter(expr string) (taskPredicate, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, filterError(err)
+	}
+
+	p := &filterParser{tokens: tokens}
+	predicate, err := p.parseOr()
+	if err != nil {
+		return nil, filterError(err)
+	}
+	if tok := p.peek(); tok.kind != filterEOF {
+		return nil, filterError(fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1))
+	}
+	return predicate, nil
+}
+
+func filterError(err error) error {
+	return errors.NewUserError(
+		fmt.Sprintf("Invalid --filter: %v", err),
+		"See 'cu task list --help' for the filter syntax, e.g. --filter 'status==open && priority>=high'",
+		errors.ErrInvalidInput,
+	)
+}
+
+// applyTaskFilter returns the tasks that match predicate
+func applyTaskFilter(tasks []clickup.Task, predicate taskPredicate) []clickup.Task {
+	filtered := make([]clickup.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if predicate(task) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// Filter token kinds
+const (
+	filterEOF = iota
+	filterWord
+	filterOp
+	filterAnd
+	filterOr
+	filterNot
+	filterLParen
+	filterRParen
+)
+
+type filterToken struct {
+	kind int
+	text string
+	pos  int
+}
+
+var filterOperators = []string{"==", "!=", "<=", ">=", "<", ">", "~"}
+
+// tokenizeFilter splits an expression into words, quoted strings, operators,
+// &&, ||, ! and parentheses
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{filterLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{filterRParen, ")", i})
+			i++
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, filterToken{filterAnd, "&&", i})
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, filterToken{filterOr, "||", i})
+			i += 2
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", i+1)
+			}
+			tokens = append(tokens, filterToken{filterWord, expr[i+1 : i+1+end], i})
+			i += end + 2
+		default:
+			if op := operatorAt(expr, i); op != "" {
+				tokens = append(tokens, filterToken{filterOp, op, i})
+				i += len(op)
+				continue
+			}
+			if c == '!' {
+				tokens = append(tokens, filterToken{filterNot, "!", i})
+				i++
+				continue
+			}
+
+			start := i
+			for i < len(expr) && isFilterWordByte(expr[i]) {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(c), i+1)
+			}
+			tokens = append(tokens, filterToken{filterWord, expr[start:i], start})
+		}
+	}
+	return append(tokens, filterToken{filterEOF, "end of filter", len(expr)}), nil
+}
+
+func operatorAt(expr string, i int) string {
+	for _, op := range filterOperators {
+		if strings.HasPrefix(expr[i:], op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func isFilterWordByte(c byte) bool {
+	return c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)) || strings.IndexByte("-_.:@/#", c) >= 0
+}
+
+// filterParser is a recursive descent parser over filter tokens
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != filterEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) parseOr() (taskPredicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == filterOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(task clickup.Task) bool { return l(task) || right(task) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (taskPredicate, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == filterAnd {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(task clickup.Task) bool { return l(task) && right(task) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseFactor() (taskPredicate, error) {
+	tok := p.next()
+	switch tok.kind {
+	case filterNot:
+		inner, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return func(task clickup.Task) bool { return !inner(task) }, nil
+	case filterLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != filterRParen {
+			return nil, fmt.Errorf("expected ) at position %d, got %q", closing.pos+1, closing.text)
+		}
+		return inner, nil
+	case filterWord:
+		op := p.next()
+		if op.kind != filterOp {
+			return nil, fmt.Errorf("expected an operator after %q at position %d, got %q", tok.text, op.pos+1, op.text)
+		}
+		value := p.next()
+		if value.kind != filterWord {
+			return nil, fmt.Errorf("expected a value after %q at position %d, got %q", op.text, value.pos+1, value.text)
+		}
+		return comparisonPredicate(strings.ToLower(tok.text), op.text, value.text)
+	default:
+		return nil, fmt.Errorf("expected a comparison at position %d, got %q", tok.pos+1, tok.text)
+	}
+}
+
+// comparisonPredicate builds the predicate of a single field comparison
+func comparisonPredicate(field, op, value string) (taskPredicate, error) {
+	switch field {
+	case "status":
+		if !slices.Contains([]string{"==", "!=", "~"}, op) {
+			return nil, fmt.Errorf("status does not support %s", op)
+		}
+		return func(task clickup.Task) bool {
+			return compareText(getTaskStatus(task), op, value)
+		}, nil
+
+	case "name":
+		if !slices.Contains([]string{"==", "!=", "~"}, op) {
+			return nil, fmt.Errorf("name does not support %s", op)
+		}
+		return func(task clickup.Task) bool {
+			return compareText(task.Name, op, value)
+		}, nil
+
+	case "priority":
+		if op == "~" {
+			return nil, fmt.Errorf("priority does not support ~")
+		}
+		rank, ok := api.ParsePriority(value)
+		if !ok {
+			return nil, fmt.Errorf("unknown priority %q, use urgent, high, normal or low", value)
+		}
+		// A lower rank is a higher priority, so compare the negated ranks
+		return func(task clickup.Task) bool {
+			return compareOrdered(-api.PriorityValue(getTaskPriority(task)), op, -rank)
+		}, nil
+
+	case "assignee":
+		if !slices.Contains([]string{"==", "!=", "~"}, op) {
+			return nil, fmt.Errorf("assignee does not support %s", op)
+		}
+		return assigneePredicate(op, value), nil
+
+	case "due":
+		return duePredicate(op, value)
+
+	default:
+		return nil, fmt.Errorf("unknown field %q, use status, priority, assignee, due or name", field)
+	}
+}
+
+// compareText compares text case-insensitively with ==, != or ~ (contains)
+func compareText(text, op, value string) bool {
+	switch op {
+	case "==":
+		return strings.EqualFold(text, value)
+	case "!=":
+		return !strings.EqualFold(text, value)
+	default:
+		return strings.Contains(strings.ToLower(text), strings.ToLower(value))
+	}
+}
+
+func compareOrdered[T int | string](a T, op string, b T) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+// assigneePredicate matches any assignee by username or ID. "none" matches
+// tasks without assignees.
+func assigneePredicate(op, value string) taskPredicate {
+	return func(task clickup.Task) bool {
+		var matched bool
+		if strings.EqualFold(value, "none") && op != "~" {
+			matched = len(task.Assignees) == 0
+		} else {
+			matched = slices.ContainsFunc(task.Assignees, func(user clickup.User) bool {
+				if op == "~" {
+					return compareText(user.Username, "~", value)
+				}
+				return strings.EqualFold(user.Username, value) || strconv.Itoa(user.ID) == value
+			})
+		}
+		if op == "!=" {
+			return !matched
+		}
+		return matched
+	}
+}
+
+// duePredicate compares due days. "none" matches tasks without a due date
+// with == and !=; tasks without one fail every other comparison.
+func duePredicate(op, value string) (taskPredicate, error) {
+	if op == "~" {
+		return nil, fmt.Errorf("due does not support ~")
+	}
+
+	if strings.EqualFold(value, "none") {
+		if op != "==" && op != "!=" {
+			return nil, fmt.Errorf("due none only supports == and !=")
+		}
+		return func(task clickup.Task) bool {
+			return hasDueDate(task) == (op == "!=")
+		}, nil
+	}
+
+	day, err := filterDay(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(task clickup.Task) bool {
+		if !hasDueDate(task) {
+			return false
+		}
+		return compareOrdered(task.DueDate.Time().Local().Format(time.DateOnly), op, day)
+	}, nil
+}
+
+// filterDay resolves a due value to a local YYYY-MM-DD day, which compare in
+// date order as strings
+func filterDay(value string) (string, error) {
+	now := clock.Now().Local()
+	switch strings.ToLower(value) {
+	case "today":
+		return now.Format(time.DateOnly), nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1).Format(time.DateOnly), nil
+	case "week":
+		return now.AddDate(0, 0, 7).Format(time.DateOnly), nil
+	}
+
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return "", fmt.Errorf("unknown due date %q, use today, tomorrow, week, none or YYYY-MM-DD", value)
+	}
+	return day.Format(time.DateOnly), nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/clock"
+	"github.com/timimsms/cu/internal/errors"
+)
+
+func TestParseTaskFilter(t *testing.T) {
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.Local)
+	defer clock.Set(clock.Fixed(now))()
+
+	tasks := []clickup.Task{
+		{
+			ID:        "1",
+			Name:      "Write release notes",
+			Status:    clickup.TaskStatus{Status: "open"},
+			Priority:  clickup.TaskPriority{Priority: "urgent"},
+			Assignees: []clickup.User{{ID: 7, Username: "alice"}},
+			DueDate:   clickup.NewDate(now.Add(2 * time.Hour)),
+		},
+		{
+			ID:        "2",
+			Name:      "Fix login bug",
+			Status:    clickup.TaskStatus{Status: "in progress"},
+			Priority:  clickup.TaskPriority{Priority: "high"},
+			Assignees: []clickup.User{{ID: 8, Username: "bob"}, {ID: 7, Username: "alice"}},
+			DueDate:   clickup.NewDate(now.AddDate(0, 0, 3)),
+		},
+		{
+			ID:      "3",
+			Name:    "Plan roadmap",
+			Status:  clickup.TaskStatus{Status: "Open"},
+			DueDate: clickup.NewDate(now.AddDate(0, 0, 10)),
+		},
+		{
+			ID:       "4",
+			Name:     "Tidy docs",
+			Status:   clickup.TaskStatus{Status: "closed"},
+			Priority: clickup.TaskPriority{Priority: "low"},
+		},
+	}
+
+	ids := func(tasks []clickup.Task) []string {
+		ids := []string{}
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"status==open", []string{"1", "3"}},
+		{"status!=open", []string{"2", "4"}},
+		{"status~progress", []string{"2"}},
+		{"priority==high", []string{"2"}},
+		{"priority!=normal", []string{"1", "2", "4"}},
+		{"priority>=high", []string{"1", "2"}},
+		{"priority>high", []string{"1"}},
+		{"priority<=normal", []string{"3", "4"}},
+		{"priority<normal", []string{"4"}},
+		{"assignee==alice", []string{"1", "2"}},
+		{"assignee==8", []string{"2"}},
+		{"assignee!=alice", []string{"3", "4"}},
+		{"assignee~BO", []string{"2"}},
+		{"assignee==none", []string{"3", "4"}},
+		{"due==today", []string{"1"}},
+		{"due<week", []string{"1", "2"}},
+		{"due<=2026-03-17", []string{"1", "2"}},
+		{"due>tomorrow", []string{"2", "3"}},
+		{"due>=2026-03-24", []string{"3"}},
+		{"due!=today", []string{"2", "3"}},
+		{"due==none", []string{"4"}},
+		{"due!=none", []string{"1", "2", "3"}},
+		{`name=="fix login bug"`, []string{"2"}},
+		{"name!='Tidy docs'", []string{"1", "2", "3"}},
+		{"name~notes", []string{"1"}},
+		{"status==open && priority>=high && due<week", []string{"1"}},
+		{"status==closed || assignee==bob", []string{"2", "4"}},
+		{"!(status==open)", []string{"2", "4"}},
+		{"status==closed || status==open && priority==urgent", []string{"1", "4"}},
+		{"(status==closed || status==open) && priority==urgent", []string{"1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			predicate, err := parseTaskFilter(test.filter)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, ids(applyTaskFilter(tasks, predicate)))
+		})
+	}
+}
+
+func TestParseTaskFilterErrors(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"status==open &&", `expected a comparison at position 16, got "end of filter"`},
+		{"status open", `expected an operator after "status" at position 8, got "open"`},
+		{"status==", `expected a value after "==" at position 9, got "end of filter"`},
+		{"(status==open", `expected ) at position 14, got "end of filter"`},
+		{"status==open)", `unexpected ")" at position 13`},
+		{"name=='open", "unterminated string at position 7"},
+		{"status==open & priority==high", `unexpected "&" at position 14`},
+		{"color==red", `unknown field "color"`},
+		{"status>open", "status does not support >"},
+		{"priority==highest", `unknown priority "highest"`},
+		{"due==someday", `unknown due date "someday"`},
+		{"due<none", "due none only supports == and !="},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			_, err := parseTaskFilter(test.filter)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.want)
+			assert.True(t, stderrors.Is(err, errors.ErrInvalidInput))
+		})
+	}
+}
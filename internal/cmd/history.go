@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/output"
+)
+
+var taskHistoryCmd = &cobra.Command{
+	Use:   "history [task-id]",
+	Short: "Show a task's change history",
+	Long: `Show how a task has changed over time, newest first.
+
+ClickUp's public API only exposes a task's status history, so each entry is a
+status change with how long the task stayed in that status.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		activity, err := client.GetTaskActivity(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get task history: %v\n", err)
+			os.Exit(1)
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			if err := printTaskHistory(os.Stdout, activity); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			if err := output.Format(format, activity); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(taskHistoryCmd)
+}
+
+// printTaskHistory renders task activity as a table with relative times
+func printTaskHistory(w io.Writer, activity []api.TaskActivity) error {
+	if len(activity) == 0 {
+		fmt.Fprintln(w, "No history found")
+		return nil
+	}
+
+	type historyRow struct {
+		When  string `json:"when"`
+		Field string `json:"field"`
+		From  string `json:"from"`
+		To    string `json:"to"`
+		For   string `json:"for"`
+	}
+
+	rows := make([]historyRow, 0, len(activity))
+	for _, entry := range activity {
+		when := ""
+		if !entry.Date.IsZero() {
+			when = output.FormatTime(entry.Date, formatRelativeTime)
+		}
+		rows = append(rows, historyRow{
+			When:  when,
+			Field: entry.Field,
+			From:  entry.From,
+			To:    entry.To,
+			For:   formatMinutes(entry.Minutes),
+		})
+	}
+
+	formatter := &output.TableFormatter{Writer: w}
+	return formatter.Format(rows)
+}
+
+// formatMinutes formats a duration in minutes as days, hours and minutes,
+// e.g. "2d 3h" or "45m"
+func formatMinutes(minutes int) string {
+	days, hours, mins := minutes/(24*60), minutes/60%24, minutes%60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/api"
+)
+
+func TestPrintTaskHistory(t *testing.T) {
+	activity := []api.TaskActivity{
+		{Field: "status", From: "in progress", To: "review", Date: time.Now().Add(-2 * time.Hour), Minutes: 125},
+		{Field: "status", From: "to do", To: "in progress", Date: time.Now().Add(-3 * 24 * time.Hour), Minutes: 3 * 24 * 60},
+		{Field: "status", To: "to do", Date: time.Now().Add(-30 * 24 * time.Hour), Minutes: 40},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printTaskHistory(&buf, activity))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Contains(t, lines[0], "when")
+	assert.Contains(t, lines[2], "2 hours ago")
+	assert.Contains(t, lines[2], "review")
+	assert.Contains(t, lines[2], "2h 5m")
+	assert.Contains(t, lines[3], "3 days ago")
+	assert.Contains(t, lines[3], "3d 0h")
+	assert.Contains(t, lines[4], "40m")
+}
+
+func TestPrintTaskHistoryEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printTaskHistory(&buf, nil))
+	assert.Equal(t, "No history found\n", buf.String())
+}
+
+func TestFormatMinutes(t *testing.T) {
+	assert.Equal(t, "0m", formatMinutes(0))
+	assert.Equal(t, "59m", formatMinutes(59))
+	assert.Equal(t, "1h 0m", formatMinutes(60))
+	assert.Equal(t, "1d 1h", formatMinutes(25*60+5))
+}
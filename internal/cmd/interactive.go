@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/auth"
 	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
 )
 
 var interactiveCmd = &cobra.Command{
@@ -18,7 +21,7 @@ var interactiveCmd = &cobra.Command{
 	Short: "Interactive mode for task management",
 	Long:  `Enter interactive mode to browse and manage tasks with a user-friendly interface.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		runInteractiveMode()
+		runInteractiveMode(cmd.Context())
 	},
 }
 
@@ -27,7 +30,7 @@ var taskInteractiveCmd = &cobra.Command{
 	Short: "Interactive task browser",
 	Long:  `Browse and manage tasks interactively.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		runTaskInteractive()
+		runTaskInteractive(cmd.Context())
 	},
 }
 
@@ -35,7 +38,7 @@ func init() {
 	taskCmd.AddCommand(taskInteractiveCmd)
 }
 
-func runInteractiveMode() {
+func runInteractiveMode(ctx context.Context) {
 	for {
 		prompt := promptui.Select{
 			Label: "What would you like to do?",
@@ -55,20 +58,92 @@ func runInteractiveMode() {
 
 		switch result {
 		case "Browse Tasks":
-			runTaskInteractive()
+			runTaskInteractive(ctx)
 		case "Create Task":
-			runCreateTaskInteractive()
+			runCreateTaskInteractive(ctx)
 		case "Switch Workspace":
-			fmt.Println("Workspace switching not yet implemented")
+			if _, err := switchWorkspace(auth.NewManager(), config.GetString("default_workspace"), selectPrompt, os.Stdout); err != nil {
+				if err == promptui.ErrInterrupt {
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+			}
 		case "Exit":
 			return
 		}
 	}
 }
 
-func runTaskInteractive() {
-	ctx := context.Background()
+// selectPrompt shows items in a select prompt and returns the chosen index.
+// Tests replace it to script selections.
+var selectPrompt = func(label string, items []string) (int, error) {
+	prompt := promptui.Select{
+		Label: label,
+		Items: items,
+	}
+	index, _, err := prompt.Run()
+	return index, err
+}
+
+// authWorkspaces is the part of the auth manager used to switch workspaces
+type authWorkspaces interface {
+	ListWorkspaces() ([]string, error)
+	IsAuthenticated(workspace string) bool
+}
+
+// switchWorkspace asks which authenticated workspace to use and saves it as
+// default_workspace. Clients created afterwards use that workspace's token.
+func switchWorkspace(authMgr authWorkspaces, current string, choose func(label string, items []string) (int, error), w io.Writer) (string, error) {
+	listed, err := authMgr.ListWorkspaces()
+	if err != nil {
+		return "", fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	if current != "" {
+		listed = append(listed, current)
+	}
+
+	var workspaces []string
+	seen := make(map[string]bool)
+	for _, workspace := range listed {
+		if seen[workspace] || !authMgr.IsAuthenticated(workspace) {
+			continue
+		}
+		seen[workspace] = true
+		workspaces = append(workspaces, workspace)
+	}
+
+	if len(workspaces) == 0 {
+		return "", errors.NewUserError(
+			"No authenticated workspaces found",
+			"Run 'cu auth login --workspace <name>' to add one",
+			errors.ErrNotAuthenticated,
+		)
+	}
+
+	items := make([]string, len(workspaces))
+	for i, workspace := range workspaces {
+		items[i] = workspace
+		if workspace == current || (current == "" && workspace == auth.DefaultWorkspace) {
+			items[i] += " (current)"
+		}
+	}
+
+	index, err := choose("Select a workspace", items)
+	if err != nil {
+		return "", err
+	}
+	selected := workspaces[index]
 
+	config.Set("default_workspace", selected)
+	if err := config.Save(); err != nil {
+		return "", fmt.Errorf("failed to save default workspace: %w", err)
+	}
+
+	fmt.Fprintf(w, "✓ Switched to workspace %s\n", selected)
+	return selected, nil
+}
+
+func runTaskInteractive(ctx context.Context) {
 	// Create API client
 	client, err := api.NewClient()
 	if err != nil {
@@ -86,12 +161,12 @@ func runTaskInteractive() {
 	// Get tasks
 	tasks, err := client.GetTasks(ctx, listID, &api.TaskQueryOptions{})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to get tasks: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Failed to get tasks from default list %s: %v\n", defaultListLabel(listID), err)
 		return
 	}
 
 	if len(tasks) == 0 {
-		fmt.Println("No tasks found")
+		fmt.Printf("No tasks found in default list %s\n", defaultListLabel(listID))
 		return
 	}
 
@@ -135,10 +210,10 @@ func runTaskInteractive() {
 	}
 
 	selectedTask := tasks[index]
-	runTaskActions(selectedTask)
+	runTaskActions(ctx, selectedTask)
 }
 
-func runTaskActions(task clickup.Task) {
+func runTaskActions(ctx context.Context, task clickup.Task) {
 	for {
 		prompt := promptui.Select{
 			Label: fmt.Sprintf("Task: %s", task.Name),
@@ -161,13 +236,13 @@ func runTaskActions(task clickup.Task) {
 		case "View Details":
 			displayTaskDetails(task)
 		case "Update Status":
-			updateTaskStatusInteractive(task)
+			updateTaskStatusInteractive(ctx, task)
 			return
 		case "Update Priority":
-			updateTaskPriorityInteractive(task)
+			updateTaskPriorityInteractive(ctx, task)
 			return
 		case "Close Task":
-			closeTaskInteractive(task)
+			closeTaskInteractive(ctx, task)
 			return
 		case "Open in Browser":
 			if task.URL != "" {
@@ -209,7 +284,7 @@ func displayTaskDetails(task clickup.Task) {
 	_, _ = fmt.Scanln()
 }
 
-func updateTaskStatusInteractive(task clickup.Task) {
+func updateTaskStatusInteractive(ctx context.Context, task clickup.Task) {
 	statuses := []string{"open", "in progress", "review", "complete", "closed"}
 
 	prompt := promptui.Select{
@@ -222,7 +297,6 @@ func updateTaskStatusInteractive(task clickup.Task) {
 		return
 	}
 
-	ctx := context.Background()
 	client, _ := api.NewClient()
 
 	updateOpts := &api.TaskUpdateOptions{
@@ -238,7 +312,7 @@ func updateTaskStatusInteractive(task clickup.Task) {
 	fmt.Printf("✓ Updated task status to: %s\n", updatedTask.Status.Status)
 }
 
-func updateTaskPriorityInteractive(task clickup.Task) {
+func updateTaskPriorityInteractive(ctx context.Context, task clickup.Task) {
 	priorities := []string{"urgent", "high", "normal", "low"}
 
 	prompt := promptui.Select{
@@ -251,7 +325,6 @@ func updateTaskPriorityInteractive(task clickup.Task) {
 		return
 	}
 
-	ctx := context.Background()
 	client, _ := api.NewClient()
 
 	updateOpts := &api.TaskUpdateOptions{
@@ -267,7 +340,7 @@ func updateTaskPriorityInteractive(task clickup.Task) {
 	fmt.Printf("✓ Updated task priority to: %s\n", priority)
 }
 
-func closeTaskInteractive(task clickup.Task) {
+func closeTaskInteractive(ctx context.Context, task clickup.Task) {
 	prompt := promptui.Prompt{
 		Label:     "Are you sure you want to close this task",
 		IsConfirm: true,
@@ -278,7 +351,6 @@ func closeTaskInteractive(task clickup.Task) {
 		return
 	}
 
-	ctx := context.Background()
 	client, _ := api.NewClient()
 
 	updateOpts := &api.TaskUpdateOptions{
@@ -294,57 +366,98 @@ func closeTaskInteractive(task clickup.Task) {
 	fmt.Println("✓ Task closed successfully")
 }
 
-func runCreateTaskInteractive() {
-	// Task name
-	namePrompt := promptui.Prompt{
-		Label: "Task name",
+func runCreateTaskInteractive(ctx context.Context) {
+	// Get default list
+	listID := config.GetString("default_list")
+	if listID == "" {
+		fmt.Fprintln(os.Stderr, "No default list set. Please set one with 'cu list default'")
+		return
 	}
 
-	name, err := namePrompt.Run()
+	client, err := api.NewClient()
 	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
 		return
 	}
 
-	// Description (optional)
-	descPrompt := promptui.Prompt{
-		Label: "Description (optional)",
+	fmt.Printf("Creating a task in default list %s\n", defaultListLabel(listID))
+	task, err := runCreateTask(ctx, client, listID, textPrompt, selectPrompt, os.Stdout)
+	if err != nil {
+		if err != promptui.ErrInterrupt {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		return
 	}
 
-	description, _ := descPrompt.Run()
+	fmt.Printf("✓ Created task: %s\n", task.Name)
+	if task.URL != "" {
+		fmt.Printf("  View in ClickUp: %s\n", task.URL)
+	}
+}
 
-	// Priority
-	priorityPrompt := promptui.Select{
-		Label: "Priority",
-		Items: []string{"urgent", "high", "normal", "low"},
+// textPrompt asks for a line of text. Tests replace it to script answers.
+var textPrompt = func(label string) (string, error) {
+	prompt := promptui.Prompt{
+		Label: label,
 	}
+	return prompt.Run()
+}
 
-	_, priority, _ := priorityPrompt.Run()
+// createPriorities are the priorities offered when creating a task
+var createPriorities = []string{"urgent", "high", "normal", "low"}
 
-	// Get default list
-	listID := config.GetString("default_list")
-	if listID == "" {
-		fmt.Fprintln(os.Stderr, "No default list set. Please set one with 'cu list default'")
-		return
+// runCreateTask prompts for a task's name, description, priority and due
+// date and creates it in listID. Empty names and due dates that
+// api.ParseDueDate rejects are reported on w and asked for again.
+func runCreateTask(ctx context.Context, client taskCreator, listID string, ask func(label string) (string, error), choose func(label string, items []string) (int, error), w io.Writer) (*clickup.Task, error) {
+	var name string
+	for {
+		answer, err := ask("Task name")
+		if err != nil {
+			return nil, err
+		}
+		name = strings.TrimSpace(answer)
+		if name != "" {
+			break
+		}
+		fmt.Fprintln(w, "✗ Task name is required")
 	}
 
-	// Create task
-	ctx := context.Background()
-	client, _ := api.NewClient()
-
-	createOpts := &api.TaskCreateOptions{
-		Name:        name,
-		Description: description,
-		Priority:    priority,
+	description, err := ask("Description (optional)")
+	if err != nil {
+		return nil, err
 	}
 
-	task, err := client.CreateTask(ctx, listID, createOpts)
+	index, err := choose("Priority", createPriorities)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to create task: %v\n", err)
-		return
+		return nil, err
 	}
+	priority := createPriorities[index]
 
-	fmt.Printf("✓ Created task: %s\n", task.Name)
-	if task.URL != "" {
-		fmt.Printf("  View in ClickUp: %s\n", task.URL)
+	var due string
+	for {
+		answer, err := ask("Due date (optional: today, tomorrow, week or YYYY-MM-DD)")
+		if err != nil {
+			return nil, err
+		}
+		due = strings.TrimSpace(answer)
+		if due == "" {
+			break
+		}
+		if _, err := api.ParseDueDate(due); err == nil {
+			break
+		}
+		fmt.Fprintf(w, "✗ Invalid due date '%s'. Use today, tomorrow, week, YYYY-MM-DD or leave it empty\n", due)
+	}
+
+	task, err := client.CreateTask(ctx, listID, &api.TaskCreateOptions{
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Priority:    priority,
+		DueDate:     due,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
+	return task, nil
 }
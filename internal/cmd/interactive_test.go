@@ -1,9 +1,19 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/manifoldco/promptui"
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
 )
 
 func TestInteractiveCommand_Structure(t *testing.T) {
@@ -26,3 +36,167 @@ func TestInteractiveCommand_Structure(t *testing.T) {
 		assert.NotNil(t, interactiveCmd.Flags())
 	})
 }
+
+// fakeAuthWorkspaces lists fixed workspaces and treats the listed ones in
+// authenticated as logged in
+type fakeAuthWorkspaces struct {
+	listed        []string
+	authenticated map[string]bool
+}
+
+func (f *fakeAuthWorkspaces) ListWorkspaces() ([]string, error) {
+	return f.listed, nil
+}
+
+func (f *fakeAuthWorkspaces) IsAuthenticated(workspace string) bool {
+	return f.authenticated[workspace]
+}
+
+func TestSwitchWorkspace(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	oldConfigDir := config.DefaultConfigDir
+	config.DefaultConfigDir = t.TempDir()
+	defer func() { config.DefaultConfigDir = oldConfigDir }()
+
+	authMgr := &fakeAuthWorkspaces{
+		listed:        []string{"default"},
+		authenticated: map[string]bool{"default": true, "work": true},
+	}
+
+	t.Run("selecting a workspace makes it current", func(t *testing.T) {
+		config.Reset()
+		config.Set("default_workspace", "work")
+
+		var items []string
+		choose := func(label string, options []string) (int, error) {
+			items = options
+			return 0, nil
+		}
+
+		var out bytes.Buffer
+		selected, err := switchWorkspace(authMgr, "work", choose, &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"default", "work (current)"}, items)
+		assert.Equal(t, "default", selected)
+		assert.Equal(t, "default", config.GetString("default_workspace"))
+		assert.Equal(t, "✓ Switched to workspace default\n", out.String())
+
+		data, err := os.ReadFile(filepath.Join(config.DefaultConfigDir, config.ConfigFileName+"."+config.ConfigType))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "default_workspace: default")
+	})
+
+	t.Run("cancelled selection keeps the current workspace", func(t *testing.T) {
+		config.Reset()
+		config.Set("default_workspace", "work")
+
+		choose := func(label string, options []string) (int, error) {
+			return 0, promptui.ErrInterrupt
+		}
+
+		_, err := switchWorkspace(authMgr, "work", choose, &bytes.Buffer{})
+		assert.ErrorIs(t, err, promptui.ErrInterrupt)
+		assert.Equal(t, "work", config.GetString("default_workspace"))
+	})
+
+	t.Run("skips workspaces without a token", func(t *testing.T) {
+		config.Reset()
+
+		choose := func(label string, options []string) (int, error) {
+			t.Fatal("prompt should not be shown")
+			return 0, nil
+		}
+
+		_, err := switchWorkspace(&fakeAuthWorkspaces{listed: []string{"default"}}, "", choose, &bytes.Buffer{})
+		assert.ErrorIs(t, err, errors.ErrNotAuthenticated)
+	})
+}
+
+// recordingCreator keeps the options of the last created task
+type recordingCreator struct {
+	listID  string
+	options *api.TaskCreateOptions
+}
+
+func (r *recordingCreator) CreateTask(ctx context.Context, listID string, options *api.TaskCreateOptions) (*clickup.Task, error) {
+	r.listID, r.options = listID, options
+	return &clickup.Task{ID: "t1", Name: options.Name}, nil
+}
+
+// scriptedAnswers answers text prompts in order and records their labels
+func scriptedAnswers(t *testing.T, answers ...string) (func(label string) (string, error), *[]string) {
+	var labels []string
+	return func(label string) (string, error) {
+		labels = append(labels, label)
+		if len(answers) == 0 {
+			t.Fatalf("unexpected prompt %q", label)
+		}
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}, &labels
+}
+
+func TestRunCreateTask(t *testing.T) {
+	choosePriority := func(label string, items []string) (int, error) {
+		assert.Equal(t, []string{"urgent", "high", "normal", "low"}, items)
+		return 1, nil
+	}
+
+	t.Run("creates the task from valid answers", func(t *testing.T) {
+		client := &recordingCreator{}
+		ask, labels := scriptedAnswers(t, "Write docs", " Cover the API ", "2026-03-20")
+
+		var out bytes.Buffer
+		task, err := runCreateTask(context.Background(), client, "l1", ask, choosePriority, &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Write docs", task.Name)
+		assert.Equal(t, "l1", client.listID)
+		assert.Equal(t, &api.TaskCreateOptions{
+			Name:        "Write docs",
+			Description: "Cover the API",
+			Priority:    "high",
+			DueDate:     "2026-03-20",
+		}, client.options)
+		assert.Len(t, *labels, 3)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("asks again for empty names and invalid due dates", func(t *testing.T) {
+		client := &recordingCreator{}
+		ask, labels := scriptedAnswers(t, "  ", "Write docs", "", "next friday", "tomorrow")
+
+		var out bytes.Buffer
+		_, err := runCreateTask(context.Background(), client, "l1", ask, choosePriority, &out)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Write docs", client.options.Name)
+		assert.Equal(t, "tomorrow", client.options.DueDate)
+		assert.Len(t, *labels, 5)
+		assert.Equal(t, "✗ Task name is required\n"+
+			"✗ Invalid due date 'next friday'. Use today, tomorrow, week, YYYY-MM-DD or leave it empty\n", out.String())
+	})
+
+	t.Run("due date is optional", func(t *testing.T) {
+		client := &recordingCreator{}
+		ask, _ := scriptedAnswers(t, "Write docs", "", "")
+
+		_, err := runCreateTask(context.Background(), client, "l1", ask, choosePriority, &bytes.Buffer{})
+		require.NoError(t, err)
+		assert.Empty(t, client.options.DueDate)
+	})
+
+	t.Run("interrupted prompt creates nothing", func(t *testing.T) {
+		client := &recordingCreator{}
+		ask := func(label string) (string, error) {
+			return "", promptui.ErrInterrupt
+		}
+
+		_, err := runCreateTask(context.Background(), client, "l1", ask, choosePriority, &bytes.Buffer{})
+		assert.ErrorIs(t, err, promptui.ErrInterrupt)
+		assert.Nil(t, client.options)
+	})
+}
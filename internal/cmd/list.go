@@ -3,12 +3,19 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/api"
 	"github.com/timimsms/cu/internal/cache"
 	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
 	"github.com/timimsms/cu/internal/output"
 )
 
@@ -19,7 +26,10 @@ var (
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Manage lists",
-	Long:  `View and manage ClickUp lists.`,
+	Long: `View and manage ClickUp lists.
+
+Lists can't be moved to another folder from cu: ClickUp's API has no way to
+change a list's folder. Move lists in the ClickUp app instead.`,
 }
 
 var listListCmd = &cobra.Command{
@@ -27,7 +37,7 @@ var listListCmd = &cobra.Command{
 	Short: "List all lists",
 	Long:  `List all lists in a space or folder.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Initialize caches if not already done
 		if cache.WorkspaceCache == nil {
@@ -47,6 +57,9 @@ var listListCmd = &cobra.Command{
 		spaceID, _ := cmd.Flags().GetString("space")
 		folderID, _ := cmd.Flags().GetString("folder")
 		includeArchived, _ := cmd.Flags().GetBool("archived")
+		if noHeader, _ := cmd.Flags().GetBool("no-header"); noHeader {
+			output.SetTableHeader(false)
+		}
 
 		// If neither space nor folder specified, show error
 		if spaceID == "" && folderID == "" {
@@ -54,7 +67,15 @@ var listListCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		var allLists []interface{}
+		sortBy, _ := cmd.Flags().GetString("sort")
+		minTasks, _ := cmd.Flags().GetInt("min-tasks")
+		limit, _ := cmd.Flags().GetInt("limit")
+		if sortBy != "" && sortBy != "name" && sortBy != "tasks" {
+			fmt.Fprintf(os.Stderr, "Invalid --sort '%s'. Use name or tasks\n", sortBy)
+			os.Exit(1)
+		}
+
+		var allLists []clickup.List
 
 		if folderID != "" {
 			// Get lists from folder
@@ -102,6 +123,9 @@ var listListCmd = &cobra.Command{
 			}
 		}
 
+		allLists = sortLists(filterListsByTasks(allLists, minTasks), sortBy)
+		allLists = applyOffsetLimit(allLists, 0, limit)
+
 		// Get default list ID for highlighting
 		defaultListID := config.GetString("default_list")
 
@@ -109,40 +133,8 @@ var listListCmd = &cobra.Command{
 		format := cmd.Flag("output").Value.String()
 
 		if format == "table" {
-			// Prepare table data
-			type listRow struct {
-				ID       string `json:"id"`
-				Name     string `json:"name"`
-				Default  string `json:"default"`
-				Tasks    int    `json:"tasks"`
-				Archived bool   `json:"archived"`
-			}
-
-			var rows []listRow
-			for _, item := range allLists {
-				// Type assertion based on the actual type
-				switch v := item.(type) {
-				case map[string]interface{}:
-					id, _ := v["id"].(string)
-					name, _ := v["name"].(string)
-					archived, _ := v["archived"].(bool)
-
-					defaultMarker := ""
-					if id == defaultListID {
-						defaultMarker = "*"
-					}
-
-					row := listRow{
-						ID:       id,
-						Name:     name,
-						Default:  defaultMarker,
-						Archived: archived,
-					}
-					rows = append(rows, row)
-				}
-			}
-
-			if err := output.Format(format, rows); err != nil {
+			headers, rows := listTable(allLists, defaultListID)
+			if err := output.PrintTable(os.Stdout, headers, rows); err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
 				os.Exit(1)
 			}
@@ -156,42 +148,296 @@ var listListCmd = &cobra.Command{
 	},
 }
 
-var listDefaultCmd = &cobra.Command{
-	Use:   "default <list-id>",
-	Short: "Set default list",
-	Long:  `Set the default list for task operations.`,
-	Args:  cobra.ExactArgs(1),
+var listTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show the space, folder and list hierarchy",
+	Long: `Print an indented tree of spaces, folders and lists with their IDs.
+
+Use --space to show a single space or --workspace to show every space in a
+workspace. Without either, the default space is used if one is configured,
+otherwise the first workspace.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		listID := args[0]
+		ctx := cmd.Context()
 
-		// TODO: Validate that the list exists and is accessible
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		spaceID, _ := cmd.Flags().GetString("space")
+		workspaceID, _ := cmd.Flags().GetString("workspace")
+		if spaceID != "" && workspaceID != "" {
+			fmt.Fprintln(os.Stderr, "Please specify only one of --space or --workspace")
+			os.Exit(1)
+		}
 
-		// Save to project config if in a project, otherwise global config
-		if config.HasProjectConfig() || isProjectFlag {
-			// Save to project config
-			settings := map[string]interface{}{
-				"default_list": listID,
+		if spaceID == "" && workspaceID == "" {
+			spaceID = config.GetString("default_space")
+		}
+		if spaceID == "" && workspaceID == "" {
+			workspaces, err := client.GetWorkspaces(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get workspaces: %v\n", err)
+				os.Exit(1)
 			}
-			if err := config.SaveProjectConfig(settings); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to save project configuration: %v\n", err)
+			if len(workspaces) == 0 {
+				fmt.Fprintln(os.Stderr, "No workspaces found")
 				os.Exit(1)
 			}
+			workspaceID = workspaces[0].ID
+		}
 
-			configPath := config.GetProjectConfigPath()
-			if configPath == "" {
-				configPath = ".cu.yml"
+		var tree []*listTreeNode
+		if spaceID != "" {
+			node, err := buildSpaceTree(ctx, client, clickup.Space{ID: spaceID}, os.Stderr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get space hierarchy: %v\n", err)
+				os.Exit(1)
 			}
-			fmt.Printf("Default list set to: %s\n", listID)
-			fmt.Printf("Saved to project config: %s\n", configPath)
+			tree = append(tree, node)
 		} else {
-			// Save to global config
-			config.Set("default_list", listID)
-			if err := config.Save(); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to save configuration: %v\n", err)
+			tree, err = buildWorkspaceTree(ctx, client, workspaceID, os.Stderr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get workspace hierarchy: %v\n", err)
 				os.Exit(1)
 			}
-			fmt.Printf("Default list set to: %s (global)\n", listID)
-			fmt.Println("Tip: Use --project flag to save to project-specific config")
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			printListTree(os.Stdout, tree, config.GetString("default_list"))
+			return
+		}
+
+		if err := output.Format(format, tree); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// listTable returns the headers and rows of the list table, marking the
+// default list with "*"
+func listTable(lists []clickup.List, defaultListID string) ([]string, [][]string) {
+	headers := []string{"id", "name", "default", "tasks", "archived"}
+
+	rows := make([][]string, 0, len(lists))
+	for _, list := range lists {
+		defaultMarker := ""
+		if list.ID == defaultListID {
+			defaultMarker = "*"
+		}
+
+		rows = append(rows, []string{
+			list.ID,
+			tableName(list.Name),
+			defaultMarker,
+			strconv.Itoa(listTaskCount(list)),
+			strconv.FormatBool(list.Archived),
+		})
+	}
+
+	return headers, rows
+}
+
+// listTaskCount returns a list's task count, or 0 when ClickUp didn't send one
+func listTaskCount(list clickup.List) int {
+	n, err := list.TaskCount.Int64()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+// filterListsByTasks keeps lists with at least minTasks tasks
+func filterListsByTasks(lists []clickup.List, minTasks int) []clickup.List {
+	if minTasks <= 0 {
+		return lists
+	}
+	filtered := make([]clickup.List, 0, len(lists))
+	for _, list := range lists {
+		if listTaskCount(list) >= minTasks {
+			filtered = append(filtered, list)
+		}
+	}
+	return filtered
+}
+
+// sortLists orders lists by case-insensitive name, or by task count with the
+// busiest first. Ties fall back to name and then ID so the order is stable
+// between runs. An empty sortBy keeps ClickUp's order.
+func sortLists(lists []clickup.List, sortBy string) []clickup.List {
+	byName := func(a, b clickup.List) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	}
+
+	switch sortBy {
+	case "name":
+		slices.SortFunc(lists, byName)
+	case "tasks":
+		slices.SortFunc(lists, func(a, b clickup.List) int {
+			if c := listTaskCount(b) - listTaskCount(a); c != 0 {
+				return c
+			}
+			return byName(a, b)
+		})
+	}
+	return lists
+}
+
+// hierarchyClient is the part of the API client used to walk a workspace
+type hierarchyClient interface {
+	GetSpaces(ctx context.Context, workspaceID string) ([]clickup.Space, error)
+	GetFolders(ctx context.Context, spaceID string) ([]clickup.Folder, error)
+	GetLists(ctx context.Context, folderID string) ([]clickup.List, error)
+	GetFolderlessLists(ctx context.Context, spaceID string) ([]clickup.List, error)
+}
+
+// listTreeNode is a space, folder or list in the hierarchy
+type listTreeNode struct {
+	Type     string          `json:"type"`
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Children []*listTreeNode `json:"children,omitempty"`
+}
+
+// buildWorkspaceTree builds the hierarchy of every space in a workspace
+func buildWorkspaceTree(ctx context.Context, client hierarchyClient, workspaceID string, warn io.Writer) ([]*listTreeNode, error) {
+	spaces, err := client.GetSpaces(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := make([]*listTreeNode, 0, len(spaces))
+	for _, space := range spaces {
+		node, err := buildSpaceTree(ctx, client, space, warn)
+		if err != nil {
+			_, _ = fmt.Fprintf(warn, "Warning: failed to get hierarchy for space %s: %v\n", space.Name, err)
+			node = &listTreeNode{Type: "space", ID: space.ID, Name: space.Name}
+		}
+		tree = append(tree, node)
+	}
+
+	return tree, nil
+}
+
+// buildSpaceTree builds the hierarchy of a single space. Folderless lists come
+// first, followed by folders and their lists, which are fetched concurrently.
+// A folder whose lists can't be fetched is kept without children and reported
+// on warn.
+func buildSpaceTree(ctx context.Context, client hierarchyClient, space clickup.Space, warn io.Writer) (*listTreeNode, error) {
+	node := &listTreeNode{Type: "space", ID: space.ID, Name: space.Name}
+
+	lists, err := client.GetFolderlessLists(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		if node.Name == "" {
+			node.Name = list.Space.Name
+		}
+		node.Children = append(node.Children, &listTreeNode{Type: "list", ID: list.ID, Name: list.Name})
+	}
+
+	folders, err := client.GetFolders(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	folderLists := make([][]clickup.List, len(folders))
+	folderErrs := make([]error, len(folders))
+	var wg sync.WaitGroup
+	for i, folder := range folders {
+		wg.Add(1)
+		go func(i int, folderID string) {
+			defer wg.Done()
+			folderLists[i], folderErrs[i] = client.GetLists(ctx, folderID)
+		}(i, folder.ID)
+	}
+	wg.Wait()
+
+	for i, folder := range folders {
+		if node.Name == "" {
+			node.Name = folder.Space.Name
+		}
+
+		folderNode := &listTreeNode{Type: "folder", ID: folder.ID, Name: folder.Name}
+		if folderErrs[i] != nil {
+			_, _ = fmt.Fprintf(warn, "Warning: failed to get lists from folder %s: %v\n", folder.Name, folderErrs[i])
+		}
+		for _, list := range folderLists[i] {
+			folderNode.Children = append(folderNode.Children, &listTreeNode{Type: "list", ID: list.ID, Name: list.Name})
+		}
+		node.Children = append(node.Children, folderNode)
+	}
+
+	return node, nil
+}
+
+// printListTree writes the hierarchy as an indented tree, marking the default list
+func printListTree(w io.Writer, nodes []*listTreeNode, defaultListID string) {
+	for _, node := range nodes {
+		printListTreeNode(w, node, 0, defaultListID)
+	}
+}
+
+func printListTreeNode(w io.Writer, node *listTreeNode, depth int, defaultListID string) {
+	name := node.Name
+	if name == "" {
+		name = "(unnamed)"
+	}
+
+	marker := ""
+	if node.Type == "list" && node.ID != "" && node.ID == defaultListID {
+		marker = " *"
+	}
+
+	_, _ = fmt.Fprintf(w, "%s%s %s (%s)%s\n", strings.Repeat("  ", depth), node.Type, name, node.ID, marker)
+	for _, child := range node.Children {
+		printListTreeNode(w, child, depth+1, defaultListID)
+	}
+}
+
+var listDefaultCmd = &cobra.Command{
+	Use:   "default <list-id>",
+	Short: "Set default list",
+	Long: `Set the default list for task operations.
+
+The list is looked up first so a mistyped ID is caught, and its name is saved
+alongside the ID. Use --no-verify to save the ID without the lookup, and
+--clear to remove the default list instead.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if clearDefault, _ := cmd.Flags().GetBool("clear"); clearDefault {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if clearDefault, _ := cmd.Flags().GetBool("clear"); clearDefault {
+			if err := clearDefaultList(os.Stdout, config.HasProjectConfig() || isProjectFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		var client listGetter
+		if noVerify, _ := cmd.Flags().GetBool("no-verify"); !noVerify {
+			apiClient, err := api.NewClient()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+				os.Exit(1)
+			}
+			client = apiClient
+		}
+
+		if err := setDefaultList(cmd.Context(), os.Stdout, client, args[0], config.HasProjectConfig() || isProjectFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
 		}
 	},
 }
@@ -199,12 +445,127 @@ var listDefaultCmd = &cobra.Command{
 func init() {
 	listCmd.AddCommand(listListCmd)
 	listCmd.AddCommand(listDefaultCmd)
+	listCmd.AddCommand(listTreeCmd)
 
 	// Add --project flag to list default command
 	listDefaultCmd.Flags().BoolVarP(&isProjectFlag, "project", "p", false, "Save to project config instead of global config")
+	listDefaultCmd.Flags().Bool("clear", false, "Remove the default list")
+	listDefaultCmd.Flags().Bool("no-verify", false, "Save the list ID without checking that the list exists")
 
 	// List command flags
 	listListCmd.Flags().StringP("space", "s", "", "Space ID or name")
 	listListCmd.Flags().StringP("folder", "f", "", "Folder ID or name")
 	listListCmd.Flags().Bool("archived", false, "Include archived lists")
+	listListCmd.Flags().Bool("no-header", false, "Omit the header row of table output")
+	listListCmd.Flags().String("sort", "", "Sort lists by name or tasks (most tasks first)")
+	listListCmd.Flags().Int("min-tasks", 0, "Only show lists with at least this many tasks")
+	listListCmd.Flags().Int("limit", 0, "Maximum number of lists to show (0 for no limit)")
+
+	listTreeCmd.Flags().StringP("space", "s", "", "Space ID")
+	listTreeCmd.Flags().StringP("workspace", "w", "", "Workspace ID")
+}
+
+// defaultListLabel names the default list listID in messages, with the
+// name saved by list default when there is one. The name is only used when
+// it comes from the same config file as default_list, since a name from the
+// other file belongs to a different list.
+func defaultListLabel(listID string) string {
+	if config.InProjectConfig("default_list") != config.InProjectConfig("default_list_name") {
+		return listID
+	}
+	if name := config.GetString("default_list_name"); name != "" {
+		return fmt.Sprintf("%s (%s)", name, listID)
+	}
+	return listID
+}
+
+// listGetter is the part of the API client used by list default
+type listGetter interface {
+	GetList(ctx context.Context, listID string) (*clickup.List, error)
+}
+
+// setDefaultList saves listID as the default list in the project config when
+// project is set, otherwise in the global config. When client is not nil the
+// list is looked up first and its name saved as default_list_name.
+func setDefaultList(ctx context.Context, w io.Writer, client listGetter, listID string, project bool) error {
+	listName := ""
+	label := listID
+	if client != nil {
+		list, err := client.GetList(ctx, listID)
+		if err != nil {
+			return errors.NewUserError(
+				fmt.Sprintf("List %s could not be found: %v", listID, err),
+				"Check the ID with 'cu list list', or pass --no-verify to save it anyway",
+				errors.ErrNotFound,
+			)
+		}
+		if list.Name != "" {
+			listName = list.Name
+			label = fmt.Sprintf("%s (%s)", list.Name, listID)
+		}
+	}
+
+	if project {
+		settings := map[string]interface{}{"default_list": listID}
+		if listName != "" {
+			settings["default_list_name"] = listName
+		}
+		if err := config.SaveProjectConfig(settings); err != nil {
+			return fmt.Errorf("failed to save project configuration: %w", err)
+		}
+		// A name saved for a previous default list no longer applies
+		if listName == "" {
+			if err := config.UnsetProjectConfig("default_list_name"); err != nil {
+				return fmt.Errorf("failed to save project configuration: %w", err)
+			}
+		}
+
+		configPath := config.GetProjectConfigPath()
+		if configPath == "" {
+			configPath = ".cu.yml"
+		}
+		fmt.Fprintf(w, "Default list set to: %s\n", label)
+		fmt.Fprintf(w, "Saved to project config: %s\n", configPath)
+		return nil
+	}
+
+	config.Set("default_list", listID)
+	if listName != "" {
+		config.Set("default_list_name", listName)
+	}
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	// A name saved for a previous default list no longer applies
+	if listName == "" {
+		if err := config.Unset("default_list_name"); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+	}
+	fmt.Fprintf(w, "Default list set to: %s (global)\n", label)
+	fmt.Fprintln(w, "Tip: Use --project flag to save to project-specific config")
+	return nil
+}
+
+// clearDefaultList removes default_list from the project config when project
+// is set, otherwise from the global config
+func clearDefaultList(w io.Writer, project bool) error {
+	if project {
+		for _, key := range []string{"default_list", "default_list_name"} {
+			if err := config.UnsetProjectConfig(key); err != nil {
+				return fmt.Errorf("failed to clear default list: %w", err)
+			}
+		}
+		fmt.Fprintln(w, "Default list cleared")
+		fmt.Fprintf(w, "Removed from project config: %s\n", config.GetProjectConfigPath())
+		return nil
+	}
+
+	for _, key := range []string{"default_list", "default_list_name"} {
+		if err := config.Unset(key); err != nil {
+			return fmt.Errorf("failed to clear default list: %w", err)
+		}
+	}
+	fmt.Fprintln(w, "Default list cleared (global)")
+	return nil
 }
@@ -1,9 +1,20 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/config"
 )
 
 func TestListCommands_Structure(t *testing.T) {
@@ -37,3 +48,347 @@ func TestListCommands_Structure(t *testing.T) {
 		assert.NotNil(t, cmd.Run)
 	})
 }
+
+// fakeHierarchyClient serves a fixed workspace hierarchy
+type fakeHierarchyClient struct {
+	mu              sync.Mutex
+	spaces          []clickup.Space
+	folders         map[string][]clickup.Folder
+	folderLists     map[string][]clickup.List
+	folderlessLists map[string][]clickup.List
+	folderErrs      map[string]error
+	listCalls       []string
+}
+
+func (f *fakeHierarchyClient) GetSpaces(ctx context.Context, workspaceID string) ([]clickup.Space, error) {
+	return f.spaces, nil
+}
+
+func (f *fakeHierarchyClient) GetFolders(ctx context.Context, spaceID string) ([]clickup.Folder, error) {
+	return f.folders[spaceID], nil
+}
+
+func (f *fakeHierarchyClient) GetLists(ctx context.Context, folderID string) ([]clickup.List, error) {
+	f.mu.Lock()
+	f.listCalls = append(f.listCalls, folderID)
+	f.mu.Unlock()
+
+	if err := f.folderErrs[folderID]; err != nil {
+		return nil, err
+	}
+	return f.folderLists[folderID], nil
+}
+
+func (f *fakeHierarchyClient) GetFolderlessLists(ctx context.Context, spaceID string) ([]clickup.List, error) {
+	return f.folderlessLists[spaceID], nil
+}
+
+func newFakeHierarchy() *fakeHierarchyClient {
+	return &fakeHierarchyClient{
+		spaces: []clickup.Space{
+			{ID: "s1", Name: "Engineering"},
+			{ID: "s2", Name: "Marketing"},
+		},
+		folders: map[string][]clickup.Folder{
+			"s1": {
+				{ID: "f1", Name: "Sprints"},
+				{ID: "f2", Name: "Archive"},
+			},
+		},
+		folderLists: map[string][]clickup.List{
+			"f1": {{ID: "l2", Name: "Sprint 1"}, {ID: "l3", Name: "Sprint 2"}},
+			"f2": {{ID: "l4", Name: "Old"}},
+		},
+		folderlessLists: map[string][]clickup.List{
+			"s1": {{ID: "l1", Name: "Backlog"}},
+			"s2": {{ID: "l5", Name: "Campaigns"}},
+		},
+		folderErrs: map[string]error{},
+	}
+}
+
+func TestBuildWorkspaceTree(t *testing.T) {
+	t.Run("builds full hierarchy", func(t *testing.T) {
+		client := newFakeHierarchy()
+		var warn bytes.Buffer
+
+		tree, err := buildWorkspaceTree(context.Background(), client, "w1", &warn)
+		require.NoError(t, err)
+		require.Len(t, tree, 2)
+		assert.Empty(t, warn.String())
+
+		engineering := tree[0]
+		assert.Equal(t, "space", engineering.Type)
+		assert.Equal(t, "Engineering", engineering.Name)
+		require.Len(t, engineering.Children, 3)
+
+		assert.Equal(t, "list", engineering.Children[0].Type)
+		assert.Equal(t, "Backlog", engineering.Children[0].Name)
+
+		sprints := engineering.Children[1]
+		assert.Equal(t, "folder", sprints.Type)
+		assert.Equal(t, "Sprints", sprints.Name)
+		require.Len(t, sprints.Children, 2)
+		assert.Equal(t, "l2", sprints.Children[0].ID)
+		assert.Equal(t, "l3", sprints.Children[1].ID)
+
+		assert.Equal(t, "Archive", engineering.Children[2].Name)
+		require.Len(t, engineering.Children[2].Children, 1)
+
+		marketing := tree[1]
+		require.Len(t, marketing.Children, 1)
+		assert.Equal(t, "Campaigns", marketing.Children[0].Name)
+
+		assert.ElementsMatch(t, []string{"f1", "f2"}, client.listCalls)
+	})
+
+	t.Run("folder error degrades gracefully", func(t *testing.T) {
+		client := newFakeHierarchy()
+		client.folderErrs["f1"] = errors.New("forbidden")
+		var warn bytes.Buffer
+
+		tree, err := buildWorkspaceTree(context.Background(), client, "w1", &warn)
+		require.NoError(t, err)
+
+		engineering := tree[0]
+		require.Len(t, engineering.Children, 3)
+		assert.Equal(t, "Sprints", engineering.Children[1].Name)
+		assert.Empty(t, engineering.Children[1].Children)
+		assert.Len(t, engineering.Children[2].Children, 1)
+
+		assert.Contains(t, warn.String(), "failed to get lists from folder Sprints: forbidden")
+	})
+}
+
+func TestBuildSpaceTree_NameFromLists(t *testing.T) {
+	client := newFakeHierarchy()
+	backlog := clickup.List{ID: "l1", Name: "Backlog"}
+	backlog.Space.Name = "Engineering"
+	client.folderlessLists["s1"] = []clickup.List{backlog}
+
+	node, err := buildSpaceTree(context.Background(), client, clickup.Space{ID: "s1"}, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, "Engineering", node.Name)
+}
+
+func TestPrintListTree(t *testing.T) {
+	tree := []*listTreeNode{
+		{Type: "space", ID: "s1", Name: "Engineering", Children: []*listTreeNode{
+			{Type: "list", ID: "l1", Name: "Backlog"},
+			{Type: "folder", ID: "f1", Name: "Sprints", Children: []*listTreeNode{
+				{Type: "list", ID: "l2", Name: "Sprint 1"},
+			}},
+		}},
+	}
+
+	var out bytes.Buffer
+	printListTree(&out, tree, "l2")
+
+	expected := "space Engineering (s1)\n" +
+		"  list Backlog (l1)\n" +
+		"  folder Sprints (f1)\n" +
+		"    list Sprint 1 (l2) *\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestClearDefaultList(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	t.Run("global", func(t *testing.T) {
+		path := writeGlobalConfig(t, "default_list: list123\ndefault_space: space1\n")
+
+		var buf bytes.Buffer
+		require.NoError(t, clearDefaultList(&buf, false))
+		assert.Contains(t, buf.String(), "Default list cleared (global)")
+		assert.Empty(t, config.GetString("default_list"))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "default_list")
+		assert.Contains(t, string(data), "default_space: space1")
+	})
+
+	t.Run("project", func(t *testing.T) {
+		oldConfigDir := config.DefaultConfigDir
+		config.DefaultConfigDir = t.TempDir()
+		defer func() { config.DefaultConfigDir = oldConfigDir }()
+
+		projectDir := t.TempDir()
+		t.Chdir(projectDir)
+		projectFile := filepath.Join(projectDir, config.ProjectConfigFileName)
+		require.NoError(t, os.WriteFile(projectFile, []byte("default_list: list123\ndefault_space: space1\n"), 0600))
+
+		config.Reset()
+		require.NoError(t, config.Init(""))
+		require.Equal(t, "list123", config.GetString("default_list"))
+
+		var buf bytes.Buffer
+		require.NoError(t, clearDefaultList(&buf, true))
+		assert.Contains(t, buf.String(), "Removed from project config")
+		assert.Empty(t, config.GetString("default_list"))
+
+		data, err := os.ReadFile(projectFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "default_list")
+		assert.Contains(t, string(data), "default_space: space1")
+	})
+}
+
+// fakeListGetter knows the lists in lists and fails for any other ID
+type fakeListGetter struct {
+	lists map[string]string
+	calls int
+}
+
+func (f *fakeListGetter) GetList(ctx context.Context, listID string) (*clickup.List, error) {
+	f.calls++
+	name, ok := f.lists[listID]
+	if !ok {
+		return nil, errors.New("list not found")
+	}
+	return &clickup.List{ID: listID, Name: name}, nil
+}
+
+func TestSetDefaultList(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	setup := func(t *testing.T) {
+		oldConfigDir := config.DefaultConfigDir
+		config.DefaultConfigDir = t.TempDir()
+		t.Cleanup(func() { config.DefaultConfigDir = oldConfigDir })
+		config.Reset()
+	}
+
+	t.Run("valid list saves its name", func(t *testing.T) {
+		setup(t)
+		client := &fakeListGetter{lists: map[string]string{"list123": "Sprint 12"}}
+
+		var buf bytes.Buffer
+		require.NoError(t, setDefaultList(context.Background(), &buf, client, "list123", false))
+		assert.Contains(t, buf.String(), "Default list set to: Sprint 12 (list123) (global)")
+		assert.Equal(t, "list123", config.GetString("default_list"))
+		assert.Equal(t, "Sprint 12", config.GetString("default_list_name"))
+
+		data, err := os.ReadFile(filepath.Join(config.DefaultConfigDir, config.ConfigFileName+"."+config.ConfigType))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "default_list_name: Sprint 12")
+		assert.Equal(t, "Sprint 12 (list123)", defaultListLabel("list123"))
+	})
+
+	t.Run("invalid list is not saved", func(t *testing.T) {
+		setup(t)
+		viper.Set("default_list", "list123")
+		client := &fakeListGetter{}
+
+		var buf bytes.Buffer
+		err := setDefaultList(context.Background(), &buf, client, "typo", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "List typo could not be found")
+		assert.Contains(t, err.Error(), "--no-verify")
+		assert.Empty(t, buf.String())
+		assert.Equal(t, "list123", config.GetString("default_list"))
+	})
+
+	t.Run("no verify saves the ID as is", func(t *testing.T) {
+		path := writeGlobalConfig(t, "default_list: list123\ndefault_list_name: Old list\n")
+
+		var buf bytes.Buffer
+		require.NoError(t, setDefaultList(context.Background(), &buf, nil, "list456", false))
+		assert.Contains(t, buf.String(), "Default list set to: list456 (global)")
+		assert.Equal(t, "list456", config.GetString("default_list"))
+		assert.Empty(t, config.GetString("default_list_name"), "name of the previous list is dropped")
+		assert.Equal(t, "list456", defaultListLabel("list456"))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.Equal(t, "default_list: list456\n", string(data))
+	})
+
+	t.Run("no verify in a project drops the previous name", func(t *testing.T) {
+		setup(t)
+		projectDir := t.TempDir()
+		t.Chdir(projectDir)
+		projectFile := filepath.Join(projectDir, config.ProjectConfigFileName)
+		require.NoError(t, os.WriteFile(projectFile, []byte("default_list: list123\ndefault_list_name: Old list\n"), 0600))
+		require.NoError(t, config.Init(""))
+
+		require.NoError(t, setDefaultList(context.Background(), io.Discard, nil, "list456", true))
+
+		data, err := os.ReadFile(projectFile)
+		require.NoError(t, err)
+		assert.Equal(t, "default_list: list456\n", string(data))
+	})
+
+	t.Run("global name is not used for a project default list", func(t *testing.T) {
+		writeGlobalConfig(t, "default_list: list123\ndefault_list_name: Sprint 12\n")
+		projectDir := t.TempDir()
+		t.Chdir(projectDir)
+		projectFile := filepath.Join(projectDir, config.ProjectConfigFileName)
+		require.NoError(t, os.WriteFile(projectFile, []byte("default_list: list456\n"), 0600))
+		require.NoError(t, config.Init(""))
+
+		assert.Equal(t, "Sprint 12", config.GetString("default_list_name"))
+		assert.Equal(t, "list456", defaultListLabel("list456"))
+
+		require.NoError(t, os.WriteFile(projectFile, []byte("default_list: list456\ndefault_list_name: Backlog\n"), 0600))
+		require.NoError(t, config.Init(""))
+		assert.Equal(t, "Backlog (list456)", defaultListLabel("list456"))
+	})
+
+	t.Run("project", func(t *testing.T) {
+		setup(t)
+		projectDir := t.TempDir()
+		t.Chdir(projectDir)
+		require.NoError(t, config.Init(""))
+		client := &fakeListGetter{lists: map[string]string{"list123": "Sprint 12"}}
+
+		var buf bytes.Buffer
+		require.NoError(t, setDefaultList(context.Background(), &buf, client, "list123", true))
+		assert.Contains(t, buf.String(), "Saved to project config")
+
+		data, err := os.ReadFile(filepath.Join(projectDir, config.ProjectConfigFileName))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "default_list: list123")
+		assert.Contains(t, string(data), "default_list_name: Sprint 12")
+	})
+}
+
+func TestSortAndFilterLists(t *testing.T) {
+	newLists := func() []clickup.List {
+		return []clickup.List{
+			{ID: "4", Name: "backlog", TaskCount: "12"},
+			{ID: "1", Name: "Sprint", TaskCount: "30"},
+			{ID: "3", Name: "Archive", TaskCount: ""},
+			{ID: "2", Name: "Bugs", TaskCount: "12"},
+			{ID: "5", Name: "sprint", TaskCount: "2"},
+		}
+	}
+	ids := func(lists []clickup.List) []string {
+		var out []string
+		for _, list := range lists {
+			out = append(out, list.ID)
+		}
+		return out
+	}
+
+	t.Run("by name", func(t *testing.T) {
+		assert.Equal(t, []string{"3", "4", "2", "1", "5"}, ids(sortLists(newLists(), "name")))
+	})
+
+	t.Run("by tasks, busiest first", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "4", "2", "5", "3"}, ids(sortLists(newLists(), "tasks")))
+	})
+
+	t.Run("no sort keeps ClickUp order", func(t *testing.T) {
+		assert.Equal(t, []string{"4", "1", "3", "2", "5"}, ids(sortLists(newLists(), "")))
+	})
+
+	t.Run("min tasks", func(t *testing.T) {
+		assert.Equal(t, []string{"4", "1", "2"}, ids(filterListsByTasks(newLists(), 12)))
+		assert.Len(t, filterListsByTasks(newLists(), 0), 5)
+	})
+
+	t.Run("missing task count is zero", func(t *testing.T) {
+		assert.Zero(t, listTaskCount(clickup.List{}))
+	})
+}
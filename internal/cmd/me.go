@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -16,7 +15,7 @@ var meCmd = &cobra.Command{
 	Long: `Display information about the currently authenticated ClickUp user,
 including workspace membership and API rate limit status.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Create API client
 		client, err := api.NewClient()
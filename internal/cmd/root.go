@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/timimsms/cu/internal/cache"
 	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/output"
 	"github.com/timimsms/cu/internal/version"
 )
 
@@ -14,6 +20,13 @@ var (
 	cfgFile      string
 	debug        bool
 	outputFormat string
+	rateLimit    int
+	cacheTTL     string
+	noColor      bool
+	compactJSON  bool
+	jsonDepth    int
+	timeFormat   string
+	templateText string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -30,13 +43,79 @@ enabling efficient task management and seamless integration with development wor
 		if err := config.Init(cfgFile); err != nil {
 			return fmt.Errorf("failed to initialize config: %w", err)
 		}
+		if config.GetBool("no_color") {
+			output.SetColorEnabled(false)
+		}
+		if config.GetBool("compact_json") {
+			output.SetJSONCompact(true)
+		}
+		if jsonDepth < 0 {
+			return fmt.Errorf("invalid --depth %d: must be 0 or more", jsonDepth)
+		}
+		output.SetJSONDepth(jsonDepth)
+		output.SetTemplate(templateText, templateFuncs)
+		// Commands read --output directly, so fill it in from config when
+		// it was not given and settle auto to table or json
+		if flag := cmd.Root().PersistentFlags().Lookup("output"); flag != nil {
+			format := flag.Value.String()
+			if !flag.Changed {
+				format = resolveOutputFormat(commandOutputKey(cmd), config.GetString)
+			}
+			// --template implies template output
+			if templateText != "" && !flag.Changed {
+				format = "template"
+			}
+			_ = flag.Value.Set(output.ResolveAuto(format, output.StdoutIsTerminal))
+		}
+		if value := config.GetString("time_format"); value != "" {
+			format, err := output.ParseTimeFormat(value)
+			if err != nil {
+				return fmt.Errorf("invalid --time-format: %w", err)
+			}
+			output.SetTimeFormat(format)
+		}
+		if cacheTTL != "" {
+			if _, err := cache.ParseTTL(cacheTTL); err != nil {
+				return fmt.Errorf("invalid --cache-ttl: %w", err)
+			}
+		}
 		return nil
 	},
 }
 
+// commandOutputKey returns the config key that sets the default output format
+// of a command, e.g. output_task_list for 'cu task list'
+func commandOutputKey(cmd *cobra.Command) string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())
+	path = strings.NewReplacer(" ", "_", "-", "_").Replace(strings.TrimSpace(path))
+	return config.OutputKeyPrefix + path
+}
+
+// resolveOutputFormat returns the output format for a command when --output
+// is not given: the command's own output key, then the global output key,
+// then auto.
+func resolveOutputFormat(commandKey string, get func(string) string) string {
+	for _, key := range []string{commandKey, "output"} {
+		if format := get(key); format != "" {
+			return format
+		}
+	}
+	return output.FormatAuto
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
+// Commands run with a context that is cancelled on SIGINT or SIGTERM so
+// in-flight requests are aborted. A second signal terminates immediately.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
@@ -45,7 +124,14 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/cu/config.yml)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug mode")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (table|json|yaml|csv)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.FormatAuto, "output format (auto|table|json|yaml|csv|template); auto is table on a terminal and json when piped")
+	rootCmd.PersistentFlags().IntVar(&rateLimit, "rate-limit", 0, "maximum API requests per minute (default 100)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&compactJSON, "compact", false, "print JSON output on a single line")
+	rootCmd.PersistentFlags().IntVar(&jsonDepth, "depth", 0, "replace JSON objects and arrays nested deeper than this with \"…\" (0 for no limit)")
+	rootCmd.PersistentFlags().StringVar(&templateText, "template", "", "Go template to render output with, e.g. '{{.ID}} {{.Name}}'; implies --output template")
+	rootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", "", "how times are shown in tables: relative, absolute or iso (default relative)")
+	rootCmd.PersistentFlags().StringVar(&cacheTTL, "cache-ttl", "", "override how long cached data stays valid (e.g. 30s, 10m, 2h)")
 
 	// Bind flags to viper
 	if err := viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug")); err != nil {
@@ -57,6 +143,31 @@ func init() {
 		fmt.Fprintf(os.Stderr, "Warning: failed to bind output flag: %v\n", err)
 	}
 
+	if err := viper.BindPFlag("rate_limit", rootCmd.PersistentFlags().Lookup("rate-limit")); err != nil {
+		// Log error but don't fail - this is non-critical
+		fmt.Fprintf(os.Stderr, "Warning: failed to bind rate-limit flag: %v\n", err)
+	}
+
+	if err := viper.BindPFlag("cache_ttl", rootCmd.PersistentFlags().Lookup("cache-ttl")); err != nil {
+		// Log error but don't fail - this is non-critical
+		fmt.Fprintf(os.Stderr, "Warning: failed to bind cache-ttl flag: %v\n", err)
+	}
+
+	if err := viper.BindPFlag("no_color", rootCmd.PersistentFlags().Lookup("no-color")); err != nil {
+		// Log error but don't fail - this is non-critical
+		fmt.Fprintf(os.Stderr, "Warning: failed to bind no-color flag: %v\n", err)
+	}
+
+	if err := viper.BindPFlag("compact_json", rootCmd.PersistentFlags().Lookup("compact")); err != nil {
+		// Log error but don't fail - this is non-critical
+		fmt.Fprintf(os.Stderr, "Warning: failed to bind compact flag: %v\n", err)
+	}
+
+	if err := viper.BindPFlag("time_format", rootCmd.PersistentFlags().Lookup("time-format")); err != nil {
+		// Log error but don't fail - this is non-critical
+		fmt.Fprintf(os.Stderr, "Warning: failed to bind time-format flag: %v\n", err)
+	}
+
 	// Version flag
 	rootCmd.Version = version.Version
 	rootCmd.SetVersionTemplate(version.FullVersion())
@@ -66,3 +66,39 @@ func TestRootCommand_Structure(t *testing.T) {
 		assert.Equal(t, "o", outputFlag.Shorthand)
 	})
 }
+
+func TestCommandOutputKey(t *testing.T) {
+	assert.Equal(t, "output_task_list", commandOutputKey(taskListCmd))
+	assert.Equal(t, "output_task_assign_me", commandOutputKey(taskAssignMeCmd))
+	assert.Equal(t, "output_me", commandOutputKey(meCmd))
+}
+
+func TestResolveOutputFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   string
+	}{
+		{
+			name:   "per-command config beats global config",
+			config: map[string]string{"output": "table", "output_task_list": "json"},
+			want:   "json",
+		},
+		{
+			name:   "global config applies without a per-command key",
+			config: map[string]string{"output": "yaml", "output_task_view": "json"},
+			want:   "yaml",
+		},
+		{
+			name: "defaults to auto",
+			want: "auto",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			get := func(key string) string { return test.config[key] }
+			assert.Equal(t, test.want, resolveOutputFormat("output_task_list", get))
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -22,7 +21,7 @@ var spaceListCmd = &cobra.Command{
 	Short: "List all spaces",
 	Long:  `List all spaces in your ClickUp workspace.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Initialize caches if not already done
 		if cache.WorkspaceCache == nil {
@@ -56,7 +55,7 @@ var spaceListCmd = &cobra.Command{
 
 		// Try cache first
 		var spaces []interface{}
-		cacheKey := fmt.Sprintf("spaces_%s", workspace.ID)
+		cacheKey := spacesCacheKey(workspace.ID)
 		if cache.WorkspaceCache != nil {
 			if err := cache.WorkspaceCache.Get(cacheKey, &spaces); err == nil {
 				// Cache hit - format and return
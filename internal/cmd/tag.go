@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/output"
+)
+
+var taskTagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List the tags used in a list",
+	Long: `List the tags available in a list with their colors.
+
+The tags defined in the list's space are shown. If the space has none, or
+they cannot be read, the distinct tags of the list's tasks are shown instead.`,
+	Example: `  cu task tags --list 901234
+  cu task tags -o json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		listID, _ := cmd.Flags().GetString("list")
+
+		if listID == "" {
+			listID = config.GetString("default_list")
+			if listID == "" {
+				fmt.Fprintln(os.Stderr, "No list specified. Use --list flag or set a default list with 'cu list default'")
+				os.Exit(1)
+			}
+		}
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		tags, err := runTaskTags(ctx, client, listID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			if len(tags) == 0 {
+				fmt.Println("No tags found")
+				return
+			}
+			headers, rows := tagTable(tags)
+			if err := output.PrintTable(os.Stdout, headers, rows); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			if err := output.Format(format, tags); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(taskTagsCmd)
+
+	taskTagsCmd.Flags().StringP("list", "l", "", "List ID (defaults to the default list)")
+}
+
+// tagLister is the part of the API client used by task tags
+type tagLister interface {
+	GetList(ctx context.Context, listID string) (*clickup.List, error)
+	GetSpaceTags(ctx context.Context, spaceID string) ([]clickup.Tag, error)
+	GetAllTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error)
+}
+
+// runTaskTags returns the tags of the list's space sorted by name, falling
+// back to the distinct tags of the list's tasks when the space has none
+func runTaskTags(ctx context.Context, client tagLister, listID string) ([]clickup.Tag, error) {
+	list, err := client.GetList(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+
+	if list.Space.ID != "" {
+		tags, err := client.GetSpaceTags(ctx, list.Space.ID)
+		if err == nil && len(tags) > 0 {
+			return distinctTags(tags), nil
+		}
+	}
+
+	tasks, err := client.GetAllTasks(ctx, listID, &api.TaskQueryOptions{IncludeClosed: true, Subtasks: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	var tags []clickup.Tag
+	for _, task := range tasks {
+		tags = append(tags, task.Tags...)
+	}
+	return distinctTags(tags), nil
+}
+
+// distinctTags drops repeated tags, comparing names case-insensitively and
+// keeping the first seen, and sorts the rest by name
+func distinctTags(tags []clickup.Tag) []clickup.Tag {
+	seen := make(map[string]bool, len(tags))
+	distinct := make([]clickup.Tag, 0, len(tags))
+	for _, tag := range tags {
+		key := strings.ToLower(tag.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		distinct = append(distinct, tag)
+	}
+
+	sort.Slice(distinct, func(i, j int) bool {
+		return strings.ToLower(distinct[i].Name) < strings.ToLower(distinct[j].Name)
+	})
+	return distinct
+}
+
+// tagTable returns the headers and rows of the tag table
+func tagTable(tags []clickup.Tag) ([]string, [][]string) {
+	headers := []string{"name", "foreground", "background"}
+
+	rows := make([][]string, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, []string{tag.Name, tag.TagFg, tag.TagBg})
+	}
+
+	return headers, rows
+}
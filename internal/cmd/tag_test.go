@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/api"
+)
+
+type fakeTagLister struct {
+	spaceTags  []clickup.Tag
+	spaceErr   error
+	tasks      []clickup.Task
+	taskCalls  int
+	listSpaces map[string]string
+}
+
+func (f *fakeTagLister) GetList(ctx context.Context, listID string) (*clickup.List, error) {
+	list := &clickup.List{ID: listID}
+	list.Space.ID = f.listSpaces[listID]
+	return list, nil
+}
+
+func (f *fakeTagLister) GetSpaceTags(ctx context.Context, spaceID string) ([]clickup.Tag, error) {
+	return f.spaceTags, f.spaceErr
+}
+
+func (f *fakeTagLister) GetAllTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error) {
+	f.taskCalls++
+	return f.tasks, nil
+}
+
+func tagNames(tags []clickup.Tag) []string {
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TestRunTaskTags(t *testing.T) {
+	overlapping := []clickup.Task{
+		{ID: "1", Tags: []clickup.Tag{{Name: "frontend", TagBg: "#f00"}, {Name: "bug"}}},
+		{ID: "2", Tags: []clickup.Tag{{Name: "bug"}, {Name: "backend"}}},
+		{ID: "3"},
+		{ID: "4", Tags: []clickup.Tag{{Name: "Frontend"}, {Name: "backend"}}},
+	}
+
+	t.Run("aggregates distinct task tags", func(t *testing.T) {
+		client := &fakeTagLister{tasks: overlapping}
+
+		tags, err := runTaskTags(context.Background(), client, "l1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "bug", "frontend"}, tagNames(tags))
+		assert.Equal(t, "#f00", tags[2].TagBg, "first seen tag keeps its colors")
+	})
+
+	t.Run("uses space tags when available", func(t *testing.T) {
+		client := &fakeTagLister{
+			listSpaces: map[string]string{"l1": "s1"},
+			spaceTags:  []clickup.Tag{{Name: "urgent", TagFg: "#fff", TagBg: "#000"}, {Name: "bug"}},
+			tasks:      overlapping,
+		}
+
+		tags, err := runTaskTags(context.Background(), client, "l1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bug", "urgent"}, tagNames(tags))
+		assert.Zero(t, client.taskCalls)
+	})
+
+	t.Run("falls back to task tags when space tags fail", func(t *testing.T) {
+		client := &fakeTagLister{
+			listSpaces: map[string]string{"l1": "s1"},
+			spaceErr:   stderrors.New("forbidden"),
+			tasks:      overlapping,
+		}
+
+		tags, err := runTaskTags(context.Background(), client, "l1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "bug", "frontend"}, tagNames(tags))
+	})
+
+	t.Run("table rows carry colors", func(t *testing.T) {
+		headers, rows := tagTable([]clickup.Tag{{Name: "urgent", TagFg: "#fff", TagBg: "#000"}})
+		assert.Equal(t, []string{"name", "foreground", "background"}, headers)
+		assert.Equal(t, [][]string{{"urgent", "#fff", "#000"}}, rows)
+	})
+}
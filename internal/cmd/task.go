@@ -2,16 +2,24 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/cache"
+	"github.com/timimsms/cu/internal/clock"
 	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
 	"github.com/timimsms/cu/internal/output"
 )
 
@@ -24,9 +32,21 @@ var taskCmd = &cobra.Command{
 var taskListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List tasks",
-	Long:  `List tasks from ClickUp with various filtering and sorting options.`,
+	Long: `List tasks from ClickUp with various filtering and sorting options.
+
+` + filterGrammar,
+	Example: `  cu task list --filter 'status==open && priority>=high && due<week'
+  cu task list --filter 'assignee==none || name~"release notes"'
+
+  # Incremental sync: every task changed since the last run, oldest first
+  cu task list --list Sprint --all --updated-since 2026-03-01T08:30:00Z -o json`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
+
+		if err := applyTemplateName(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
 
 		// Create API client
 		client, err := api.NewClient()
@@ -39,93 +59,255 @@ var taskListCmd = &cobra.Command{
 		listID, _ := cmd.Flags().GetString("list")
 		spaceID, _ := cmd.Flags().GetString("space")
 		folderID, _ := cmd.Flags().GetString("folder")
-		assignee, _ := cmd.Flags().GetString("assignee")
+		assignees, _ := cmd.Flags().GetStringSlice("assignee")
 		status, _ := cmd.Flags().GetString("status")
 		tag, _ := cmd.Flags().GetString("tag")
 		priority, _ := cmd.Flags().GetString("priority")
 		due, _ := cmd.Flags().GetString("due")
 		sortBy, _ := cmd.Flags().GetString("sort")
 		order, _ := cmd.Flags().GetString("order")
+		nulls, _ := cmd.Flags().GetString("nulls")
 		limit, _ := cmd.Flags().GetInt("limit")
 		page, _ := cmd.Flags().GetInt("page")
+		mine, _ := cmd.Flags().GetBool("mine")
+		unassigned, _ := cmd.Flags().GetBool("unassigned")
+		overdue, _ := cmd.Flags().GetBool("overdue")
+		parentID, _ := cmd.Flags().GetString("parent")
+		fields, _ := cmd.Flags().GetStringSlice("fields")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		archived, _ := cmd.Flags().GetBool("archived")
+		count, _ := cmd.Flags().GetBool("count")
+		porcelain, _ := cmd.Flags().GetBool("porcelain")
+		noHeader, _ := cmd.Flags().GetBool("no-header")
+		envelope, _ := cmd.Flags().GetBool("envelope")
+		totals, _ := cmd.Flags().GetBool("totals")
+		filterExpr, _ := cmd.Flags().GetString("filter")
+		updatedSince, _ := cmd.Flags().GetString("updated-since")
+		all, _ := cmd.Flags().GetBool("all")
+		useCache, _ := cmd.Flags().GetBool("cache")
+		format := cmd.Flag("output").Value.String()
+
+		if noHeader {
+			output.SetTableHeader(false)
+		}
+
+		if mine && len(assignees) > 0 {
+			fmt.Fprintln(os.Stderr, "--mine cannot be combined with --assignee")
+			os.Exit(1)
+		}
+
+		if envelope && format != "json" && format != "yaml" {
+			fmt.Fprintln(os.Stderr, "--envelope needs json or yaml output")
+			os.Exit(1)
+		}
+
+		order, err = normalizeSortOrder(order)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		if nulls != "first" && nulls != "last" {
+			fmt.Fprintf(os.Stderr, "Invalid --nulls '%s'. Use first or last\n", nulls)
+			os.Exit(1)
+		}
+
+		if groupBy != "" && !isTaskGroupField(groupBy) {
+			fmt.Fprintf(os.Stderr, "Invalid --group-by '%s'. Use status, assignee, or priority\n", groupBy)
+			os.Exit(1)
+		}
+
+		var since time.Time
+		if updatedSince != "" {
+			since, err = parseUpdatedSince(updatedSince)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// --all fetches every page and --envelope reports whether there are
+		// more, so --limit only applies when given
+		if (all || envelope) && !cmd.Flags().Changed("limit") {
+			limit = 0
+		}
 
-		// If no list is specified, try to use default from config
+		var filter taskPredicate
+		if filterExpr != "" {
+			filter, err = parseTaskFilter(filterExpr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Subtasks live in their parent's list
+		if parentID != "" && listID == "" && spaceID == "" && folderID == "" {
+			parent, err := client.GetTask(ctx, parentID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get parent task: %v\n", err)
+				os.Exit(1)
+			}
+			listID = parent.List.ID
+		}
+
+		// If no list is specified, try to use defaults from config
 		if listID == "" && spaceID == "" && folderID == "" {
 			listID = config.GetString("default_list")
-			if listID == "" {
+			if listID == "" && mine {
+				spaceID = config.GetString("default_space")
+			}
+			if listID == "" && spaceID == "" {
 				fmt.Fprintln(os.Stderr, "No list specified. Use --list, --space, or --folder flag, or set a default list with 'cu list default'")
 				os.Exit(1)
 			}
 		}
 
-		// TODO: Implement space/folder to list resolution
-		// For now, require a list ID
-		if listID == "" {
-			fmt.Fprintln(os.Stderr, "List ID is required for now. Space/folder resolution coming soon.")
+		// Resolve space and list names to IDs
+		spaceID, listID, err = resolveSpaceAndList(ctx, client, spaceID, listID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		// Resolve the lists to query
+		listIDs, err := resolveTaskListIDs(ctx, client, listID, spaceID, folderID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to resolve lists: %v\n", err)
+			os.Exit(1)
+		}
+
+		// ClickUp filters assignees by user ID
+		assigneeIDs, err := client.ResolveAssignees(ctx, assignees)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to resolve assignees: %v\n", err)
 			os.Exit(1)
 		}
 
 		// Build query options
-		queryOpts := &api.TaskQueryOptions{
-			Page: page,
+		queryOpts := buildTaskQueryOptions(page, assigneeIDs, status, tag)
+
+		if mine {
+			user, err := client.GetCurrentUser(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get current user: %v\n", err)
+				os.Exit(1)
+			}
+			applyMineOptions(queryOpts, user.ID)
 		}
 
-		if assignee != "" {
-			queryOpts.Assignees = []string{assignee}
+		if parentID != "" {
+			queryOpts.Subtasks = true
 		}
-		if status != "" {
-			queryOpts.Statuses = []string{status}
+		queryOpts.Archived = archived
+
+		// Custom field values are needed for --fields columns and raw output
+		queryOpts.IncludeCustomFields = len(fields) > 0 || format != "table"
+
+		// Recently updated tasks come first, so paging can stop at since
+		if !since.IsZero() {
+			queryOpts.OrderBy = "updated"
+			queryOpts.UpdatedSince = since
 		}
-		if tag != "" {
-			queryOpts.Tags = []string{tag}
+
+		var fetcher taskPageFetcher = client
+		if useCache {
+			initCaches()
+			if cache.TaskCache != nil {
+				fetcher = &cachedTaskFetcher{fetcher: client, cache: cache.TaskCache, scope: client.CacheScope()}
+			}
 		}
 
 		// Get tasks
-		tasks, err := client.GetTasks(ctx, listID, queryOpts)
+		var tasks []clickup.Task
+		var hasMore bool
+		if fetchesAllPages(all, parentID, cmd.Flags().Changed("page")) {
+			tasks, err = fetchAllTaskPages(ctx, fetcher, listIDs, queryOpts)
+		} else {
+			tasks, hasMore, err = fetchTaskPage(ctx, fetcher, listIDs, queryOpts)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get tasks: %v\n", err)
 			os.Exit(1)
 		}
 
+		// Oldest changes first unless --sort is given, ready to replay
+		if !since.IsZero() {
+			tasks = filterUpdatedSince(tasks, since)
+			if sortBy == "" {
+				sortBy, order = "updated", "asc"
+			}
+		}
+
+		// Only open tasks are shown for --mine
+		if mine {
+			tasks = filterOpenTasks(tasks)
+		}
+
+		if parentID != "" {
+			tasks = filterSubtasks(tasks, parentID)
+		}
+
+		// The API has no filter for tasks without assignees
+		if unassigned {
+			tasks = filterUnassignedTasks(tasks)
+		}
+
 		// Apply client-side filtering
 		tasks = filterTasks(tasks, priority, due)
+		if filter != nil {
+			tasks = applyTaskFilter(tasks, filter)
+		}
+
+		// Overdue tasks are listed most overdue first unless --sort is given
+		if overdue {
+			tasks = filterOverdueTasks(tasks, clock.Now())
+			if sortBy == "" {
+				sortBy, order = "due", "asc"
+			}
+		}
+
+		if count {
+			if err := printTaskCount(os.Stdout, format, len(tasks)); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 
 		// Apply sorting
-		sortTasks(tasks, sortBy, order)
+		sortTasks(tasks, sortBy, order, nulls)
 
 		// Apply limit
-		if limit > 0 && len(tasks) > limit {
-			tasks = tasks[:limit]
-		}
+		tasks, hasMore = limitTaskPage(tasks, limit, hasMore)
 
 		// Format output
-		format := cmd.Flag("output").Value.String()
-
-		if format == "table" {
-			// Prepare table data
-			type taskRow struct {
-				ID       string `json:"id"`
-				Name     string `json:"name"`
-				Status   string `json:"status"`
-				Assignee string `json:"assignee"`
-				Priority string `json:"priority"`
-				Due      string `json:"due"`
-			}
-
-			var rows []taskRow
-			for _, task := range tasks {
-				row := taskRow{
-					ID:       task.ID,
-					Name:     truncate(task.Name, 50),
-					Status:   getTaskStatus(task),
-					Assignee: getTaskAssignee(task),
-					Priority: getTaskPriority(task),
-					Due:      getTaskDueDate(task),
-				}
-				rows = append(rows, row)
+		if porcelain {
+			if err := printTaskPorcelain(os.Stdout, tasks); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
 			}
+			return
+		}
 
-			if err := output.Format(format, rows); err != nil {
+		if format == "table" && groupBy != "" && len(tasks) > 0 {
+			if err := printTaskGroups(os.Stdout, groupTasks(tasks, groupBy), fields); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else if format == "table" {
+			if err := printTaskTable(os.Stdout, tasks, fields); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else if format == "csv" {
+			if err := printTaskCSV(os.Stdout, tasks, fields); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else if envelope {
+			if err := output.Format(format, newTaskEnvelope(tasks, page, hasMore)); err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
 				os.Exit(1)
 			}
@@ -136,6 +318,10 @@ var taskListCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+
+		if format == "table" {
+			printTaskFooter(os.Stdout, tasks, quiet, totals)
+		}
 	},
 }
 
@@ -144,7 +330,7 @@ var taskCreateCmd = &cobra.Command{
 	Short: "Create a new task",
 	Long:  `Create a new task in ClickUp with the specified name and optional properties.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Get task name from args or flag
 		var name string
@@ -159,6 +345,8 @@ var taskCreateCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		initCaches()
+
 		// Create API client
 		client, err := api.NewClient()
 		if err != nil {
@@ -174,12 +362,34 @@ var taskCreateCmd = &cobra.Command{
 		priority, _ := cmd.Flags().GetString("priority")
 		dueDate, _ := cmd.Flags().GetString("due")
 		tags, _ := cmd.Flags().GetStringSlice("tag")
+		fromStdin, _ := cmd.Flags().GetBool("stdin")
+		notifyAll := notifyOption(cmd)
 
-		// If no list is specified, try to use default from config
-		if listID == "" {
-			listID = config.GetString("default_list")
-			if listID == "" {
-				fmt.Fprintln(os.Stderr, "No list specified. Use --list flag or set a default list with 'cu list default'")
+		description, err = readDescription(cmd.InOrStdin(), description, fromStdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		usesDefault := listID == ""
+		listID, err = resolveCreateListID(ctx, client, listID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		if usesDefault {
+			fmt.Fprintf(os.Stderr, "Using default list %s\n", defaultListLabel(listID))
+		}
+
+		if statusType := statusTypeOption(cmd); statusType != "" {
+			list, err := client.GetList(ctx, listID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get list: %v\n", err)
+				os.Exit(1)
+			}
+			status, err = resolveStatusByType(list, statusType)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
 				os.Exit(1)
 			}
 		}
@@ -191,6 +401,7 @@ var taskCreateCmd = &cobra.Command{
 			Status:      status,
 			Priority:    priority,
 			Tags:        tags,
+			NotifyAll:   notifyAll,
 		}
 
 		// Handle assignees
@@ -234,12 +445,37 @@ var taskCreateCmd = &cobra.Command{
 var taskViewCmd = &cobra.Command{
 	Use:   "view [task-id]",
 	Short: "View task details",
-	Long:  `View detailed information about a specific task.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `View detailed information about a specific task.
+
+Use --output markdown to print the task as a markdown block for pasting into
+pull requests and documents. Use --raw to print the task exactly as ClickUp
+returns it, whatever the output setting, or --web to open it in the browser.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
+
+		if err := applyTemplateName(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
 		taskID := args[0]
 
+		web, _ := cmd.Flags().GetBool("web")
+		raw, _ := cmd.Flags().GetBool("raw")
+		showCustomFields, _ := cmd.Flags().GetBool("show-custom-fields")
+		mode := selectTaskViewMode(web, raw)
+
+		if mode == taskViewWeb {
+			link := taskWebURL(taskID)
+			fmt.Printf("Opening %s in your browser.\n", link)
+			if err := openURL(link); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to open browser: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		// Create API client
 		client, err := api.NewClient()
 		if err != nil {
@@ -254,6 +490,14 @@ var taskViewCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if mode == taskViewRaw {
+			if err := printRawTask(os.Stdout, task); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		// Format output
 		format := cmd.Flag("output").Value.String()
 
@@ -304,9 +548,22 @@ var taskViewCmd = &cobra.Command{
 				}
 				fmt.Println()
 			}
+
+			if len(task.Checklists) > 0 {
+				fmt.Printf("\nChecklists:\n")
+				printChecklists(os.Stdout, task.Checklists)
+			}
+
+			if showCustomFields {
+				printTaskCustomFields(os.Stdout, task.CustomFields)
+			}
 		} else {
 			// For other formats, output the raw task
-			if err := output.Format(format, task); err != nil {
+			var data interface{} = task
+			if format == "markdown" || format == "md" {
+				data = markdownTask{task: task}
+			}
+			if err := output.Format(format, data); err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
 				os.Exit(1)
 			}
@@ -317,12 +574,17 @@ var taskViewCmd = &cobra.Command{
 var taskUpdateCmd = &cobra.Command{
 	Use:   "update [task-id]",
 	Short: "Update a task",
-	Long:  `Update an existing task with new properties.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Update an existing task with new properties.
+
+--add-assignee and --remove-assignee change single assignees and keep the
+rest. Use 'cu task assign' to replace all of a task's assignees.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		taskID := args[0]
 
+		initCaches()
+
 		// Create API client
 		client, err := api.NewClient()
 		if err != nil {
@@ -333,12 +595,14 @@ var taskUpdateCmd = &cobra.Command{
 		// Get flags
 		name, _ := cmd.Flags().GetString("name")
 		description, _ := cmd.Flags().GetString("description")
+		appendText, _ := cmd.Flags().GetString("append-description")
 		status, _ := cmd.Flags().GetString("status")
 		priority, _ := cmd.Flags().GetString("priority")
 		dueDate, _ := cmd.Flags().GetString("due")
 		addAssignees, _ := cmd.Flags().GetStringSlice("add-assignee")
 		removeAssignees, _ := cmd.Flags().GetStringSlice("remove-assignee")
 		tags, _ := cmd.Flags().GetStringSlice("tag")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 		// Build update options
 		updateOpts := &api.TaskUpdateOptions{
@@ -350,6 +614,23 @@ var taskUpdateCmd = &cobra.Command{
 			Tags:            tags,
 			AddAssignees:    addAssignees,
 			RemoveAssignees: removeAssignees,
+			NotifyAll:       notifyOption(cmd),
+		}
+
+		if statusType := statusTypeOption(cmd); statusType != "" {
+			updateOpts.Status, err = resolveTaskStatusByType(ctx, client, taskID, statusType)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to update task: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if appendText != "" {
+			updateOpts.Description, err = appendTaskDescription(ctx, client, taskID, appendText)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to update task: %v\n", err)
+				os.Exit(1)
+			}
 		}
 
 		// Check if any updates were specified
@@ -358,16 +639,19 @@ var taskUpdateCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		// Update task
-		task, err := client.UpdateTask(ctx, taskID, updateOpts)
+		format := cmd.Flag("output").Value.String()
+
+		// Update task, or only show the plan in dry-run mode
+		task, err := runTaskUpdate(ctx, client, os.Stdout, format, taskID, updateOpts, dryRun)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to update task: %v\n", err)
 			os.Exit(1)
 		}
+		if task == nil {
+			return
+		}
 
 		// Format output
-		format := cmd.Flag("output").Value.String()
-
 		if format == "table" {
 			fmt.Printf("✓ Updated task %s: %s\n", task.ID, task.Name)
 			if task.URL != "" {
@@ -383,13 +667,119 @@ var taskUpdateCmd = &cobra.Command{
 	},
 }
 
+// taskUpdater is the part of the API client used by task update
+type taskUpdater interface {
+	PlanTaskUpdate(ctx context.Context, options *api.TaskUpdateOptions) (*api.TaskUpdatePlan, error)
+	UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error)
+}
+
+// runTaskUpdate applies an update to a task. In dry-run mode it prints the
+// resolved plan instead and returns a nil task.
+func runTaskUpdate(ctx context.Context, client taskUpdater, w io.Writer, format, taskID string, opts *api.TaskUpdateOptions, dryRun bool) (*clickup.Task, error) {
+	if !dryRun {
+		return client.UpdateTask(ctx, taskID, opts)
+	}
+
+	plan, err := client.PlanTaskUpdate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if format != "table" {
+		return nil, output.Format(format, map[string]interface{}{
+			"task_id": taskID,
+			"dry_run": true,
+			"update":  plan,
+		})
+	}
+
+	printTaskUpdatePlan(w, taskID, opts, plan)
+	return nil, nil
+}
+
+// taskGetter is the part of the API client used to read a task before
+// changing it
+type taskGetter interface {
+	GetTask(ctx context.Context, taskID string) (*clickup.Task, error)
+}
+
+// appendTaskDescription returns the task's current description with text
+// added on a new line. Trailing newlines of the current description are
+// dropped so notes are separated by exactly one line break.
+func appendTaskDescription(ctx context.Context, client taskGetter, taskID, text string) (string, error) {
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get task: %w", err)
+	}
+
+	current := strings.TrimRight(task.Description, "\r\n")
+	if current == "" {
+		return text, nil
+	}
+	return current + "\n" + text, nil
+}
+
+// printTaskUpdatePlan shows the resolved values task update would send
+func printTaskUpdatePlan(w io.Writer, taskID string, opts *api.TaskUpdateOptions, plan *api.TaskUpdatePlan) {
+	_, _ = fmt.Fprintln(w, "Dry run - no changes will be made")
+	_, _ = fmt.Fprintf(w, "Would update task %s:\n", taskID)
+	if plan.Name != "" {
+		_, _ = fmt.Fprintf(w, "  Name: %s\n", plan.Name)
+	}
+	if plan.Description != "" {
+		_, _ = fmt.Fprintf(w, "  Description: %s\n", plan.Description)
+	}
+	if plan.Status != "" {
+		_, _ = fmt.Fprintf(w, "  Status: %s\n", plan.Status)
+	}
+	if plan.Priority != 0 {
+		_, _ = fmt.Fprintf(w, "  Priority: %s (%d)\n", opts.Priority, plan.Priority)
+	}
+	if opts.DueDate != "" {
+		if plan.DueDate != nil {
+			_, _ = fmt.Fprintf(w, "  Due date: %s\n", plan.DueDate.Format("2006-01-02 15:04"))
+		} else {
+			_, _ = fmt.Fprintf(w, "  Due date: %s (could not be parsed, will be skipped)\n", opts.DueDate)
+		}
+	}
+	if len(plan.Tags) > 0 {
+		_, _ = fmt.Fprintf(w, "  Tags: %s\n", strings.Join(plan.Tags, ", "))
+	}
+	if len(opts.AddAssignees) > 0 {
+		_, _ = fmt.Fprintf(w, "  Add assignees: %s\n", formatPlannedAssignees(opts.AddAssignees, plan.AddAssignees))
+	}
+	if len(opts.RemoveAssignees) > 0 {
+		_, _ = fmt.Fprintf(w, "  Remove assignees: %s\n", formatPlannedAssignees(opts.RemoveAssignees, plan.RemoveAssignees))
+	}
+	if plan.NotifyAll {
+		_, _ = fmt.Fprintln(w, "  Notify: everyone on the task")
+	}
+}
+
+// formatPlannedAssignees pairs requested assignee names with their resolved IDs
+func formatPlannedAssignees(names []string, ids []int) string {
+	if len(ids) != len(names) {
+		return strings.Join(names, ", ") + " (could not be resolved, will be skipped)"
+	}
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		if name == strconv.Itoa(ids[i]) {
+			parts[i] = name
+		} else {
+			parts[i] = fmt.Sprintf("%s (%d)", name, ids[i])
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 var taskCloseCmd = &cobra.Command{
 	Use:   "close [task-id]",
 	Short: "Close a task",
 	Long:  `Close a task by marking it as complete.`,
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		taskID := args[0]
 
 		// Create API client
@@ -434,10 +824,11 @@ var taskCloseCmd = &cobra.Command{
 var taskReopenCmd = &cobra.Command{
 	Use:   "reopen [task-id]",
 	Short: "Reopen a task",
-	Long:  `Reopen a closed task by marking it as open/in progress.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Reopen a closed task. The task moves to --status, which must be one of
+its list's open statuses, or to the list's first open status.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		taskID := args[0]
 
 		// Create API client
@@ -447,20 +838,11 @@ var taskReopenCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		// Get the status flag or use default
 		status, _ := cmd.Flags().GetString("status")
-		if status == "" {
-			status = "open" // Default to "open"
-		}
-
-		// Update task
-		updateOpts := &api.TaskUpdateOptions{
-			Status: status,
-		}
 
-		updatedTask, err := client.UpdateTask(ctx, taskID, updateOpts)
+		updatedTask, err := runTaskReopen(ctx, client, taskID, status)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to reopen task: %v\n", err)
+			fmt.Fprintf(os.Stderr, "%v\n", err)
 			os.Exit(1)
 		}
 
@@ -483,14 +865,32 @@ var taskReopenCmd = &cobra.Command{
 	},
 }
 
-var taskSearchCmd = &cobra.Command{
-	Use:   "search [query]",
-	Short: "Search for tasks",
-	Long:  `Search for tasks across all lists in your workspace. Searches in task names and descriptions.`,
-	Args:  cobra.MinimumNArgs(1),
+var taskMoveStatusCmd = &cobra.Command{
+	Use:   "move-status [task-id] [status]",
+	Short: "Move a task to another status",
+	Long: `Move a task to another status.
+
+With --validate-transition the status must be one of the statuses of the
+task's list, and moving the task backward in the list's workflow, such as
+from a closed status to an open one, prints a warning unless --force is
+given.`,
+	Example: `  cu task move-status abc123 "in review"
+  cu task move-status abc123 open --validate-transition --force`,
+	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
-		query := strings.Join(args, " ")
+		ctx := cmd.Context()
+		taskID, status := args[0], args[1]
+
+		validate, _ := cmd.Flags().GetBool("validate-transition")
+		force, _ := cmd.Flags().GetBool("force")
+		if force && !validate {
+			fmt.Fprintln(os.Stderr, errors.NewUserError(
+				"--force only applies with --validate-transition",
+				"Add --validate-transition, or drop --force",
+				errors.ErrInvalidInput,
+			))
+			os.Exit(1)
+		}
 
 		// Create API client
 		client, err := api.NewClient()
@@ -499,222 +899,1763 @@ var taskSearchCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		// Get search scope from flags
-		spaceID, _ := cmd.Flags().GetString("space")
-		listID, _ := cmd.Flags().GetString("list")
-		searchDescription, _ := cmd.Flags().GetBool("include-description")
-		limit, _ := cmd.Flags().GetInt("limit")
-
-		// Get workspaces to search
-		workspaces, err := client.GetWorkspaces(ctx)
+		updatedTask, err := runTaskMoveStatus(ctx, client, os.Stderr, taskID, status, validate, force)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get workspaces: %v\n", err)
+			fmt.Fprintf(os.Stderr, "%v\n", err)
 			os.Exit(1)
 		}
 
-		if len(workspaces) == 0 {
-			fmt.Fprintln(os.Stderr, "No workspaces found")
-			os.Exit(1)
-		}
-
-		var allTasks []clickup.Task
-		var searchErrors []string
+		// Format output
+		format := cmd.Flag("output").Value.String()
 
-		// If specific list is provided, search only that list
-		if listID != "" {
-			tasks, err := client.GetTasks(ctx, listID, &api.TaskQueryOptions{})
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to get tasks from list %s: %v\n", listID, err)
-				os.Exit(1)
+		if format == "table" {
+			fmt.Printf("✓ Moved task %s to %s: %s\n", updatedTask.ID, updatedTask.Status.Status, updatedTask.Name)
+			if updatedTask.URL != "" {
+				fmt.Printf("  View in ClickUp: %s\n", updatedTask.URL)
 			}
-			allTasks = tasks
 		} else {
-			// Search across all lists in workspace or space
-			for _, workspace := range workspaces {
-				spaces, err := client.GetSpaces(ctx, workspace.ID)
-				if err != nil {
-					searchErrors = append(searchErrors, fmt.Sprintf("Failed to get spaces for workspace %s: %v", workspace.Name, err))
-					continue
-				}
-
-				for _, space := range spaces {
-					// Skip if specific space is requested and this isn't it
-					if spaceID != "" && space.ID != spaceID && space.Name != spaceID {
-						continue
-					}
-
-					// Get folders in space
-					folders, err := client.GetFolders(ctx, space.ID)
-					if err != nil {
-						searchErrors = append(searchErrors, fmt.Sprintf("Failed to get folders for space %s: %v", space.Name, err))
-						continue
-					}
-
-					// Get tasks from folders
-					for _, folder := range folders {
-						lists, err := client.GetLists(ctx, folder.ID)
-						if err != nil {
-							searchErrors = append(searchErrors, fmt.Sprintf("Failed to get lists for folder %s: %v", folder.Name, err))
-							continue
-						}
-
-						for _, list := range lists {
-							tasks, err := client.GetTasks(ctx, list.ID, &api.TaskQueryOptions{})
-							if err != nil {
-								searchErrors = append(searchErrors, fmt.Sprintf("Failed to get tasks for list %s: %v", list.Name, err))
-								continue
-							}
-							allTasks = append(allTasks, tasks...)
-						}
-					}
-
-					// Get folderless lists
-					lists, err := client.GetFolderlessLists(ctx, space.ID)
-					if err != nil {
-						searchErrors = append(searchErrors, fmt.Sprintf("Failed to get folderless lists for space %s: %v", space.Name, err))
-						continue
-					}
-
-					for _, list := range lists {
-						tasks, err := client.GetTasks(ctx, list.ID, &api.TaskQueryOptions{})
-						if err != nil {
-							searchErrors = append(searchErrors, fmt.Sprintf("Failed to get tasks for list %s: %v", list.Name, err))
-							continue
-						}
-						allTasks = append(allTasks, tasks...)
-					}
-				}
+			if err := output.Format(format, updatedTask); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
 			}
 		}
+	},
+}
 
-		// Print any errors encountered during search
-		if len(searchErrors) > 0 {
-			fmt.Fprintln(os.Stderr, "Some errors occurred during search:")
-			for _, err := range searchErrors {
-				fmt.Fprintf(os.Stderr, "  - %s\n", err)
-			}
+var taskBumpCmd = &cobra.Command{
+	Use:   "bump [task-id]",
+	Short: "Raise or lower a task's priority by one step",
+	Long: `Raise or lower a task's priority by one step, between low, normal, high and
+urgent. A task without a priority counts as normal. A task already at the top
+or bottom is left unchanged.`,
+	Example: `  cu task bump abc123 --priority up
+  cu task bump abc123 --priority down`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		taskID := args[0]
+
+		direction, _ := cmd.Flags().GetString("priority")
+		if direction != "up" && direction != "down" {
+			fmt.Fprintf(os.Stderr, "Invalid --priority '%s'. Use up or down\n", direction)
+			os.Exit(1)
 		}
 
-		// Filter tasks based on search query
-		query = strings.ToLower(query)
+		// Create API client
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		result, err := runTaskBump(ctx, client, taskID, direction)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		// Format output
+		format := cmd.Flag("output").Value.String()
+
+		if format == "table" {
+			if result.From == result.To {
+				fmt.Printf("Task %s is already %s priority\n", result.Task.ID, result.To)
+				return
+			}
+			fmt.Printf("✓ Changed priority of task %s: %s → %s\n", result.Task.ID, result.From, result.To)
+		} else {
+			if err := output.Format(format, result.Task); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+var taskLinkCmd = &cobra.Command{
+	Use:   "link [task-id] [other-id]",
+	Short: "Add a dependency between two tasks",
+	Long: `Add a dependency between two tasks.
+
+By default the first task is waiting on the second. Use --type blocks to mark
+the first task as blocking the second instead.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTaskLinkCommand(cmd, args, false)
+	},
+}
+
+var taskUnlinkCmd = &cobra.Command{
+	Use:   "unlink [task-id] [other-id]",
+	Short: "Remove a dependency between two tasks",
+	Long:  `Remove a dependency previously added with 'cu task link'. Use the same --type that was used to create it.`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTaskLinkCommand(cmd, args, true)
+	},
+}
+
+var taskAssignCmd = &cobra.Command{
+	Use:   "assign [task-id] [user...]",
+	Short: "Set a task's assignees",
+	Long: `Set the exact assignees of a task. Users given by username, email or ID
+are assigned and everyone else is unassigned.
+
+To add or remove single assignees while keeping the rest, use 'cu task update'
+with --add-assignee and --remove-assignee.`,
+	Example: `  cu task assign abc123 alice bob@example.com
+  cu task assign abc123 12345`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		taskID := args[0]
+
+		// Create API client
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		task, err := runTaskAssign(ctx, client, taskID, args[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			fmt.Printf("✓ Assigned task %s to %s: %s\n", task.ID, taskAssigneeNames(*task), task.Name)
+		} else {
+			if err := output.Format(format, task); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+var taskAssignMeCmd = &cobra.Command{
+	Use:   "assign-me [task-id]",
+	Short: "Assign a task to yourself",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTaskAssignMeCommand(cmd, args[0], false)
+	},
+}
+
+var taskUnassignMeCmd = &cobra.Command{
+	Use:   "unassign-me [task-id]",
+	Short: "Remove yourself from a task's assignees",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTaskAssignMeCommand(cmd, args[0], true)
+	},
+}
+
+var taskDueSoonCmd = &cobra.Command{
+	Use:   "due-soon",
+	Short: "List open tasks due in the next few days",
+	Long: `List open tasks due between the start of today and the end of the next
+--days days, soonest first. Tasks come from --list or --space, falling back to the default list and
+then the default space.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		listID, _ := cmd.Flags().GetString("list")
+		spaceID, _ := cmd.Flags().GetString("space")
+		days, _ := cmd.Flags().GetInt("days")
+		format := cmd.Flag("output").Value.String()
+
+		if days < 1 {
+			fmt.Fprintln(os.Stderr, "--days must be at least 1")
+			os.Exit(1)
+		}
+
+		if listID == "" && spaceID == "" {
+			listID = config.GetString("default_list")
+			if listID == "" {
+				spaceID = config.GetString("default_space")
+			}
+			if listID == "" && spaceID == "" {
+				fmt.Fprintln(os.Stderr, "No list specified. Use --list or --space flag, or set a default list with 'cu list default'")
+				os.Exit(1)
+			}
+		}
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		spaceID, listID, err = resolveSpaceAndList(ctx, client, spaceID, listID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		listIDs, err := resolveTaskListIDs(ctx, client, listID, spaceID, "")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to resolve lists: %v\n", err)
+			os.Exit(1)
+		}
+
+		var tasks []clickup.Task
+		for _, id := range listIDs {
+			listTasks, err := client.GetAllTasks(ctx, id, &api.TaskQueryOptions{IncludeCustomFields: format != "table"})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get tasks: %v\n", err)
+				os.Exit(1)
+			}
+			tasks = append(tasks, listTasks...)
+		}
+
+		tasks = filterDueWithin(filterOpenTasks(tasks), clock.Now(), days)
+		sortTasks(tasks, "due", "asc", "last")
+
+		if format == "table" {
+			if len(tasks) == 0 {
+				fmt.Printf("No open tasks due in the next %d day(s)\n", days)
+				return
+			}
+			if err := printTaskTable(os.Stdout, tasks, nil); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			if err := output.Format(format, tasks); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+var taskSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search for tasks",
+	Long: `Search for tasks across all lists in your workspace. Searches in task names and descriptions.
+
+Lists that cannot be read are skipped and reported on stderr, and the command
+then exits with status 1 after printing the tasks it found. With
+--with-errors, json and yaml output is an object with the matching tasks and
+an errors array listing the skipped parts of the workspace.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		if err := applyTemplateName(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		query := strings.Join(args, " ")
+		format := cmd.Flag("output").Value.String()
+
+		withErrors, _ := cmd.Flags().GetBool("with-errors")
+		if withErrors && format != "json" && format != "yaml" {
+			fmt.Fprintln(os.Stderr, "--with-errors applies to json and yaml output")
+			os.Exit(1)
+		}
+
+		// Create API client
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Get search scope from flags
+		spaceID, _ := cmd.Flags().GetString("space")
+		listID, _ := cmd.Flags().GetString("list")
+		searchDescription, _ := cmd.Flags().GetBool("include-description")
+		overdue, _ := cmd.Flags().GetBool("overdue")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		// Resolve space and list names to IDs
+		spaceID, listID, err = resolveSpaceAndList(ctx, client, spaceID, listID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var allTasks []clickup.Task
+		var fetchErrors []fetchError
+
+		// Custom field values are only shown in raw output formats
+		queryOpts := &api.TaskQueryOptions{
+			IncludeCustomFields: format != "table",
+		}
+
+		// If specific list is provided, search only that list
+		if listID != "" {
+			tasks, err := client.GetTasks(ctx, listID, queryOpts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get tasks from list %s: %v\n", listID, err)
+				os.Exit(1)
+			}
+			allTasks = tasks
+		} else {
+			// Search across all lists in workspace or space
+			allTasks, fetchErrors, err = collectWorkspaceTasks(ctx, client, spaceID, func(listID string) ([]clickup.Task, error) {
+				return client.GetTasks(ctx, listID, queryOpts)
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			printFetchErrors(os.Stderr, "search", fetchErrors)
+		}
+
+		// The results are incomplete, so exit with an error once they are
+		// printed
+		if len(fetchErrors) > 0 {
+			defer os.Exit(1)
+		}
+
+		// Filter tasks based on search query
+		query = strings.ToLower(query)
 		var matchedTasks []clickup.Task
 
-		for _, task := range allTasks {
-			nameMatch := strings.Contains(strings.ToLower(task.Name), query)
-			descMatch := searchDescription && strings.Contains(strings.ToLower(task.Description), query)
+		for _, task := range allTasks {
+			nameMatch := strings.Contains(strings.ToLower(task.Name), query)
+			descMatch := searchDescription && strings.Contains(strings.ToLower(task.Description), query)
+
+			if nameMatch || descMatch {
+				matchedTasks = append(matchedTasks, task)
+			}
+		}
+
+		if overdue {
+			matchedTasks = filterOverdueTasks(matchedTasks, clock.Now())
+			sortTasks(matchedTasks, "due", "asc", "last")
+		}
+
+		// Format output
+		if count, _ := cmd.Flags().GetBool("count"); count {
+			if err := printTaskCount(os.Stdout, format, len(matchedTasks)); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// Apply limit
+		matchedTasks = applyLimit(matchedTasks, limit)
+
+		if format == "table" {
+			if len(matchedTasks) == 0 {
+				fmt.Printf("No tasks found matching '%s'\n", strings.Join(args, " "))
+				return
+			}
+
+			fmt.Printf("Found %d task(s) matching '%s':\n\n", len(matchedTasks), strings.Join(args, " "))
+
+			if err := printTaskTable(os.Stdout, matchedTasks, nil); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else if format == "csv" {
+			if err := printTaskCSV(os.Stdout, matchedTasks, nil); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else if withErrors {
+			if err := output.Format(format, newPartialTasks(matchedTasks, fetchErrors)); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			// For other formats, output raw task data
+			if err := output.Format(format, matchedTasks); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	taskCmd.AddCommand(taskListCmd)
+	taskCmd.AddCommand(taskCreateCmd)
+	taskCmd.AddCommand(taskViewCmd)
+	taskCmd.AddCommand(taskUpdateCmd)
+	taskCmd.AddCommand(taskCloseCmd)
+	taskCmd.AddCommand(taskReopenCmd)
+	taskCmd.AddCommand(taskMoveStatusCmd)
+	taskCmd.AddCommand(taskBumpCmd)
+	taskCmd.AddCommand(taskSearchCmd)
+	taskCmd.AddCommand(taskLinkCmd)
+	taskCmd.AddCommand(taskUnlinkCmd)
+	taskCmd.AddCommand(taskAssignCmd)
+	taskCmd.AddCommand(taskAssignMeCmd)
+	taskCmd.AddCommand(taskUnassignMeCmd)
+	taskCmd.AddCommand(taskDueSoonCmd)
+
+	// List command flags
+	addTemplateNameFlag(taskListCmd)
+	taskListCmd.Flags().StringP("list", "l", "", "List ID or name")
+	taskListCmd.Flags().StringP("space", "s", "", "Space ID or name")
+	taskListCmd.Flags().StringP("folder", "f", "", "Folder ID or name")
+	taskListCmd.Flags().StringSlice("assignee", []string{}, "Filter by assignees, matching tasks assigned to any of them (username or ID; repeatable)")
+	taskListCmd.Flags().String("status", "", "Filter by status")
+	taskListCmd.Flags().String("tag", "", "Filter by tag")
+	taskListCmd.Flags().String("priority", "", "Filter by priority")
+	taskListCmd.Flags().String("due", "", "Filter by due date (today, tomorrow, week, overdue)")
+	taskListCmd.Flags().String("filter", "", "Filter by an expression such as 'status==open && priority>=high'")
+	taskListCmd.Flags().Int("limit", 30, "Maximum number of tasks to return (0 or less for no limit)")
+	taskListCmd.Flags().Int("page", 0, "Page number for pagination")
+	taskListCmd.Flags().Bool("cache", false, "Serve results from the task cache while they are fresh (see 'cu cache invalidate')")
+	taskListCmd.Flags().Bool("all", false, "Fetch every page instead of one (--limit applies only when given)")
+	taskListCmd.Flags().String("updated-since", "", "Show only tasks updated after a time (RFC 3339, YYYY-MM-DD, or ago such as 2h or 7d), oldest first")
+	taskListCmd.Flags().String("sort", "", "Sort by field (created, updated, due, priority, name, assignee)")
+	taskListCmd.Flags().String("order", "asc", "Sort order (asc, desc)")
+	taskListCmd.Flags().String("nulls", "last", "Where tasks without a due date go when sorting by due (first, last)")
+	taskListCmd.Flags().Bool("mine", false, "Show only open tasks assigned to you")
+	taskListCmd.Flags().Bool("unassigned", false, "Show only tasks with no assignees")
+	taskListCmd.Flags().Bool("overdue", false, "Show only open tasks past their due date, most overdue first")
+	taskListCmd.Flags().String("parent", "", "Show only subtasks of this task ID, searching every page of the list unless --page is given")
+	taskListCmd.Flags().StringSlice("fields", []string{}, "Custom fields to show as extra table columns (name or ID)")
+	taskListCmd.Flags().Bool("archived", false, "Include archived tasks")
+	taskListCmd.Flags().Bool("count", false, "Print only the number of matching tasks")
+	taskListCmd.Flags().BoolP("quiet", "q", false, "Hide the summary line after the table (--totals is still printed)")
+	taskListCmd.Flags().String("group-by", "", "Group table output by field (status, assignee, priority)")
+	taskListCmd.Flags().Bool("porcelain", false, "Print tab-separated id, name, status, priority and due date in a stable format for scripts")
+	taskListCmd.Flags().Bool("no-header", false, "Omit the header row of table output")
+	taskListCmd.Flags().Bool("envelope", false, "Wrap json or yaml output in an object with count, page and has_more (no default --limit)")
+	taskListCmd.Flags().Bool("totals", false, "Sum the estimated and tracked time of the listed tasks below the table")
+	taskListCmd.MarkFlagsMutuallyExclusive("porcelain", "count")
+	taskListCmd.MarkFlagsMutuallyExclusive("porcelain", "group-by")
+	taskListCmd.MarkFlagsMutuallyExclusive("envelope", "porcelain")
+	taskListCmd.MarkFlagsMutuallyExclusive("envelope", "count")
+	taskListCmd.MarkFlagsMutuallyExclusive("unassigned", "assignee")
+	taskListCmd.MarkFlagsMutuallyExclusive("all", "page")
+	taskListCmd.MarkFlagsMutuallyExclusive("unassigned", "mine")
+	taskListCmd.MarkFlagsMutuallyExclusive("overdue", "due")
+
+	// View command flags
+	addTemplateNameFlag(taskViewCmd)
+	taskViewCmd.Flags().Bool("web", false, "Open the task in the browser")
+	taskViewCmd.Flags().Bool("raw", false, "Print the task as ClickUp returns it, ignoring --output")
+	taskViewCmd.Flags().Bool("show-custom-fields", false, "Show the task's custom field values")
+	taskViewCmd.MarkFlagsMutuallyExclusive("web", "raw")
+
+	// Create command flags
+	taskCreateCmd.Flags().StringP("name", "n", "", "Task name (alternative to providing as argument)")
+	taskCreateCmd.Flags().StringP("list", "l", "", "List ID or name to create task in")
+	taskCreateCmd.Flags().StringP("description", "d", "", "Task description ('-' to read it from stdin)")
+	taskCreateCmd.Flags().Bool("stdin", false, "Read the task description from stdin")
+	taskCreateCmd.Flags().StringSliceP("assignee", "a", []string{}, "Assignees (username or ID)")
+	taskCreateCmd.Flags().StringP("status", "s", "", "Task status")
+	taskCreateCmd.Flags().StringP("priority", "p", "", "Task priority (urgent, high, normal, low)")
+	taskCreateCmd.Flags().String("due", "", "Due date (ISO format or 'today', 'tomorrow')")
+	taskCreateCmd.Flags().StringSlice("tag", []string{}, "Tags to add to the task")
+	addNotifyFlags(taskCreateCmd)
+	addStatusTypeFlags(taskCreateCmd)
+
+	// Update command flags
+	taskUpdateCmd.Flags().StringP("name", "n", "", "New task name")
+	taskUpdateCmd.Flags().StringP("description", "d", "", "New task description")
+	taskUpdateCmd.Flags().String("append-description", "", "Add text to the end of the task description on a new line")
+	taskUpdateCmd.Flags().StringP("status", "s", "", "New task status")
+	taskUpdateCmd.Flags().StringP("priority", "p", "", "New task priority (urgent, high, normal, low)")
+	taskUpdateCmd.Flags().String("due", "", "New due date (ISO format or 'today', 'tomorrow')")
+	taskUpdateCmd.Flags().StringSlice("tag", []string{}, "Replace tags with these tags")
+	taskUpdateCmd.Flags().StringSlice("add-assignee", []string{}, "Add assignees (username or ID)")
+	taskUpdateCmd.Flags().StringSlice("remove-assignee", []string{}, "Remove assignees (username or ID)")
+	taskUpdateCmd.Flags().Bool("dry-run", false, "Show the resolved update without applying it")
+	addNotifyFlags(taskUpdateCmd)
+	taskUpdateCmd.MarkFlagsMutuallyExclusive("description", "append-description")
+	addStatusTypeFlags(taskUpdateCmd)
+
+	// Reopen command flags
+	taskReopenCmd.Flags().StringP("status", "s", "", "Status to set when reopening (default: the list's first open status)")
+
+	// Move-status command flags
+	taskMoveStatusCmd.Flags().Bool("validate-transition", false, "Check the status exists in the task's list and warn about backward moves")
+	taskMoveStatusCmd.Flags().Bool("force", false, "Skip the backward move warning of --validate-transition")
+
+	// Bump command flags
+	taskBumpCmd.Flags().String("priority", "", "Direction to move the priority (up, down)")
+	_ = taskBumpCmd.MarkFlagRequired("priority")
+
+	// Link command flags
+	taskLinkCmd.Flags().String("type", api.DependencyWaitingOn, "Dependency type (blocks, waiting-on)")
+	taskUnlinkCmd.Flags().String("type", api.DependencyWaitingOn, "Dependency type (blocks, waiting-on)")
+
+	// Due-soon command flags
+	taskDueSoonCmd.Flags().StringP("list", "l", "", "List ID or name")
+	taskDueSoonCmd.Flags().StringP("space", "s", "", "Space ID or name")
+	taskDueSoonCmd.Flags().Int("days", 3, "Number of days ahead to look")
+
+	// Search command flags
+	addTemplateNameFlag(taskSearchCmd)
+	taskSearchCmd.Flags().StringP("space", "s", "", "Limit search to a space (ID or name)")
+	taskSearchCmd.Flags().StringP("list", "l", "", "Limit search to a list (ID or name)")
+	taskSearchCmd.Flags().Bool("include-description", false, "Search in task descriptions as well as names")
+	taskSearchCmd.Flags().Bool("overdue", false, "Show only open tasks past their due date, most overdue first")
+	taskSearchCmd.Flags().Bool("count", false, "Print only the number of matching tasks")
+	taskSearchCmd.Flags().Bool("with-errors", false, "Write json or yaml as an object with the tasks and an errors array for lists that could not be read")
+	taskSearchCmd.Flags().Int("limit", 50, "Maximum number of results to return (0 or less for no limit)")
+}
+
+// taskAssigner is the part of the API client used by task assign
+type taskAssigner interface {
+	GetTask(ctx context.Context, taskID string) (*clickup.Task, error)
+	ResolveAssignees(ctx context.Context, assignees []string) ([]string, error)
+	UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error)
+}
+
+// runTaskAssign makes users the only assignees of a task. ClickUp only adds
+// and removes assignees, so the difference to the current assignees is
+// sent. The task is not updated when the assignees already match.
+func runTaskAssign(ctx context.Context, client taskAssigner, taskID string, users []string) (*clickup.Task, error) {
+	ids, err := client.ResolveAssignees(ctx, users)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve assignees: %w", err)
+	}
+
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	current := make([]string, 0, len(task.Assignees))
+	for _, assignee := range task.Assignees {
+		current = append(current, strconv.Itoa(assignee.ID))
+	}
+
+	opts := &api.TaskUpdateOptions{}
+	for _, id := range ids {
+		if !slices.Contains(current, id) && !slices.Contains(opts.AddAssignees, id) {
+			opts.AddAssignees = append(opts.AddAssignees, id)
+		}
+	}
+	for _, id := range current {
+		if !slices.Contains(ids, id) {
+			opts.RemoveAssignees = append(opts.RemoveAssignees, id)
+		}
+	}
+	if !opts.HasUpdates() {
+		return task, nil
+	}
+
+	updated, err := client.UpdateTask(ctx, taskID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return updated, nil
+}
+
+// taskSelfAssigner is the part of the API client used by assign-me and unassign-me
+type taskSelfAssigner interface {
+	GetCurrentUser(ctx context.Context) (*clickup.User, error)
+	UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error)
+}
+
+// runTaskAssignMeCommand handles task assign-me and task unassign-me
+func runTaskAssignMeCommand(cmd *cobra.Command, taskID string, unassign bool) {
+	ctx := cmd.Context()
+
+	client, err := api.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+		os.Exit(1)
+	}
+
+	task, err := runTaskAssignMe(ctx, client, taskID, unassign)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	format := cmd.Flag("output").Value.String()
+	if format == "table" {
+		if unassign {
+			fmt.Printf("✓ Unassigned you from task %s: %s\n", task.ID, task.Name)
+		} else {
+			fmt.Printf("✓ Assigned task %s to you: %s\n", task.ID, task.Name)
+		}
+	} else {
+		if err := output.Format(format, task); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// runTaskAssignMe adds the current user to a task's assignees, or removes
+// them when unassign is set
+func runTaskAssignMe(ctx context.Context, client taskSelfAssigner, taskID string, unassign bool) (*clickup.Task, error) {
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	me := []string{strconv.Itoa(user.ID)}
+	opts := &api.TaskUpdateOptions{AddAssignees: me}
+	if unassign {
+		opts = &api.TaskUpdateOptions{RemoveAssignees: me}
+	}
+
+	task, err := client.UpdateTask(ctx, taskID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return task, nil
+}
+
+// taskLinker adds and removes task dependencies
+type taskLinker interface {
+	AddDependency(ctx context.Context, taskID, otherID, depType string) error
+	RemoveDependency(ctx context.Context, taskID, otherID, depType string) error
+}
+
+// runTaskLinkCommand handles task link and task unlink
+func runTaskLinkCommand(cmd *cobra.Command, args []string, unlink bool) {
+	ctx := cmd.Context()
+
+	client, err := api.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+		os.Exit(1)
+	}
+
+	depType, _ := cmd.Flags().GetString("type")
+	if err := runTaskLink(ctx, client, os.Stdout, args[0], args[1], depType, unlink); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runTaskLink adds or removes a dependency and reports the result to w
+func runTaskLink(ctx context.Context, client taskLinker, w io.Writer, taskID, otherID, depType string, unlink bool) error {
+	relation := "is waiting on"
+	if depType == api.DependencyBlocks {
+		relation = "blocks"
+	}
+
+	if unlink {
+		if err := client.RemoveDependency(ctx, taskID, otherID, depType); err != nil {
+			return fmt.Errorf("failed to remove dependency: %w", err)
+		}
+		fmt.Fprintf(w, "✓ Removed dependency: %s %s %s\n", taskID, relation, otherID)
+		return nil
+	}
+
+	if err := client.AddDependency(ctx, taskID, otherID, depType); err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+	fmt.Fprintf(w, "✓ Added dependency: %s %s %s\n", taskID, relation, otherID)
+	return nil
+}
+
+// Helper functions
+
+// buildTaskQueryOptions builds the API query options from task list filters
+func buildTaskQueryOptions(page int, assignees []string, status, tag string) *api.TaskQueryOptions {
+	queryOpts := &api.TaskQueryOptions{
+		Page: page,
+	}
+
+	// Tasks assigned to any of the assignees match
+	if len(assignees) > 0 {
+		queryOpts.Assignees = assignees
+	}
+	if status != "" {
+		queryOpts.Statuses = []string{status}
+	}
+	if tag != "" {
+		queryOpts.Tags = []string{tag}
+	}
+
+	return queryOpts
+}
+
+// statusTypeFlags maps the status convenience flags to the ClickUp status
+// type they pick from the list
+var statusTypeFlags = []struct {
+	flag       string
+	statusType string
+	usage      string
+}{
+	{"status-open", "open", "Set the list's first open status"},
+	{"status-in-progress", "custom", "Set the list's first in-progress status"},
+	{"status-done", "done", "Set the list's done status, or its closed status if it has none"},
+	{"status-closed", "closed", "Set the list's closed status"},
+}
+
+// addStatusTypeFlags registers the status convenience flags, which can't be
+// combined with --status or each other
+func addStatusTypeFlags(cmd *cobra.Command) {
+	names := []string{"status"}
+	for _, f := range statusTypeFlags {
+		cmd.Flags().Bool(f.flag, false, f.usage)
+		names = append(names, f.flag)
+	}
+	cmd.MarkFlagsMutuallyExclusive(names...)
+}
+
+// statusTypeOption returns the status type chosen with a status convenience
+// flag, or "" when none was given
+func statusTypeOption(cmd *cobra.Command) string {
+	for _, f := range statusTypeFlags {
+		if set, _ := cmd.Flags().GetBool(f.flag); set {
+			return f.statusType
+		}
+	}
+	return ""
+}
+
+// addNotifyFlags registers the --notify flag
+func addNotifyFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("notify", false, "Notify everyone on the task, including you")
+}
+
+// notifyOption returns true for notify_all when --notify was given, or nil so
+// ClickUp's default applies. ClickUp's only notify field is notify_all, and
+// false is its default, so there is no quieter setting to ask for.
+func notifyOption(cmd *cobra.Command) *bool {
+	if notify, _ := cmd.Flags().GetBool("notify"); notify {
+		return &notify
+	}
+	return nil
+}
+
+// applyMineOptions restricts a task query to open tasks assigned to userID
+func applyMineOptions(opts *api.TaskQueryOptions, userID int) {
+	opts.Assignees = []string{strconv.Itoa(userID)}
+	opts.IncludeClosed = false
+}
+
+// resolveSpaceAndList converts space and list names to IDs. Empty values are
+// returned unchanged.
+func resolveSpaceAndList(ctx context.Context, client *api.Client, space, list string) (string, string, error) {
+	if space != "" {
+		resolved, err := client.ResolveSpace(ctx, space)
+		if err != nil {
+			return "", "", err
+		}
+		space = resolved.ID
+	}
+
+	if list != "" {
+		resolved, err := client.ResolveList(ctx, list)
+		if err != nil {
+			return "", "", err
+		}
+		list = resolved.ID
+	}
+
+	return space, list, nil
+}
+
+// listResolver is the part of the API client used to resolve list names
+type listResolver interface {
+	ResolveList(ctx context.Context, nameOrID string) (*clickup.List, error)
+}
+
+// resolveCreateListID returns the ID of the list a new task goes in: list
+// resolved by ID or name, or the default list when list is empty. Names
+// shared by several lists are an error.
+func resolveCreateListID(ctx context.Context, client listResolver, list string) (string, error) {
+	if list == "" {
+		list = config.GetString("default_list")
+		if list == "" {
+			return "", errors.NewUserError(
+				"No list specified",
+				"Use --list flag or set a default list with 'cu list default'",
+				errors.ErrInvalidInput,
+			)
+		}
+		return list, nil
+	}
+
+	resolved, err := client.ResolveList(ctx, list)
+	if err != nil {
+		return "", err
+	}
+	return resolved.ID, nil
+}
+
+// resolveTaskListIDs returns the list IDs to query for a list, space, or folder
+func resolveTaskListIDs(ctx context.Context, client *api.Client, listID, spaceID, folderID string) ([]string, error) {
+	if listID != "" {
+		return []string{listID}, nil
+	}
+
+	var listIDs []string
+
+	if folderID != "" {
+		lists, err := client.GetLists(ctx, folderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get lists from folder: %w", err)
+		}
+		for _, list := range lists {
+			listIDs = append(listIDs, list.ID)
+		}
+		return listIDs, nil
+	}
+
+	// Get folderless lists from space
+	lists, err := client.GetFolderlessLists(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folderless lists: %w", err)
+	}
+	for _, list := range lists {
+		listIDs = append(listIDs, list.ID)
+	}
+
+	// Also get lists from folders in the space
+	folders, err := client.GetFolders(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders: %w", err)
+	}
+	for _, folder := range folders {
+		lists, err := client.GetLists(ctx, folder.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get lists from folder %s: %v\n", folder.Name, err)
+			continue
+		}
+		for _, list := range lists {
+			listIDs = append(listIDs, list.ID)
+		}
+	}
+
+	return listIDs, nil
+}
+
+// isClosedTask reports whether a task is in a closed or done status
+func isClosedTask(task clickup.Task) bool {
+	statusType := strings.ToLower(task.Status.Type)
+	return statusType == "closed" || statusType == "done"
+}
+
+// taskStatusUpdater is the part of the API client used by task reopen and
+// task move-status
+type taskStatusUpdater interface {
+	taskListGetter
+	UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error)
+}
+
+// runTaskReopen moves a task to an open status of its list, checking status
+// against the list's statuses first
+func runTaskReopen(ctx context.Context, client taskStatusUpdater, taskID, status string) (*clickup.Task, error) {
+	status, err := taskReopenStatus(ctx, client, taskID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateTask(ctx, taskID, &api.TaskUpdateOptions{Status: status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen task: %w", err)
+	}
+	return updated, nil
+}
+
+// taskReopenStatus returns the status runTaskReopen would move a task to
+func taskReopenStatus(ctx context.Context, client taskListGetter, taskID, status string) (string, error) {
+	_, list, err := getTaskList(ctx, client, taskID)
+	if err != nil {
+		return "", err
+	}
+	return resolveReopenStatus(list, status)
+}
+
+// runTaskMoveStatus moves a task to status. With validate, the move is
+// checked against the statuses of the task's list first, and a backward move
+// is warned about on w unless force is set.
+func runTaskMoveStatus(ctx context.Context, client taskStatusUpdater, w io.Writer, taskID, status string, validate, force bool) (*clickup.Task, error) {
+	if validate {
+		task, list, err := getTaskList(ctx, client, taskID)
+		if err != nil {
+			return nil, err
+		}
+
+		from := task.Status.Status
+		var backward bool
+		status, backward, err = validateStatusTransition(list, from, status)
+		if err != nil {
+			return nil, err
+		}
+		if backward && !force {
+			fmt.Fprintf(w, "Warning: moving the task back from '%s' to '%s'\n", from, status)
+		}
+	}
+
+	updated, err := client.UpdateTask(ctx, taskID, &api.TaskUpdateOptions{Status: status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move task: %w", err)
+	}
+	return updated, nil
+}
+
+// validateStatusTransition checks that to is one of the list's statuses and
+// returns it with the list's spelling, and whether it comes earlier in the
+// list's workflow than from
+func validateStatusTransition(list *clickup.List, from, to string) (string, bool, error) {
+	fromIndex, toIndex := -1, -1
+	names := make([]string, 0, len(list.Statuses))
+	for i, s := range list.Statuses {
+		if strings.EqualFold(s.Status, from) {
+			fromIndex = i
+		}
+		if strings.EqualFold(s.Status, to) {
+			toIndex = i
+		}
+		names = append(names, s.Status)
+	}
+
+	if toIndex < 0 {
+		return "", false, errors.NewUserError(
+			fmt.Sprintf("'%s' is not a status of list %s", to, list.Name),
+			fmt.Sprintf("Valid statuses: %s", strings.Join(names, ", ")),
+			errors.ErrInvalidInput,
+		)
+	}
+
+	return list.Statuses[toIndex].Status, fromIndex > toIndex, nil
+}
+
+// taskBumper is the part of the API client used by task bump
+type taskBumper interface {
+	GetTask(ctx context.Context, taskID string) (*clickup.Task, error)
+	UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error)
+}
+
+// bumpResult is the outcome of task bump. From and To are equal when the
+// priority was already at the end it was moved towards.
+type bumpResult struct {
+	Task *clickup.Task
+	From string
+	To   string
+}
+
+// priorityLevels are the priority names from most to least urgent
+var priorityLevels = []string{"urgent", "high", "normal", "low"}
+
+// runTaskBump moves a task's priority one step up or down. The task is only
+// updated when the priority changes.
+func runTaskBump(ctx context.Context, client taskBumper, taskID, direction string) (*bumpResult, error) {
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	from := strings.ToLower(getTaskPriority(*task))
+	to := bumpPriority(from, direction)
+	if to == from {
+		return &bumpResult{Task: task, From: from, To: to}, nil
+	}
+
+	updated, err := client.UpdateTask(ctx, taskID, &api.TaskUpdateOptions{Priority: to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return &bumpResult{Task: updated, From: from, To: to}, nil
+}
+
+// bumpPriority returns the priority one step up (more urgent) or down from
+// priority, clamped at urgent and low. Unknown priorities count as normal.
+func bumpPriority(priority, direction string) string {
+	level, ok := api.ParsePriority(priority)
+	if !ok {
+		level = 3
+	}
+
+	i := level - 1
+	if direction == "up" {
+		i = max(i-1, 0)
+	} else {
+		i = min(i+1, len(priorityLevels)-1)
+	}
+	return priorityLevels[i]
+}
+
+// statusTypeNames are the names of ClickUp status types used in messages
+var statusTypeNames = map[string]string{
+	"open":   "open",
+	"custom": "in progress",
+	"done":   "done",
+	"closed": "closed",
+}
+
+// resolveStatusByType returns the list's first status of statusType, in
+// workflow order. Lists without a done status use their closed status.
+func resolveStatusByType(list *clickup.List, statusType string) (string, error) {
+	var names []string
+	for _, s := range list.Statuses {
+		if strings.EqualFold(s.Type, statusType) {
+			return s.Status, nil
+		}
+		names = append(names, s.Status)
+	}
+
+	if statusType == "done" {
+		for _, s := range list.Statuses {
+			if strings.EqualFold(s.Type, "closed") {
+				return s.Status, nil
+			}
+		}
+	}
+
+	return "", errors.NewUserError(
+		fmt.Sprintf("List %s has no %s status", list.Name, statusTypeNames[statusType]),
+		fmt.Sprintf("Pass one of its statuses with --status: %s", strings.Join(names, ", ")),
+		errors.ErrInvalidInput,
+	)
+}
+
+// taskListGetter is the part of the API client used to find a task's list
+type taskListGetter interface {
+	GetTask(ctx context.Context, taskID string) (*clickup.Task, error)
+	GetList(ctx context.Context, listID string) (*clickup.List, error)
+}
+
+// getTaskList returns the task and the list it belongs to, whose statuses
+// the task can move between
+func getTaskList(ctx context.Context, client taskListGetter, taskID string) (*clickup.Task, *clickup.List, error) {
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	list, err := client.GetList(ctx, task.List.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get list: %w", err)
+	}
+	return task, list, nil
+}
+
+// resolveTaskStatusByType returns the status of statusType in the list the
+// task belongs to
+func resolveTaskStatusByType(ctx context.Context, client taskListGetter, taskID, statusType string) (string, error) {
+	_, list, err := getTaskList(ctx, client, taskID)
+	if err != nil {
+		return "", err
+	}
+	return resolveStatusByType(list, statusType)
+}
+
+// resolveReopenStatus returns the list status to reopen a task into. An
+// empty status picks the list's first open status. A given status must
+// name one of the list's statuses that isn't closed or done; it is returned
+// with the list's spelling. Lists without statuses fall back to "open".
+func resolveReopenStatus(list *clickup.List, status string) (string, error) {
+	if len(list.Statuses) == 0 {
+		if status == "" {
+			return "open", nil
+		}
+		return status, nil
+	}
+
+	var open []string
+	for _, s := range list.Statuses {
+		statusType := strings.ToLower(s.Type)
+		if statusType == "closed" || statusType == "done" {
+			continue
+		}
+		if status == "" && statusType == "open" {
+			return s.Status, nil
+		}
+		if status != "" && strings.EqualFold(s.Status, status) {
+			return s.Status, nil
+		}
+		open = append(open, s.Status)
+	}
+
+	if status == "" {
+		if len(open) > 0 {
+			return open[0], nil
+		}
+		return "", errors.NewUserError(
+			fmt.Sprintf("List %s has no open statuses", list.Name),
+			"Pass a status with --status",
+			errors.ErrInvalidInput,
+		)
+	}
+
+	return "", errors.NewUserError(
+		fmt.Sprintf("'%s' is not an open status of list %s", status, list.Name),
+		fmt.Sprintf("Valid statuses: %s", strings.Join(open, ", ")),
+		errors.ErrInvalidInput,
+	)
+}
+
+// filterOpenTasks removes tasks in closed or done statuses
+func filterOpenTasks(tasks []clickup.Task) []clickup.Task {
+	filtered := make([]clickup.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !isClosedTask(task) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// filterUnassignedTasks keeps the tasks that have no assignees
+func filterUnassignedTasks(tasks []clickup.Task) []clickup.Task {
+	filtered := make([]clickup.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if len(task.Assignees) == 0 {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// printTaskTable renders tasks as the task list table, with an extra column
+// for each requested custom field
+func printTaskTable(w io.Writer, tasks []clickup.Task, fields []string) error {
+	headers, rows := taskTable(tasks, fields)
+	formatter := &output.TableFormatter{
+		Writer:       w,
+		NoHeader:     !output.TableHeaderEnabled(),
+		ShowEmpty:    true,
+		EmptyMessage: "No tasks found",
+		ColorEnabled: output.ColorEnabled(),
+		ColumnColors: taskColumnColors(tasks),
+	}
+	return formatter.FormatRows(headers, rows)
+}
+
+// taskRow is a task as shown in CSV output
+type taskRow struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee"`
+	Priority string `json:"priority"`
+	Due      string `json:"due"`
+}
+
+func newTaskRow(task clickup.Task) taskRow {
+	return taskRow{
+		ID:       task.ID,
+		Name:     task.Name,
+		Status:   getTaskStatus(task),
+		Assignee: getTaskAssignee(task),
+		Priority: getTaskPriority(task),
+		Due:      getTaskDueDate(task),
+	}
+}
+
+// printTaskCSV writes tasks as CSV with the task table's columns and one more
+// column per requested custom field. Names are not truncated.
+func printTaskCSV(w io.Writer, tasks []clickup.Task, fields []string) error {
+	if len(fields) > 0 {
+		headers, rows := taskTable(tasks, fields)
+		records := [][]string{headers}
+		for i, row := range rows {
+			row[1] = tasks[i].Name
+			records = append(records, row)
+		}
+		return output.FormatTo(w, "csv", records)
+	}
+
+	rows := make([]taskRow, 0, len(tasks))
+	for _, task := range tasks {
+		rows = append(rows, newTaskRow(task))
+	}
+	return output.FormatTo(w, "csv", rows)
+}
+
+// taskGroup is a set of tasks sharing a status, assignee or priority
+type taskGroup struct {
+	Name  string
+	Tasks []clickup.Task
+}
+
+// isTaskGroupField reports whether tasks can be grouped by field
+func isTaskGroupField(field string) bool {
+	switch field {
+	case "status", "assignee", "priority":
+		return true
+	}
+	return false
+}
+
+// groupTasks splits tasks into groups by status, assignee or priority,
+// keeping the existing order of tasks within each group. Statuses follow
+// their workflow order, priorities go from urgent to none and assignees are
+// alphabetical with unassigned tasks last.
+func groupTasks(tasks []clickup.Task, groupBy string) []taskGroup {
+	var groups []taskGroup
+	index := make(map[string]int)
+	keys := make(map[string]int)
+
+	for _, task := range tasks {
+		var name string
+		var key int
+		switch groupBy {
+		case "status":
+			name = getTaskStatus(task)
+			key, _ = strconv.Atoi(task.Status.Orderindex.String())
+		case "priority":
+			name = task.Priority.Priority
+			if name == "" {
+				name = "No priority"
+			}
+			key = getTaskPriorityRank(task)
+		case "assignee":
+			name = getTaskAssignee(task)
+			if name == "" {
+				name = "Unassigned"
+				key = 1
+			}
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			keys[name] = key
+			groups = append(groups, taskGroup{Name: name})
+		}
+		keys[name] = min(keys[name], key)
+		groups[i].Tasks = append(groups[i].Tasks, task)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Name, groups[j].Name
+		if keys[a] != keys[b] {
+			return keys[a] < keys[b]
+		}
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+
+	return groups
+}
+
+// taskSummary describes how many tasks there are in each status, in
+// workflow order, e.g. "3 tasks: 2 to do, 1 done"
+func taskSummary(tasks []clickup.Task) string {
+	noun := "tasks"
+	if len(tasks) == 1 {
+		noun = "task"
+	}
+	summary := fmt.Sprintf("%d %s", len(tasks), noun)
+
+	groups := groupTasks(tasks, "status")
+	if len(groups) == 0 {
+		return summary
+	}
+
+	counts := make([]string, 0, len(groups))
+	for _, group := range groups {
+		counts = append(counts, fmt.Sprintf("%d %s", len(group.Tasks), group.Name))
+	}
+	return summary + ": " + strings.Join(counts, ", ")
+}
+
+// printTaskFooter writes the lines below the task table: the summary
+// unless quiet, and the --totals footer when totals is set. --quiet only
+// hides the summary, so -q --totals still prints the totals.
+func printTaskFooter(w io.Writer, tasks []clickup.Task, quiet, totals bool) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	var lines []string
+	if !quiet {
+		lines = append(lines, taskSummary(tasks))
+	}
+	if totals {
+		if footer := taskTotals(tasks); footer != "" {
+			lines = append(lines, footer)
+		}
+	}
+	if len(lines) > 0 {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(lines, "\n"))
+	}
+}
+
+// taskTotals sums the time estimates and tracked time of tasks for the
+// --totals footer. It is empty when no task has an estimate or tracked time.
+func taskTotals(tasks []clickup.Task) string {
+	var estimated, tracked int64
+	estimatedTasks := 0
+	for _, task := range tasks {
+		if task.TimeEstimate > 0 {
+			estimated += task.TimeEstimate
+			estimatedTasks++
+		}
+		tracked += max(task.TimeSpent, 0)
+	}
+	if estimatedTasks == 0 && tracked == 0 {
+		return ""
+	}
 
-			if nameMatch || descMatch {
-				matchedTasks = append(matchedTasks, task)
-			}
+	return fmt.Sprintf("Estimated: %s (%d of %d tasks), tracked: %s",
+		formatTimeTotal(time.Duration(estimated)*time.Millisecond), estimatedTasks, len(tasks),
+		formatTimeTotal(time.Duration(tracked)*time.Millisecond))
+}
+
+// formatTimeTotal formats d in hours and minutes, e.g. "12h 30m"
+func formatTimeTotal(d time.Duration) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	hours := minutes / 60
+	minutes %= 60
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+}
+
+// printTaskGroups renders each group as a subheader followed by its table
+func printTaskGroups(w io.Writer, groups []taskGroup, fields []string) error {
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%d)\n", group.Name, len(group.Tasks))
+		if err := printTaskTable(w, group.Tasks, fields); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		// Apply limit
-		if limit > 0 && len(matchedTasks) > limit {
-			matchedTasks = matchedTasks[:limit]
+// taskTableColumns are the standard task list columns
+var taskTableColumns = []string{"id", "name", "status", "assignee", "priority", "due"}
+
+// taskTable returns the headers and rows of a task table, with an extra
+// column for each requested custom field. Long names are truncated on a
+// terminal.
+func taskTable(tasks []clickup.Task, fields []string) ([]string, [][]string) {
+	headers := append(append([]string{}, taskTableColumns...), fields...)
+
+	rows := make([][]string, 0, len(tasks))
+	for _, task := range tasks {
+		row := []string{
+			task.ID,
+			tableName(task.Name),
+			getTaskStatus(task),
+			getTaskAssignee(task),
+			getTaskPriority(task),
+			getTaskDueDate(task),
 		}
+		for _, field := range fields {
+			row = append(row, getCustomFieldValue(task, field))
+		}
+		rows = append(rows, row)
+	}
 
-		// Format output
-		format := cmd.Flag("output").Value.String()
+	return headers, rows
+}
 
-		if format == "table" {
-			if len(matchedTasks) == 0 {
-				fmt.Printf("No tasks found matching '%s'\n", strings.Join(args, " "))
-				return
-			}
+// priorityColors are ClickUp's colors for each priority, used when a task
+// does not carry its own
+var priorityColors = map[string]string{
+	"urgent": "#f50000",
+	"high":   "#ffcc00",
+	"normal": "#6fddff",
+	"low":    "#d8d8d8",
+}
 
-			fmt.Printf("Found %d task(s) matching '%s':\n\n", len(matchedTasks), strings.Join(args, " "))
+// taskColumnColors maps the status and priority cells of a task table to
+// their ClickUp colors
+func taskColumnColors(tasks []clickup.Task) map[string]map[string]string {
+	statuses := make(map[string]string)
+	priorities := make(map[string]string)
 
-			// Prepare table data
-			type taskRow struct {
-				ID       string `json:"id"`
-				Name     string `json:"name"`
-				Status   string `json:"status"`
-				Assignee string `json:"assignee"`
-				Priority string `json:"priority"`
-				Due      string `json:"due"`
-			}
-
-			var rows []taskRow
-			for _, task := range matchedTasks {
-				row := taskRow{
-					ID:       task.ID,
-					Name:     truncate(task.Name, 50),
-					Status:   getTaskStatus(task),
-					Assignee: getTaskAssignee(task),
-					Priority: getTaskPriority(task),
-					Due:      getTaskDueDate(task),
-				}
-				rows = append(rows, row)
+	for _, task := range tasks {
+		if task.Status.Color != "" {
+			statuses[getTaskStatus(task)] = task.Status.Color
+		}
+		if task.Priority.Priority != "" {
+			priorityColor := task.Priority.Color
+			if priorityColor == "" {
+				priorityColor = priorityColors[strings.ToLower(task.Priority.Priority)]
 			}
+			priorities[getTaskPriority(task)] = priorityColor
+		}
+	}
 
-			if err := output.Format(format, rows); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+	return map[string]map[string]string{
+		"status":   statuses,
+		"priority": priorities,
+	}
+}
+
+// getCustomFieldValue returns the display value of a task's custom field,
+// matched by ID or case-insensitive name. Unset fields are empty.
+func getCustomFieldValue(task clickup.Task, nameOrID string) string {
+	for _, field := range task.CustomFields {
+		if field.ID != nameOrID && !strings.EqualFold(field.Name, nameOrID) {
+			continue
+		}
+
+		switch value := field.Value.(type) {
+		case nil:
+			return ""
+		case string:
+			return value
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(value)
+		default:
+			data, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Sprint(value)
 			}
-		} else {
-			// For other formats, output raw task data
-			if err := output.Format(format, matchedTasks); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+			return string(data)
+		}
+	}
+	return ""
+}
+
+// Task view modes
+const (
+	taskViewHuman = "human"
+	taskViewRaw   = "raw"
+	taskViewWeb   = "web"
+)
+
+// selectTaskViewMode picks how task view shows a task. Human output follows
+// --output; raw and web ignore it.
+func selectTaskViewMode(web, raw bool) string {
+	switch {
+	case web:
+		return taskViewWeb
+	case raw:
+		return taskViewRaw
+	default:
+		return taskViewHuman
+	}
+}
+
+// taskWebURL returns the ClickUp web app URL of a task
+func taskWebURL(taskID string) string {
+	return "https://app.clickup.com/t/" + url.PathEscape(taskID)
+}
+
+// printRawTask writes the task as indented JSON with every field ClickUp
+// returned
+func printRawTask(w io.Writer, task *clickup.Task) error {
+	data, err := json.MarshalIndent(task, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// readDescription returns the task description, reading it from in when
+// fromStdin is set or the description is "-". Only the final line ending of
+// piped input is removed.
+func readDescription(in io.Reader, description string, fromStdin bool) (string, error) {
+	if !fromStdin && description != "-" {
+		return description, nil
+	}
+	if fromStdin && description != "" {
+		return "", fmt.Errorf("--stdin cannot be combined with --description")
+	}
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read description from stdin: %w", err)
+	}
+
+	text := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(text, "\r"), nil
+}
+
+// taskCount is the --count result in structured output formats
+type taskCount struct {
+	Count int `json:"count" yaml:"count"`
+}
+
+// printTaskCount prints the number of matching tasks, as a bare integer for
+// table output and as {"count": N} otherwise
+func printTaskCount(w io.Writer, format string, count int) error {
+	if format == "table" {
+		_, err := fmt.Fprintln(w, count)
+		return err
+	}
+	return output.FormatTo(w, format, taskCount{Count: count})
+}
+
+// printTaskPorcelain writes one line per task with the id, name, status,
+// priority and due date separated by tabs. There is no header, color or
+// truncation, and the field order must not change. Missing values are empty
+// and due dates are written as YYYY-MM-DD in local time, as in the table.
+func printTaskPorcelain(w io.Writer, tasks []clickup.Task) error {
+	for _, task := range tasks {
+		due := ""
+		if task.DueDate != nil {
+			if t := task.DueDate.Time(); t != nil {
+				due = t.Local().Format(time.DateOnly)
 			}
 		}
-	},
+
+		fields := []string{
+			task.ID,
+			porcelainField(task.Name),
+			porcelainField(task.Status.Status),
+			strings.ToLower(task.Priority.Priority),
+			due,
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func init() {
-	taskCmd.AddCommand(taskListCmd)
-	taskCmd.AddCommand(taskCreateCmd)
-	taskCmd.AddCommand(taskViewCmd)
-	taskCmd.AddCommand(taskUpdateCmd)
-	taskCmd.AddCommand(taskCloseCmd)
-	taskCmd.AddCommand(taskReopenCmd)
-	taskCmd.AddCommand(taskSearchCmd)
+// porcelainField replaces tabs and line breaks so a value stays in its column
+func porcelainField(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
 
-	// List command flags
-	taskListCmd.Flags().StringP("list", "l", "", "List ID or name")
-	taskListCmd.Flags().StringP("space", "s", "", "Space ID or name")
-	taskListCmd.Flags().StringP("folder", "f", "", "Folder ID or name")
-	taskListCmd.Flags().String("assignee", "", "Filter by assignee (username or ID)")
-	taskListCmd.Flags().String("status", "", "Filter by status")
-	taskListCmd.Flags().String("tag", "", "Filter by tag")
-	taskListCmd.Flags().String("priority", "", "Filter by priority")
-	taskListCmd.Flags().String("due", "", "Filter by due date (today, tomorrow, week, overdue)")
-	taskListCmd.Flags().Int("limit", 30, "Maximum number of tasks to return")
-	taskListCmd.Flags().Int("page", 0, "Page number for pagination")
-	taskListCmd.Flags().String("sort", "", "Sort by field (created, updated, due, priority)")
-	taskListCmd.Flags().String("order", "asc", "Sort order (asc, desc)")
+// taskPageFetcher is the part of the API client used to fetch a page of tasks
+type taskPageFetcher interface {
+	GetTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error)
+}
 
-	// Create command flags
-	taskCreateCmd.Flags().StringP("name", "n", "", "Task name (alternative to providing as argument)")
-	taskCreateCmd.Flags().StringP("list", "l", "", "List ID to create task in")
-	taskCreateCmd.Flags().StringP("description", "d", "", "Task description")
-	taskCreateCmd.Flags().StringSliceP("assignee", "a", []string{}, "Assignees (username or ID)")
-	taskCreateCmd.Flags().StringP("status", "s", "", "Task status")
-	taskCreateCmd.Flags().StringP("priority", "p", "", "Task priority (urgent, high, normal, low)")
-	taskCreateCmd.Flags().String("due", "", "Due date (ISO format or 'today', 'tomorrow')")
-	taskCreateCmd.Flags().StringSlice("tag", []string{}, "Tags to add to the task")
+// fetchTaskPage gets one page of tasks from each list. hasMore reports
+// whether any list returned a full page, so a later page may have more.
+func fetchTaskPage(ctx context.Context, client taskPageFetcher, listIDs []string, queryOpts *api.TaskQueryOptions) ([]clickup.Task, bool, error) {
+	var tasks []clickup.Task
+	hasMore := false
+	for _, id := range listIDs {
+		listTasks, err := client.GetTasks(ctx, id, queryOpts)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(listTasks) >= api.TasksPageSize {
+			hasMore = true
+		}
+		tasks = append(tasks, listTasks...)
+	}
+	return tasks, hasMore, nil
+}
 
-	// Update command flags
-	taskUpdateCmd.Flags().StringP("name", "n", "", "New task name")
-	taskUpdateCmd.Flags().StringP("description", "d", "", "New task description")
-	taskUpdateCmd.Flags().StringP("status", "s", "", "New task status")
-	taskUpdateCmd.Flags().StringP("priority", "p", "", "New task priority (urgent, high, normal, low)")
-	taskUpdateCmd.Flags().String("due", "", "New due date (ISO format or 'today', 'tomorrow')")
-	taskUpdateCmd.Flags().StringSlice("tag", []string{}, "Replace tags with these tags")
-	taskUpdateCmd.Flags().StringSlice("add-assignee", []string{}, "Add assignees (username or ID)")
-	taskUpdateCmd.Flags().StringSlice("remove-assignee", []string{}, "Remove assignees (username or ID)")
+// taskListCache stores task list results. *cache.Cache implements it.
+type taskListCache interface {
+	Get(key string, dest interface{}) error
+	Set(key string, value interface{}) error
+	TTL() time.Duration
+}
 
-	// Reopen command flags
-	taskReopenCmd.Flags().StringP("status", "s", "", "Status to set when reopening (default: open)")
+// taskListCacheKey is the task cache key holding an account's cached
+// results of a list, so invalidating the list removes every query at once
+func taskListCacheKey(scope, listID string) string {
+	return fmt.Sprintf("task_list_%s_%s", scope, listID)
+}
 
-	// Search command flags
-	taskSearchCmd.Flags().StringP("space", "s", "", "Limit search to specific space")
-	taskSearchCmd.Flags().StringP("list", "l", "", "Limit search to specific list")
-	taskSearchCmd.Flags().Bool("include-description", false, "Search in task descriptions as well as names")
-	taskSearchCmd.Flags().Int("limit", 50, "Maximum number of results to return")
+// cachedTaskPage is one cached GetTasks result
+type cachedTaskPage struct {
+	Tasks     []clickup.Task `json:"tasks"`
+	FetchedAt time.Time      `json:"fetched_at"`
 }
 
-// Helper functions
+// cachedTaskFetcher serves GetTasks from the task cache while a result for
+// the same list and query is younger than the cache TTL. scope is the
+// account's cache scope.
+type cachedTaskFetcher struct {
+	fetcher taskPageFetcher
+	cache   taskListCache
+	scope   string
+}
+
+func (f *cachedTaskFetcher) GetTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error) {
+	query, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	key := taskListCacheKey(f.scope, listID)
+	pages := map[string]cachedTaskPage{}
+	if err := f.cache.Get(key, &pages); err != nil {
+		pages = map[string]cachedTaskPage{}
+	}
+	if page, ok := pages[string(query)]; ok && clock.Now().Sub(page.FetchedAt) < f.cache.TTL() {
+		return page.Tasks, nil
+	}
+
+	tasks, err := f.fetcher.GetTasks(ctx, listID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every Set renews the entry, so expired queries are dropped here to
+	// keep it from growing
+	for q, page := range pages {
+		if clock.Now().Sub(page.FetchedAt) >= f.cache.TTL() {
+			delete(pages, q)
+		}
+	}
+	pages[string(query)] = cachedTaskPage{Tasks: tasks, FetchedAt: clock.Now()}
+	// A failed write only costs a later refetch
+	_ = f.cache.Set(key, pages)
+	return tasks, nil
+}
+
+// fetchAllTaskPages gets every page of tasks from each list, starting at
+// queryOpts.Page
+func fetchAllTaskPages(ctx context.Context, client taskPageFetcher, listIDs []string, queryOpts *api.TaskQueryOptions) ([]clickup.Task, error) {
+	var tasks []clickup.Task
+	for _, id := range listIDs {
+		listTasks, err := api.GetAllTaskPages(ctx, client, id, queryOpts)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, listTasks...)
+	}
+	return tasks, nil
+}
+
+// filterUpdatedSince returns the tasks updated after since
+func filterUpdatedSince(tasks []clickup.Task, since time.Time) []clickup.Task {
+	var filtered []clickup.Task
+	for _, task := range tasks {
+		if api.TaskUpdatedAt(task).After(since) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// parseUpdatedSince parses --updated-since: an RFC 3339 time, a YYYY-MM-DD
+// day starting at local midnight, or a duration ago such as 90m, 12h or 7d
+func parseUpdatedSince(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return clock.Now().AddDate(0, 0, -n), nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return clock.Now().Add(-d), nil
+	}
+
+	return time.Time{}, errors.NewUserError(
+		fmt.Sprintf("Invalid --updated-since '%s'", value),
+		"Use an RFC 3339 time, a YYYY-MM-DD date or a duration such as 2h or 7d",
+		errors.ErrInvalidInput,
+	)
+}
+
+// taskEnvelope wraps task list json and yaml output with paging metadata
+type taskEnvelope struct {
+	Items   []clickup.Task `json:"items" yaml:"items"`
+	Count   int            `json:"count" yaml:"count"`
+	Page    int            `json:"page" yaml:"page"`
+	HasMore bool           `json:"has_more" yaml:"has_more"`
+}
+
+func newTaskEnvelope(tasks []clickup.Task, page int, hasMore bool) taskEnvelope {
+	if tasks == nil {
+		tasks = []clickup.Task{}
+	}
+	return taskEnvelope{Items: tasks, Count: len(tasks), Page: page, HasMore: hasMore}
+}
+
+// limitTaskPage caps a page of tasks at limit, and reports whether there are
+// more tasks than returned: either hasMore from the page, or tasks the limit
+// dropped
+func limitTaskPage(tasks []clickup.Task, limit int, hasMore bool) ([]clickup.Task, bool) {
+	limited := applyLimit(tasks, limit)
+	return limited, hasMore || len(limited) < len(tasks)
+}
+
+// applyLimit caps tasks at limit. A limit of zero or less means no cap.
+func applyLimit(tasks []clickup.Task, limit int) []clickup.Task {
+	return applyOffsetLimit(tasks, 0, limit)
+}
+
+// applyOffsetLimit skips the first offset items and keeps at most limit of
+// the rest. A limit of 0 or less keeps them all.
+func applyOffsetLimit[T any](items []T, offset, limit int) []T {
+	if offset > 0 {
+		items = items[min(offset, len(items)):]
+	}
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// filterDueWithin keeps tasks due between the start of today and the end of
+// the day days from now, so tasks due earlier today are kept. Tasks without a
+// due date are dropped.
+func filterDueWithin(tasks []clickup.Task, now time.Time, days int) []clickup.Task {
+	y, m, d := now.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	end := time.Date(y, m, d+days+1, 0, 0, 0, 0, now.Location())
+
+	filtered := make([]clickup.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		due := task.DueDate.Time()
+		if due == nil || due.Before(start) || !due.Before(end) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+	return filtered
+}
+
+// filterOverdueTasks keeps the open tasks whose due date is before now
+func filterOverdueTasks(tasks []clickup.Task, now time.Time) []clickup.Task {
+	filtered := make([]clickup.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.DueDate == nil || isClosedTask(task) {
+			continue
+		}
+		if due := task.DueDate.Time(); due != nil && due.Before(now) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// fetchesAllPages reports whether task list reads every page of tasks: with
+// --all, or with --parent since a parent's subtasks can be on any page,
+// unless --page asks for one
+func fetchesAllPages(all bool, parentID string, pageGiven bool) bool {
+	return all || (parentID != "" && !pageGiven)
+}
+
+// filterSubtasks keeps only the direct children of parentID
+func filterSubtasks(tasks []clickup.Task, parentID string) []clickup.Task {
+	filtered := make([]clickup.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Parent == parentID {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// stdoutIsTerminal reports whether stdout is a terminal. Tests replace it.
+var stdoutIsTerminal = output.StdoutIsTerminal
+
+// tableName returns a task or list name as shown in a table. Names are cut
+// to 50 characters on a terminal and kept whole when output is piped, so
+// scripts get the full name.
+func tableName(name string) string {
+	if !stdoutIsTerminal() {
+		return name
+	}
+	return truncate(name, 50)
+}
 
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -750,14 +2691,14 @@ func getTaskDueDate(task clickup.Task) string {
 	// DueDate is a *Date type, convert to string
 	t := task.DueDate.Time()
 	if t != nil {
-		return formatRelativeTime(*t)
+		return output.FormatTime(*t, formatRelativeTime)
 	}
 	return ""
 }
 
 // formatRelativeTime formats a time as relative to now
 func formatRelativeTime(t time.Time) string {
-	now := time.Now()
+	now := clock.Now()
 	diff := t.Sub(now)
 
 	if diff < 0 {
@@ -799,7 +2740,6 @@ func filterTasks(tasks []clickup.Task, priority, due string) []clickup.Task {
 	}
 
 	filtered := make([]clickup.Task, 0, len(tasks))
-	now := time.Now()
 
 	for _, task := range tasks {
 		// Filter by priority
@@ -811,33 +2751,8 @@ func filterTasks(tasks []clickup.Task, priority, due string) []clickup.Task {
 			}
 		}
 
-		// Filter by due date
-		if due != "" && task.DueDate != nil {
-			dueTime := task.DueDate.Time()
-			if dueTime == nil {
-				continue
-			}
-
-			switch due {
-			case "today":
-				if !isToday(*dueTime) {
-					continue
-				}
-			case "tomorrow":
-				if !isTomorrow(*dueTime) {
-					continue
-				}
-			case "week":
-				if !isThisWeek(*dueTime) {
-					continue
-				}
-			case "overdue":
-				if !dueTime.Before(now) {
-					continue
-				}
-			}
-		} else if due != "" && task.DueDate == nil {
-			// Skip tasks without due dates if filtering by due date
+		// Filter by due date, skipping tasks without one
+		if due != "" && !matchesDueFilter(task, due) {
 			continue
 		}
 
@@ -847,84 +2762,152 @@ func filterTasks(tasks []clickup.Task, priority, due string) []clickup.Task {
 	return filtered
 }
 
-// sortTasks sorts tasks by the specified field and order
-func sortTasks(tasks []clickup.Task, sortBy, order string) {
+// matchesDueFilter reports whether a task's due date matches a --due filter
+// (today, tomorrow, week or overdue). Tasks without a due date never match.
+func matchesDueFilter(task clickup.Task, due string) bool {
+	if !hasDueDate(task) {
+		return false
+	}
+	dueTime := *task.DueDate.Time()
+
+	switch due {
+	case "today":
+		return isToday(dueTime)
+	case "tomorrow":
+		return isTomorrow(dueTime)
+	case "week":
+		return isThisWeek(dueTime)
+	case "overdue":
+		return dueTime.Before(clock.Now())
+	default:
+		return true
+	}
+}
+
+// normalizeSortOrder returns order as asc or desc, ignoring case. Other
+// values are an error.
+func normalizeSortOrder(order string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(order))
+	if normalized != "asc" && normalized != "desc" {
+		return "", errors.NewUserError(
+			fmt.Sprintf("Invalid --order '%s'", order),
+			"Use asc or desc",
+			errors.ErrInvalidInput,
+		)
+	}
+	return normalized, nil
+}
+
+// sortTasks sorts tasks by the specified field and order. Tasks that compare
+// equal are ordered by ID so the output is deterministic. When sorting by due
+// date, nulls ("first" or "last") places undated tasks regardless of order.
+func sortTasks(tasks []clickup.Task, sortBy, order, nulls string) {
 	if sortBy == "" {
 		return
 	}
 
-	sort.Slice(tasks, func(i, j int) bool {
-		var less bool
-
-		switch sortBy {
-		case "created":
-			less = tasks[i].DateCreated < tasks[j].DateCreated
-		case "updated":
-			less = tasks[i].DateUpdated < tasks[j].DateUpdated
-		case "due":
-			// Tasks without due dates go to the end
-			if tasks[i].DueDate == nil && tasks[j].DueDate == nil {
-				less = false
-			} else if tasks[i].DueDate == nil {
-				less = false
-			} else if tasks[j].DueDate == nil {
-				less = true
-			} else {
-				iTime := tasks[i].DueDate.Time()
-				jTime := tasks[j].DueDate.Time()
-				if iTime != nil && jTime != nil {
-					less = iTime.Before(*jTime)
-				}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		// Undated tasks keep their place whatever the order
+		if sortBy == "due" {
+			iDated, jDated := hasDueDate(tasks[i]), hasDueDate(tasks[j])
+			if iDated != jDated {
+				return iDated == (nulls != "first")
 			}
-		case "priority":
-			// Convert priority to number for comparison (lower number = higher priority)
-			iPriority := getPriorityValue(getTaskPriority(tasks[i]))
-			jPriority := getPriorityValue(getTaskPriority(tasks[j]))
-			less = iPriority < jPriority
-		default:
-			// Default to sorting by name
-			less = tasks[i].Name < tasks[j].Name
 		}
-
+		c := compareTasks(tasks[i], tasks[j], sortBy)
 		if order == "desc" {
-			return !less
+			c = -c
+		}
+		if c == 0 {
+			return tasks[i].ID < tasks[j].ID
 		}
-		return less
+		return c < 0
 	})
 }
 
+func hasDueDate(task clickup.Task) bool {
+	return task.DueDate != nil && task.DueDate.Time() != nil
+}
+
+// compareTasks compares two tasks on a single sort field
+func compareTasks(a, b clickup.Task, sortBy string) int {
+	switch sortBy {
+	case "created":
+		return strings.Compare(a.DateCreated, b.DateCreated)
+	case "updated":
+		return strings.Compare(a.DateUpdated, b.DateUpdated)
+	case "due":
+		var aTime, bTime *time.Time
+		if a.DueDate != nil {
+			aTime = a.DueDate.Time()
+		}
+		if b.DueDate != nil {
+			bTime = b.DueDate.Time()
+		}
+		// Tasks without due dates go to the end
+		switch {
+		case aTime == nil && bTime == nil:
+			return 0
+		case aTime == nil:
+			return 1
+		case bTime == nil:
+			return -1
+		}
+		return aTime.Compare(*bTime)
+	case "priority":
+		// Lower number = higher priority
+		return getTaskPriorityRank(a) - getTaskPriorityRank(b)
+	case "assignee":
+		// Unassigned tasks go to the end
+		aName, bName := strings.ToLower(getTaskAssignee(a)), strings.ToLower(getTaskAssignee(b))
+		switch {
+		case aName == bName:
+			return 0
+		case aName == "":
+			return 1
+		case bName == "":
+			return -1
+		}
+		return strings.Compare(aName, bName)
+	default:
+		// Default to sorting by name
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+}
+
 // Helper functions for date filtering
 func isToday(t time.Time) bool {
-	now := time.Now()
+	now := clock.Now()
 	y1, m1, d1 := now.Date()
 	y2, m2, d2 := t.Date()
 	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
 func isTomorrow(t time.Time) bool {
-	tomorrow := time.Now().AddDate(0, 0, 1)
+	tomorrow := clock.Now().AddDate(0, 0, 1)
 	y1, m1, d1 := tomorrow.Date()
 	y2, m2, d2 := t.Date()
 	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
 func isThisWeek(t time.Time) bool {
-	now := time.Now()
+	now := clock.Now()
 	weekFromNow := now.AddDate(0, 0, 7)
 	return t.After(now) && t.Before(weekFromNow)
 }
 
 func getPriorityValue(priority string) int {
-	switch strings.ToLower(priority) {
-	case "urgent":
-		return 1
-	case "high":
-		return 2
-	case "normal":
-		return 3
-	case "low":
-		return 4
-	default:
-		return 3 // Default to normal
+	return api.PriorityValue(priority)
+}
+
+// noPriorityRank places tasks without a priority after low priority tasks
+const noPriorityRank = 5
+
+// getTaskPriorityRank returns the sort rank of a task's priority. Tasks with
+// no priority are displayed as Normal but rank after every set priority.
+func getTaskPriorityRank(task clickup.Task) int {
+	if task.Priority.Priority == "" {
+		return noPriorityRank
 	}
+	return getPriorityValue(task.Priority.Priority)
 }
@@ -1,13 +1,29 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/api"
+	"github.com/timimsms/cu/internal/clock"
+	"github.com/timimsms/cu/internal/config"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
 )
 
 func TestTaskCommands_Structure(t *testing.T) {
@@ -331,6 +347,42 @@ func TestFilterTasks(t *testing.T) {
 	})
 }
 
+func TestDueFiltersAcrossMidnight(t *testing.T) {
+	// Thirty seconds before midnight: a task due in a minute is due tomorrow
+	now := time.Date(2026, 3, 14, 23, 59, 30, 0, time.Local)
+	defer clock.Set(clock.Fixed(now))()
+
+	due := func(id string, t time.Time) clickup.Task {
+		return clickup.Task{ID: id, DueDate: clickup.NewDate(t)}
+	}
+	tasks := []clickup.Task{
+		due("earlier-today", now.Add(-time.Hour)),
+		due("end-of-today", time.Date(2026, 3, 14, 23, 59, 59, 0, time.Local)),
+		due("after-midnight", now.Add(time.Minute)),
+		due("in-six-days", now.AddDate(0, 0, 6)),
+		{ID: "undated"},
+	}
+
+	ids := func(tasks []clickup.Task) []string {
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"earlier-today", "end-of-today"}, ids(filterTasks(tasks, "", "today")))
+	assert.Equal(t, []string{"after-midnight"}, ids(filterTasks(tasks, "", "tomorrow")))
+	assert.Equal(t, []string{"end-of-today", "after-midnight", "in-six-days"}, ids(filterTasks(tasks, "", "week")))
+	assert.Equal(t, []string{"earlier-today"}, ids(filterTasks(tasks, "", "overdue")))
+	assert.False(t, matchesDueFilter(tasks[4], "today"))
+
+	assert.Equal(t, "in 1 minutes", formatRelativeTime(now.Add(time.Minute)))
+	assert.Equal(t, "1 hours ago", formatRelativeTime(now.Add(-time.Hour)))
+	assert.Equal(t, "tomorrow", formatRelativeTime(now.Add(25*time.Hour)))
+	assert.Equal(t, "Mar 24, 2026", formatRelativeTime(now.AddDate(0, 0, 10)))
+}
+
 func TestIsToday(t *testing.T) {
 	t.Run("function signature is correct", func(t *testing.T) {
 		var fn func(time.Time) bool = isToday
@@ -338,7 +390,8 @@ func TestIsToday(t *testing.T) {
 	})
 
 	t.Run("identifies today correctly", func(t *testing.T) {
-		now := time.Now()
+		now := time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local)
+		defer clock.Set(clock.Fixed(now))()
 
 		tests := []struct {
 			name     string
@@ -368,7 +421,8 @@ func TestIsTomorrow(t *testing.T) {
 	})
 
 	t.Run("identifies tomorrow correctly", func(t *testing.T) {
-		now := time.Now()
+		now := time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local)
+		defer clock.Set(clock.Fixed(now))()
 		tomorrow := now.Add(24 * time.Hour)
 
 		tests := []struct {
@@ -399,7 +453,8 @@ func TestIsThisWeek(t *testing.T) {
 	})
 
 	t.Run("identifies this week correctly", func(t *testing.T) {
-		now := time.Now()
+		now := time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local)
+		defer clock.Set(clock.Fixed(now))()
 
 		tests := []struct {
 			name     string
@@ -454,3 +509,1722 @@ func TestGetPriorityValue(t *testing.T) {
 		}
 	})
 }
+
+func TestSortTasks(t *testing.T) {
+	ids := func(tasks []clickup.Task) []string {
+		var out []string
+		for _, task := range tasks {
+			out = append(out, task.ID)
+		}
+		return out
+	}
+	assignee := func(name string) []clickup.User {
+		return []clickup.User{{Username: name}}
+	}
+
+	t.Run("assignee sorts by first assignee with unassigned last", func(t *testing.T) {
+		tasks := []clickup.Task{
+			{ID: "1"},
+			{ID: "2", Assignees: assignee("zoe")},
+			{ID: "3", Assignees: assignee("Adam")},
+			{ID: "4", Assignees: assignee("mia")},
+		}
+
+		sortTasks(tasks, "assignee", "asc", "last")
+		assert.Equal(t, []string{"3", "4", "2", "1"}, ids(tasks))
+	})
+
+	t.Run("no priority sorts after low", func(t *testing.T) {
+		tasks := []clickup.Task{
+			{ID: "1"},
+			{ID: "2", Priority: clickup.TaskPriority{Priority: "low"}},
+			{ID: "3", Priority: clickup.TaskPriority{Priority: "urgent"}},
+			{ID: "4", Priority: clickup.TaskPriority{Priority: "normal"}},
+		}
+
+		sortTasks(tasks, "priority", "asc", "last")
+		assert.Equal(t, []string{"3", "4", "2", "1"}, ids(tasks))
+
+		sortTasks(tasks, "priority", "desc", "last")
+		assert.Equal(t, []string{"1", "2", "4", "3"}, ids(tasks))
+	})
+
+	t.Run("ties are broken by ID", func(t *testing.T) {
+		tasks := []clickup.Task{
+			{ID: "c", Name: "same"},
+			{ID: "a", Name: "Same"},
+			{ID: "b", Name: "same"},
+		}
+
+		sortTasks(tasks, "name", "asc", "last")
+		assert.Equal(t, []string{"a", "b", "c"}, ids(tasks))
+
+		sortTasks(tasks, "name", "desc", "last")
+		assert.Equal(t, []string{"a", "b", "c"}, ids(tasks))
+	})
+
+	t.Run("empty sort keeps order", func(t *testing.T) {
+		tasks := []clickup.Task{{ID: "2"}, {ID: "1"}}
+
+		sortTasks(tasks, "", "asc", "last")
+		assert.Equal(t, []string{"2", "1"}, ids(tasks))
+	})
+
+	t.Run("nulls places undated tasks regardless of order", func(t *testing.T) {
+		due := func(day int) *clickup.Date {
+			return clickup.NewDate(time.Date(2026, 3, day, 12, 0, 0, 0, time.UTC))
+		}
+		tasks := []clickup.Task{
+			{ID: "u1"},
+			{ID: "d12", DueDate: due(12)},
+			{ID: "u2"},
+			{ID: "d10", DueDate: due(10)},
+			{ID: "d11", DueDate: due(11)},
+		}
+
+		sortTasks(tasks, "due", "asc", "last")
+		assert.Equal(t, []string{"d10", "d11", "d12", "u1", "u2"}, ids(tasks))
+
+		sortTasks(tasks, "due", "desc", "last")
+		assert.Equal(t, []string{"d12", "d11", "d10", "u1", "u2"}, ids(tasks))
+
+		sortTasks(tasks, "due", "asc", "first")
+		assert.Equal(t, []string{"u1", "u2", "d10", "d11", "d12"}, ids(tasks))
+
+		sortTasks(tasks, "due", "desc", "first")
+		assert.Equal(t, []string{"u1", "u2", "d12", "d11", "d10"}, ids(tasks))
+	})
+
+	t.Run("nulls flag defaults to last", func(t *testing.T) {
+		flag := taskListCmd.Flags().Lookup("nulls")
+		require.NotNil(t, flag)
+		assert.Equal(t, "last", flag.DefValue)
+	})
+}
+
+func TestNormalizeSortOrder(t *testing.T) {
+	for _, order := range []string{"asc", "ASC", " Desc "} {
+		normalized, err := normalizeSortOrder(order)
+		require.NoError(t, err)
+		assert.Equal(t, strings.ToLower(strings.TrimSpace(order)), normalized)
+	}
+
+	_, err := normalizeSortOrder("descending")
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	assert.Equal(t, "Invalid --order 'descending'\n\nSuggestion: Use asc or desc", err.Error())
+}
+
+func TestGetTaskPriorityRank(t *testing.T) {
+	assert.Equal(t, 1, getTaskPriorityRank(clickup.Task{Priority: clickup.TaskPriority{Priority: "urgent"}}))
+	assert.Equal(t, 4, getTaskPriorityRank(clickup.Task{Priority: clickup.TaskPriority{Priority: "low"}}))
+	assert.Equal(t, noPriorityRank, getTaskPriorityRank(clickup.Task{}))
+}
+
+func TestNotifyOption(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		addNotifyFlags(cmd)
+		return cmd
+	}
+
+	t.Run("unset keeps default", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.ParseFlags(nil))
+		assert.Nil(t, notifyOption(cmd))
+	})
+
+	t.Run("notify", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"--notify"}))
+		require.NotNil(t, notifyOption(cmd))
+		assert.True(t, *notifyOption(cmd))
+	})
+
+	t.Run("notify false keeps default", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"--notify=false"}))
+		assert.Nil(t, notifyOption(cmd))
+	})
+
+	t.Run("registered on create and update", func(t *testing.T) {
+		for _, c := range []*cobra.Command{taskCreateCmd, taskUpdateCmd} {
+			assert.NotNil(t, c.Flags().Lookup("notify"), c.Name())
+		}
+	})
+}
+
+func TestGetCustomFieldValue(t *testing.T) {
+	task := clickup.Task{
+		CustomFields: []clickup.CustomField{
+			{ID: "f1", Name: "Points", Value: float64(3)},
+			{ID: "f2", Name: "Team", Value: "Platform"},
+			{ID: "f3", Name: "Billable", Value: true},
+			{ID: "f4", Name: "Unset"},
+		},
+	}
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{"Points", "3"},
+		{"points", "3"},
+		{"f2", "Platform"},
+		{"Billable", "true"},
+		{"Unset", ""},
+		{"Missing", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getCustomFieldValue(task, tt.field))
+		})
+	}
+}
+
+func TestTaskTable(t *testing.T) {
+	defer func(isTerminal func() bool) { stdoutIsTerminal = isTerminal }(stdoutIsTerminal)
+	stdoutIsTerminal = func() bool { return true }
+
+	tasks := []clickup.Task{
+		{
+			ID:           "abc",
+			Name:         "Ship it",
+			Status:       clickup.TaskStatus{Status: "open"},
+			CustomFields: []clickup.CustomField{{ID: "f1", Name: "Points", Value: float64(5)}},
+		},
+		{ID: "def", Name: strings.Repeat("x", 60)},
+	}
+
+	headers, rows := taskTable(tasks, []string{"Points"})
+	assert.Equal(t, []string{"id", "name", "status", "assignee", "priority", "due", "Points"}, headers)
+	assert.Equal(t, []string{"abc", "Ship it", "open", "", "Normal", "", "5"}, rows[0])
+	assert.Equal(t, strings.Repeat("x", 47)+"...", rows[1][1])
+
+	var buf bytes.Buffer
+	formatter := &output.TableFormatter{Writer: &buf}
+	require.NoError(t, formatter.FormatRows(headers, rows))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Regexp(t, `^id\s+name\s+status\s+assignee\s+priority\s+due\s+Points$`, lines[0])
+	assert.Regexp(t, `^abc\s+Ship it\s+open\s+Normal\s+5$`, lines[2])
+	assert.Regexp(t, `^def\s+x+\.\.\.\s+Normal\s*$`, lines[3])
+
+	stdoutIsTerminal = func() bool { return false }
+	_, rows = taskTable(tasks, nil)
+	assert.Equal(t, strings.Repeat("x", 60), rows[1][1], "piped output keeps the full name")
+}
+
+func TestTaskColumnColors(t *testing.T) {
+	tasks := []clickup.Task{
+		{
+			ID:       "1",
+			Status:   clickup.TaskStatus{Status: "in progress", Color: "#4194f6"},
+			Priority: clickup.TaskPriority{Priority: "urgent", Color: "#f50000"},
+		},
+		{
+			ID:       "2",
+			Status:   clickup.TaskStatus{Status: "open"},
+			Priority: clickup.TaskPriority{Priority: "low"},
+		},
+		{ID: "3"},
+	}
+
+	colors := taskColumnColors(tasks)
+	assert.Equal(t, map[string]string{"in progress": "#4194f6"}, colors["status"])
+	assert.Equal(t, map[string]string{"urgent": "#f50000", "low": "#d8d8d8"}, colors["priority"])
+
+	t.Run("no-color flag is registered", func(t *testing.T) {
+		assert.NotNil(t, rootCmd.PersistentFlags().Lookup("no-color"))
+	})
+}
+
+func TestGroupTasks(t *testing.T) {
+	tasks := []clickup.Task{
+		{ID: "1", Status: clickup.TaskStatus{Status: "done", Orderindex: "3"}, Priority: clickup.TaskPriority{Priority: "low"}},
+		{ID: "2", Status: clickup.TaskStatus{Status: "to do", Orderindex: "0"}, Assignees: []clickup.User{{Username: "bob"}}},
+		{ID: "3", Status: clickup.TaskStatus{Status: "in progress", Orderindex: "1"}, Priority: clickup.TaskPriority{Priority: "urgent"}, Assignees: []clickup.User{{Username: "Alice"}}},
+		{ID: "4", Status: clickup.TaskStatus{Status: "to do", Orderindex: "0"}, Priority: clickup.TaskPriority{Priority: "low"}, Assignees: []clickup.User{{Username: "bob"}}},
+	}
+
+	summarize := func(groups []taskGroup) map[string][]string {
+		ids := make(map[string][]string)
+		for _, group := range groups {
+			for _, task := range group.Tasks {
+				ids[group.Name] = append(ids[group.Name], task.ID)
+			}
+		}
+		return ids
+	}
+	names := func(groups []taskGroup) []string {
+		var result []string
+		for _, group := range groups {
+			result = append(result, group.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		groupBy string
+		names   []string
+		ids     map[string][]string
+	}{
+		{
+			groupBy: "status",
+			names:   []string{"to do", "in progress", "done"},
+			ids:     map[string][]string{"to do": {"2", "4"}, "in progress": {"3"}, "done": {"1"}},
+		},
+		{
+			groupBy: "priority",
+			names:   []string{"urgent", "low", "No priority"},
+			ids:     map[string][]string{"urgent": {"3"}, "low": {"1", "4"}, "No priority": {"2"}},
+		},
+		{
+			groupBy: "assignee",
+			names:   []string{"Alice", "bob", "Unassigned"},
+			ids:     map[string][]string{"Alice": {"3"}, "bob": {"2", "4"}, "Unassigned": {"1"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.groupBy, func(t *testing.T) {
+			groups := groupTasks(tasks, test.groupBy)
+			assert.Equal(t, test.names, names(groups))
+			assert.Equal(t, test.ids, summarize(groups))
+
+			// Order is stable across runs
+			for i := 0; i < 5; i++ {
+				assert.Equal(t, test.names, names(groupTasks(tasks, test.groupBy)))
+			}
+		})
+	}
+}
+
+func TestPrintTaskGroups(t *testing.T) {
+	output.SetColorEnabled(false)
+
+	tasks := []clickup.Task{
+		{ID: "a1", Name: "Write docs", Status: clickup.TaskStatus{Status: "to do", Orderindex: "0"}},
+		{ID: "b2", Name: "Ship it", Status: clickup.TaskStatus{Status: "done", Orderindex: "2"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printTaskGroups(&buf, groupTasks(tasks, "status"), nil))
+
+	out := buf.String()
+	todo := strings.Index(out, "to do (1)")
+	done := strings.Index(out, "done (1)")
+	require.NotEqual(t, -1, todo)
+	require.NotEqual(t, -1, done)
+	assert.Less(t, todo, strings.Index(out, "Write docs"))
+	assert.Less(t, strings.Index(out, "Write docs"), done)
+	assert.Less(t, done, strings.Index(out, "Ship it"))
+}
+
+func TestTaskTotals(t *testing.T) {
+	hour := int64(time.Hour / time.Millisecond)
+
+	tests := []struct {
+		name  string
+		tasks []clickup.Task
+		want  string
+	}{
+		{
+			name: "mixed estimates",
+			tasks: []clickup.Task{
+				{ID: "1", TimeEstimate: 2 * hour, TimeSpent: hour},
+				{ID: "2", TimeEstimate: hour / 2},
+				{ID: "3", TimeSpent: hour / 4},
+				{ID: "4"},
+			},
+			want: "Estimated: 2h 30m (2 of 4 tasks), tracked: 1h 15m",
+		},
+		{
+			name:  "whole hours",
+			tasks: []clickup.Task{{ID: "1", TimeEstimate: 8 * hour}, {ID: "2", TimeEstimate: 4 * hour}},
+			want:  "Estimated: 12h (2 of 2 tasks), tracked: 0m",
+		},
+		{
+			name:  "no estimates or tracked time",
+			tasks: []clickup.Task{{ID: "1"}, {ID: "2"}},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, taskTotals(tt.tasks))
+		})
+	}
+}
+
+func TestPrintTaskFooter(t *testing.T) {
+	hour := int64(time.Hour / time.Millisecond)
+	tasks := []clickup.Task{{ID: "1", Status: clickup.TaskStatus{Status: "to do"}, TimeEstimate: hour}}
+
+	t.Run("quiet still prints totals", func(t *testing.T) {
+		var buf bytes.Buffer
+		printTaskFooter(&buf, tasks, true, true)
+		assert.Equal(t, "\nEstimated: 1h (1 of 1 tasks), tracked: 0m\n", buf.String())
+	})
+
+	t.Run("summary and totals", func(t *testing.T) {
+		var buf bytes.Buffer
+		printTaskFooter(&buf, tasks, false, true)
+		assert.Equal(t, "\n"+taskSummary(tasks)+"\nEstimated: 1h (1 of 1 tasks), tracked: 0m\n", buf.String())
+	})
+
+	t.Run("quiet without totals prints nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		printTaskFooter(&buf, tasks, true, false)
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestTaskSummary(t *testing.T) {
+	todo := clickup.TaskStatus{Status: "to do", Orderindex: "0"}
+	progress := clickup.TaskStatus{Status: "in progress", Orderindex: "1"}
+	done := clickup.TaskStatus{Status: "done", Orderindex: "2"}
+
+	tests := []struct {
+		name  string
+		tasks []clickup.Task
+		want  string
+	}{
+		{
+			name: "counts per status in workflow order",
+			tasks: []clickup.Task{
+				{ID: "1", Status: done},
+				{ID: "2", Status: todo},
+				{ID: "3", Status: progress},
+				{ID: "4", Status: todo},
+				{ID: "5", Status: progress},
+				{ID: "6", Status: progress},
+			},
+			want: "6 tasks: 2 to do, 3 in progress, 1 done",
+		},
+		{
+			name:  "single task",
+			tasks: []clickup.Task{{ID: "1", Status: todo}},
+			want:  "1 task: 1 to do",
+		},
+		{
+			name: "no tasks",
+			want: "0 tasks",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, taskSummary(test.tasks))
+		})
+	}
+}
+
+func TestIsTaskGroupField(t *testing.T) {
+	assert.True(t, isTaskGroupField("status"))
+	assert.True(t, isTaskGroupField("assignee"))
+	assert.True(t, isTaskGroupField("priority"))
+	assert.False(t, isTaskGroupField("due"))
+}
+
+func TestBuildTaskQueryOptions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		opts := buildTaskQueryOptions(2, nil, "", "")
+		assert.Equal(t, 2, opts.Page)
+		assert.Nil(t, opts.Assignees)
+		assert.Nil(t, opts.Statuses)
+		assert.Nil(t, opts.Tags)
+	})
+
+	t.Run("all filters", func(t *testing.T) {
+		opts := buildTaskQueryOptions(0, []string{"7"}, "open", "bug")
+		assert.Equal(t, []string{"7"}, opts.Assignees)
+		assert.Equal(t, []string{"open"}, opts.Statuses)
+		assert.Equal(t, []string{"bug"}, opts.Tags)
+	})
+
+	t.Run("several assignees match any of them", func(t *testing.T) {
+		client := &fakeAssigneePager{tasks: []clickup.Task{
+			{ID: "1", Assignees: []clickup.User{{ID: 7}}},
+			{ID: "2", Assignees: []clickup.User{{ID: 8}, {ID: 9}}},
+			{ID: "3", Assignees: []clickup.User{{ID: 9}}},
+			{ID: "4"},
+		}}
+
+		opts := buildTaskQueryOptions(0, []string{"7", "8"}, "", "")
+		assert.Equal(t, []string{"7", "8"}, opts.Assignees)
+
+		tasks, _, err := fetchTaskPage(context.Background(), client, []string{"l1"}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"7", "8"}, client.assignees)
+
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, []string{"1", "2"}, ids)
+	})
+
+	t.Run("assignee flag is repeatable", func(t *testing.T) {
+		flag := taskListCmd.Flags().Lookup("assignee")
+		require.NotNil(t, flag)
+		assert.Equal(t, "stringSlice", flag.Value.Type())
+	})
+}
+
+// fakeAssigneePager filters its tasks by assignee ID like ClickUp, keeping
+// tasks assigned to any of the requested users
+type fakeAssigneePager struct {
+	tasks     []clickup.Task
+	assignees []string
+}
+
+func (f *fakeAssigneePager) GetTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error) {
+	f.assignees = options.Assignees
+
+	var tasks []clickup.Task
+	for _, task := range f.tasks {
+		for _, user := range task.Assignees {
+			if slices.Contains(options.Assignees, strconv.Itoa(user.ID)) {
+				tasks = append(tasks, task)
+				break
+			}
+		}
+	}
+	return tasks, nil
+}
+
+func TestApplyMineOptions(t *testing.T) {
+	t.Run("sets current user as assignee and excludes closed", func(t *testing.T) {
+		opts := buildTaskQueryOptions(0, nil, "", "")
+		opts.IncludeClosed = true
+
+		applyMineOptions(opts, 12345)
+
+		assert.Equal(t, []string{"12345"}, opts.Assignees)
+		assert.False(t, opts.IncludeClosed)
+	})
+
+	t.Run("keeps other filters", func(t *testing.T) {
+		opts := buildTaskQueryOptions(1, nil, "in progress", "bug")
+
+		applyMineOptions(opts, 7)
+
+		assert.Equal(t, []string{"7"}, opts.Assignees)
+		assert.Equal(t, []string{"in progress"}, opts.Statuses)
+		assert.Equal(t, []string{"bug"}, opts.Tags)
+		assert.Equal(t, 1, opts.Page)
+	})
+
+	t.Run("mine flag is registered", func(t *testing.T) {
+		flag := taskListCmd.Flags().Lookup("mine")
+		if assert.NotNil(t, flag) {
+			assert.Equal(t, "false", flag.DefValue)
+		}
+	})
+}
+
+func TestFilterOpenTasks(t *testing.T) {
+	tasks := []clickup.Task{
+		{ID: "1", Status: clickup.TaskStatus{Status: "to do", Type: "open"}},
+		{ID: "2", Status: clickup.TaskStatus{Status: "in progress", Type: "custom"}},
+		{ID: "3", Status: clickup.TaskStatus{Status: "complete", Type: "closed"}},
+		{ID: "4", Status: clickup.TaskStatus{Status: "shipped", Type: "done"}},
+	}
+
+	filtered := filterOpenTasks(tasks)
+
+	var ids []string
+	for _, task := range filtered {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestFilterUnassignedTasks(t *testing.T) {
+	tasks := []clickup.Task{
+		{ID: "1"},
+		{ID: "2", Assignees: []clickup.User{{ID: 11, Username: "alice"}}},
+		{ID: "3", Assignees: []clickup.User{}},
+		{ID: "4", Assignees: []clickup.User{{ID: 11}, {ID: 22}}},
+	}
+
+	var ids []string
+	for _, task := range filterUnassignedTasks(tasks) {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"1", "3"}, ids)
+
+	assert.Empty(t, filterUnassignedTasks(tasks[1:2]))
+}
+
+func TestSelectTaskViewMode(t *testing.T) {
+	assert.Equal(t, taskViewHuman, selectTaskViewMode(false, false))
+	assert.Equal(t, taskViewRaw, selectTaskViewMode(false, true))
+	assert.Equal(t, taskViewWeb, selectTaskViewMode(true, false))
+
+	// --web and --raw are rejected together by cobra
+	cmd := &cobra.Command{Use: "view", Run: func(*cobra.Command, []string) {}}
+	cmd.Flags().Bool("web", false, "")
+	cmd.Flags().Bool("raw", false, "")
+	cmd.MarkFlagsMutuallyExclusive("web", "raw")
+	cmd.SetArgs([]string{"--web", "--raw"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestTaskWebURL(t *testing.T) {
+	assert.Equal(t, "https://app.clickup.com/t/abc123", taskWebURL("abc123"))
+}
+
+func TestPrintRawTask(t *testing.T) {
+	task := &clickup.Task{
+		ID:     "abc123",
+		Name:   "Raw task",
+		Status: clickup.TaskStatus{Status: "open"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printRawTask(&buf, task))
+
+	var decoded clickup.Task
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "abc123", decoded.ID)
+	assert.Equal(t, "open", decoded.Status.Status)
+	assert.Contains(t, buf.String(), `"orderindex"`)
+}
+
+func TestReadDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		description string
+		fromStdin   bool
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "flag value is used as is",
+			input:       "ignored",
+			description: "Short description",
+			want:        "Short description",
+		},
+		{
+			name:      "stdin keeps inner newlines and trims the last one",
+			input:     "# Notes\n\n- first\n- second\n",
+			fromStdin: true,
+			want:      "# Notes\n\n- first\n- second",
+		},
+		{
+			name:        "dash reads stdin",
+			input:       "line one\r\nline two\r\n",
+			description: "-",
+			want:        "line one\r\nline two",
+		},
+		{
+			name:      "only one trailing newline is removed",
+			input:     "body\n\n",
+			fromStdin: true,
+			want:      "body\n",
+		},
+		{
+			name:        "stdin with description is rejected",
+			description: "text",
+			fromStdin:   true,
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := readDescription(strings.NewReader(test.input), test.description, test.fromStdin)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestPrintTaskCount(t *testing.T) {
+	t.Run("table prints the integer", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printTaskCount(&buf, "table", 42))
+		assert.Equal(t, "42\n", buf.String())
+	})
+
+	t.Run("json prints a count object", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printTaskCount(&buf, "json", 7))
+		assert.JSONEq(t, `{"count": 7}`, buf.String())
+	})
+
+	t.Run("yaml prints a count key", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printTaskCount(&buf, "yaml", 0))
+		assert.Equal(t, "count: 0\n", buf.String())
+	})
+}
+
+func TestApplyLimit(t *testing.T) {
+	tasks := []clickup.Task{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"zero returns all", 0, 3},
+		{"negative returns all", -1, 3},
+		{"positive truncates", 2, 2},
+		{"limit above count returns all", 10, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := applyLimit(tasks, test.limit)
+			assert.Len(t, got, test.want)
+			assert.Equal(t, "1", got[0].ID)
+		})
+	}
+}
+
+// fakeTaskPager serves tasks from each list in pages of api.TasksPageSize.
+// With newest set, task i was updated i minutes before it.
+type fakeTaskPager struct {
+	lists    map[string]int
+	newest   time.Time
+	requests []string
+}
+
+func (f *fakeTaskPager) GetTasks(ctx context.Context, listID string, options *api.TaskQueryOptions) ([]clickup.Task, error) {
+	f.requests = append(f.requests, fmt.Sprintf("%s page %d order %q", listID, options.Page, options.OrderBy))
+
+	start := options.Page * api.TasksPageSize
+	end := min(start+api.TasksPageSize, f.lists[listID])
+
+	var tasks []clickup.Task
+	for i := start; i < end; i++ {
+		task := clickup.Task{ID: fmt.Sprintf("%s-%d", listID, i)}
+		if !f.newest.IsZero() {
+			task.DateUpdated = strconv.FormatInt(f.newest.Add(-time.Duration(i)*time.Minute).UnixMilli(), 10)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func TestFetchAllTaskPages(t *testing.T) {
+	ctx := context.Background()
+	newest := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("reads every page of each list", func(t *testing.T) {
+		client := &fakeTaskPager{lists: map[string]int{"big": 2*api.TasksPageSize + 5, "small": 3}}
+
+		tasks, err := fetchAllTaskPages(ctx, client, []string{"big", "small"}, &api.TaskQueryOptions{})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2*api.TasksPageSize+8)
+		assert.Equal(t, []string{
+			`big page 0 order ""`,
+			`big page 1 order ""`,
+			`big page 2 order ""`,
+			`small page 0 order ""`,
+		}, client.requests)
+	})
+
+	t.Run("stops at the page that reaches since", func(t *testing.T) {
+		client := &fakeTaskPager{lists: map[string]int{"big": 3 * api.TasksPageSize}, newest: newest}
+		since := newest.Add(-time.Duration(api.TasksPageSize+10) * time.Minute)
+
+		tasks, err := fetchAllTaskPages(ctx, client, []string{"big"}, &api.TaskQueryOptions{OrderBy: "updated", UpdatedSince: since})
+		require.NoError(t, err)
+		assert.Equal(t, []string{`big page 0 order "updated"`, `big page 1 order "updated"`}, client.requests)
+
+		tasks = filterUpdatedSince(tasks, since)
+		assert.Len(t, tasks, api.TasksPageSize+10)
+		assert.Equal(t, "big-0", tasks[0].ID)
+	})
+}
+
+func TestFilterUpdatedSince(t *testing.T) {
+	since := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	at := func(t time.Time) string { return strconv.FormatInt(t.UnixMilli(), 10) }
+
+	tasks := []clickup.Task{
+		{ID: "1", DateUpdated: at(since.Add(time.Second))},
+		{ID: "2", DateUpdated: at(since)},
+		{ID: "3", DateUpdated: at(since.Add(-time.Hour))},
+		{ID: "4"},
+		{ID: "5", DateUpdated: at(since.Add(time.Hour))},
+	}
+
+	var ids []string
+	for _, task := range filterUpdatedSince(tasks, since) {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"1", "5"}, ids)
+}
+
+func TestParseUpdatedSince(t *testing.T) {
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	defer clock.Set(clock.Fixed(now))()
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2026-03-01T08:30:00Z", time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"2h", now.Add(-2 * time.Hour)},
+		{"7d", now.AddDate(0, 0, -7)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := parseUpdatedSince(test.value)
+			require.NoError(t, err)
+			assert.True(t, test.want.Equal(got), "got %s", got)
+		})
+	}
+
+	for _, value := range []string{"yesterday", "-2h", "xd", "2026-13-01"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := parseUpdatedSince(value)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "Invalid --updated-since")
+			assert.True(t, stderrors.Is(err, errors.ErrInvalidInput))
+		})
+	}
+}
+
+func TestTaskEnvelope(t *testing.T) {
+	client := &fakeTaskPager{lists: map[string]int{"big": api.TasksPageSize + 5, "small": 3}}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		listIDs   []string
+		page      int
+		wantCount int
+		wantMore  bool
+	}{
+		{"full first page has more", []string{"big"}, 0, api.TasksPageSize, true},
+		{"last page has no more", []string{"big"}, 1, 5, false},
+		{"short list has no more", []string{"small"}, 0, 3, false},
+		{"any full list has more", []string{"small", "big"}, 0, api.TasksPageSize + 3, true},
+		{"page past the end is empty", []string{"small"}, 2, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tasks, hasMore, err := fetchTaskPage(ctx, client, test.listIDs, &api.TaskQueryOptions{Page: test.page})
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, output.FormatTo(&buf, "json", newTaskEnvelope(tasks, test.page, hasMore)))
+
+			var got struct {
+				Items   []json.RawMessage `json:"items"`
+				Count   int               `json:"count"`
+				Page    int               `json:"page"`
+				HasMore bool              `json:"has_more"`
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.NotNil(t, got.Items)
+			assert.Len(t, got.Items, test.wantCount)
+			assert.Equal(t, test.wantCount, got.Count)
+			assert.Equal(t, test.page, got.Page)
+			assert.Equal(t, test.wantMore, got.HasMore)
+		})
+	}
+
+	t.Run("tasks dropped by the limit count as more", func(t *testing.T) {
+		client := &fakeTaskPager{lists: map[string]int{"mid": 50}}
+		tasks, hasMore, err := fetchTaskPage(ctx, client, []string{"mid"}, &api.TaskQueryOptions{})
+		require.NoError(t, err)
+		require.False(t, hasMore)
+
+		tasks, hasMore = limitTaskPage(tasks, 30, hasMore)
+		assert.Len(t, tasks, 30)
+		assert.True(t, hasMore)
+
+		tasks, hasMore = limitTaskPage(tasks, 0, false)
+		assert.Len(t, tasks, 30)
+		assert.False(t, hasMore)
+	})
+}
+
+func TestApplyOffsetLimit(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name   string
+		offset int
+		limit  int
+		want   []int
+	}{
+		{"no offset or limit keeps all", 0, 0, []int{1, 2, 3, 4, 5}},
+		{"limit truncates", 0, 2, []int{1, 2}},
+		{"offset skips", 3, 0, []int{4, 5}},
+		{"offset then limit", 1, 2, []int{2, 3}},
+		{"limit past the end", 4, 10, []int{5}},
+		{"offset past the end is empty", 7, 2, []int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, applyOffsetLimit(items, test.offset, test.limit))
+		})
+	}
+
+	t.Run("limit flags default to unbounded", func(t *testing.T) {
+		for _, cmd := range []*cobra.Command{listCommentsCmd, userListCmd, listListCmd} {
+			flag := cmd.Flags().Lookup("limit")
+			require.NotNil(t, flag, cmd.Name())
+			assert.Equal(t, "0", flag.DefValue, cmd.Name())
+		}
+		require.NotNil(t, listCommentsCmd.Flags().Lookup("offset"))
+	})
+}
+
+func TestFilterSubtasks(t *testing.T) {
+	tasks := []clickup.Task{
+		{ID: "1"},
+		{ID: "2", Parent: "1"},
+		{ID: "3", Parent: "1"},
+		{ID: "4", Parent: "9"},
+		{ID: "5", Parent: "2"},
+	}
+
+	t.Run("keeps only direct children", func(t *testing.T) {
+		var ids []string
+		for _, task := range filterSubtasks(tasks, "1") {
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, []string{"2", "3"}, ids)
+	})
+
+	t.Run("no children", func(t *testing.T) {
+		assert.Empty(t, filterSubtasks(tasks, "5"))
+	})
+
+	t.Run("parent flag is registered", func(t *testing.T) {
+		assert.NotNil(t, taskListCmd.Flags().Lookup("parent"))
+	})
+
+	t.Run("parent reads every page unless --page is given", func(t *testing.T) {
+		assert.True(t, fetchesAllPages(false, "1", false))
+		assert.False(t, fetchesAllPages(false, "1", true))
+		assert.True(t, fetchesAllPages(true, "", false))
+		assert.False(t, fetchesAllPages(false, "", false))
+	})
+}
+
+func TestFilterDueWithin(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	due := func(tm time.Time) *clickup.Date { return clickup.NewDate(tm) }
+
+	tasks := []clickup.Task{
+		{ID: "late", DueDate: due(time.Date(2026, 3, 13, 23, 59, 0, 0, time.UTC))},
+		{ID: "earlier", DueDate: due(now.Add(-time.Hour))},
+		{ID: "yesterday", DueDate: due(time.Date(2026, 3, 9, 23, 0, 0, 0, time.UTC))},
+		{ID: "soon", DueDate: due(now.Add(time.Hour))},
+		{ID: "outside", DueDate: due(time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC))},
+		{ID: "none"},
+	}
+
+	t.Run("keeps tasks inside the window", func(t *testing.T) {
+		got := filterDueWithin(tasks, now, 3)
+		sortTasks(got, "due", "asc", "last")
+
+		var ids []string
+		for _, task := range got {
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, []string{"earlier", "soon", "late"}, ids, "tasks due earlier today are kept")
+	})
+
+	t.Run("window grows with days", func(t *testing.T) {
+		assert.Len(t, filterDueWithin(tasks, now, 4), 4)
+	})
+
+	t.Run("due-soon flags are registered", func(t *testing.T) {
+		days := taskDueSoonCmd.Flags().Lookup("days")
+		require.NotNil(t, days)
+		assert.Equal(t, "3", days.DefValue)
+	})
+}
+
+func TestFilterOverdueTasks(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	due := func(tm time.Time) *clickup.Date { return clickup.NewDate(tm) }
+	open := clickup.TaskStatus{Status: "to do", Type: "open"}
+	closed := clickup.TaskStatus{Status: "complete", Type: "closed"}
+
+	tasks := []clickup.Task{
+		{ID: "hour", Status: open, DueDate: due(now.Add(-time.Hour))},
+		{ID: "week", Status: open, DueDate: due(now.Add(-7 * 24 * time.Hour))},
+		{ID: "done", Status: closed, DueDate: due(now.Add(-48 * time.Hour))},
+		{ID: "future", Status: open, DueDate: due(now.Add(time.Hour))},
+		{ID: "none", Status: open},
+	}
+
+	got := filterOverdueTasks(tasks, now)
+	sortTasks(got, "due", "asc", "last")
+
+	var ids []string
+	for _, task := range got {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"week", "hour"}, ids)
+
+	for _, cmd := range []*cobra.Command{taskListCmd, taskSearchCmd} {
+		assert.NotNil(t, cmd.Flags().Lookup("overdue"), cmd.Name())
+	}
+}
+
+func TestPrintTaskTableEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printTaskTable(&buf, nil, nil))
+	assert.Equal(t, "No tasks found\n", buf.String())
+
+	output.SetTableHeader(false)
+	defer output.SetTableHeader(true)
+	buf.Reset()
+	require.NoError(t, printTaskTable(&buf, nil, nil))
+	assert.Empty(t, buf.String(), "no message is printed as a row without the header")
+}
+
+func TestPrintTaskTableNoHeader(t *testing.T) {
+	output.SetTableHeader(false)
+	defer output.SetTableHeader(true)
+	defer output.SetColorEnabled(output.ColorEnabled())
+	output.SetColorEnabled(false)
+
+	tasks := []clickup.Task{
+		{ID: "a1", Name: "First", Status: clickup.TaskStatus{Status: "open"}},
+		{ID: "b22", Name: "Second task", Status: clickup.TaskStatus{Status: "in progress"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printTaskTable(&buf, tasks, nil))
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "a1   First        open"))
+	assert.True(t, strings.HasPrefix(lines[1], "b22  Second task  in progress"))
+	assert.NotContains(t, buf.String(), "----------")
+}
+
+func TestPrintTaskPorcelain(t *testing.T) {
+	longName := strings.Repeat("very long task name ", 10)
+	tasks := []clickup.Task{
+		{
+			ID:       "abc",
+			Name:     longName,
+			Status:   clickup.TaskStatus{Status: "in progress", Color: "#d33d44"},
+			Priority: clickup.TaskPriority{Priority: "High", Color: "#f50000"},
+			DueDate:  clickup.NewDate(time.Date(2026, 3, 15, 23, 30, 0, 0, time.Local)),
+		},
+		{ID: "def", Name: "tab\there\nnewline", Status: clickup.TaskStatus{Status: "to do"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printTaskPorcelain(&buf, tasks))
+
+	assert.Equal(t,
+		"abc\t"+longName+"\tin progress\thigh\t2026-03-15\n"+
+			"def\ttab here newline\tto do\t\t\n",
+		buf.String())
+	assert.NotContains(t, buf.String(), "\x1b[")
+	assert.NotContains(t, buf.String(), "...")
+
+	t.Run("porcelain flag is registered", func(t *testing.T) {
+		assert.NotNil(t, taskListCmd.Flags().Lookup("porcelain"))
+	})
+}
+
+func TestPrintTaskCSV(t *testing.T) {
+	longName := strings.Repeat("long, quoted \"name\" ", 5)
+	tasks := []clickup.Task{
+		{
+			ID:        "abc",
+			Name:      longName,
+			Status:    clickup.TaskStatus{Status: "in progress"},
+			Priority:  clickup.TaskPriority{Priority: "high"},
+			Assignees: []clickup.User{{ID: 1, Username: "alice"}},
+		},
+		{ID: "def", Name: "Short", Status: clickup.TaskStatus{Status: "to do"}},
+	}
+
+	t.Run("task columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printTaskCSV(&buf, tasks, nil))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "name", "status", "assignee", "priority", "due"},
+			{"abc", longName, "in progress", "alice", "high", ""},
+			{"def", "Short", "to do", "", "Normal", ""},
+		}, records)
+	})
+
+	t.Run("custom field columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printTaskCSV(&buf, tasks[1:], []string{"Sprint"}))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "name", "status", "assignee", "priority", "due", "Sprint"}, records[0])
+		assert.Equal(t, "Short", records[1][1])
+	})
+}
+
+// testStatusList returns a list with custom open statuses as ClickUp sends it
+func testStatusList(t *testing.T) *clickup.List {
+	t.Helper()
+	var list clickup.List
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "list1", "name": "Sprint", "statuses": [
+		{"status": "backlog", "type": "open", "orderindex": 0},
+		{"status": "In Review", "type": "custom", "orderindex": 1},
+		{"status": "shipped", "type": "closed", "orderindex": 2}
+	]}`), &list))
+	return &list
+}
+
+// fakeTaskReopener serves one task in testStatusList, in status current, and
+// records the update
+type fakeTaskReopener struct {
+	list    *clickup.List
+	current string
+	status  string
+}
+
+func (f *fakeTaskReopener) GetTask(ctx context.Context, taskID string) (*clickup.Task, error) {
+	return &clickup.Task{
+		ID:     taskID,
+		Status: clickup.TaskStatus{Status: f.current},
+		List:   clickup.ListOfTaskBelonging{ID: f.list.ID},
+	}, nil
+}
+
+func (f *fakeTaskReopener) GetList(ctx context.Context, listID string) (*clickup.List, error) {
+	return f.list, nil
+}
+
+func (f *fakeTaskReopener) UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error) {
+	f.status = options.Status
+	return &clickup.Task{ID: taskID, Status: clickup.TaskStatus{Status: options.Status}}, nil
+}
+
+// fakeTaskAssigner serves one task, resolves usernames from users and
+// applies assignee updates to the task
+type fakeTaskAssigner struct {
+	task    clickup.Task
+	users   map[string]int
+	updates int
+}
+
+func (f *fakeTaskAssigner) GetTask(ctx context.Context, taskID string) (*clickup.Task, error) {
+	task := f.task
+	return &task, nil
+}
+
+func (f *fakeTaskAssigner) ResolveAssignees(ctx context.Context, assignees []string) ([]string, error) {
+	ids := make([]string, 0, len(assignees))
+	for _, name := range assignees {
+		id, ok := f.users[name]
+		if !ok {
+			return nil, fmt.Errorf("failed to find user %s", name)
+		}
+		ids = append(ids, strconv.Itoa(id))
+	}
+	return ids, nil
+}
+
+func (f *fakeTaskAssigner) UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error) {
+	f.updates++
+	var assignees []clickup.User
+	for _, user := range f.task.Assignees {
+		if !slices.Contains(options.RemoveAssignees, strconv.Itoa(user.ID)) {
+			assignees = append(assignees, user)
+		}
+	}
+	for _, id := range options.AddAssignees {
+		n, _ := strconv.Atoi(id)
+		assignees = append(assignees, clickup.User{ID: n})
+	}
+	f.task.Assignees = assignees
+	task := f.task
+	return &task, nil
+}
+
+func TestRunTaskAssign(t *testing.T) {
+	ctx := context.Background()
+	users := map[string]int{"alice": 7, "bob@example.com": 8, "carol": 9}
+	assigneeIDs := func(task *clickup.Task) []int {
+		var ids []int
+		for _, user := range task.Assignees {
+			ids = append(ids, user.ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	t.Run("replaces the assignees with the given users", func(t *testing.T) {
+		client := &fakeTaskAssigner{
+			task:  clickup.Task{ID: "abc", Assignees: []clickup.User{{ID: 7}, {ID: 9}}},
+			users: users,
+		}
+
+		task, err := runTaskAssign(ctx, client, "abc", []string{"alice", "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{7, 8}, assigneeIDs(task))
+		assert.Equal(t, 1, client.updates)
+	})
+
+	t.Run("unassigned task gets the users", func(t *testing.T) {
+		client := &fakeTaskAssigner{task: clickup.Task{ID: "abc"}, users: users}
+
+		task, err := runTaskAssign(ctx, client, "abc", []string{"carol", "carol", "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{7, 9}, assigneeIDs(task))
+	})
+
+	t.Run("matching assignees are not updated", func(t *testing.T) {
+		client := &fakeTaskAssigner{
+			task:  clickup.Task{ID: "abc", Assignees: []clickup.User{{ID: 8}, {ID: 7}}},
+			users: users,
+		}
+
+		task, err := runTaskAssign(ctx, client, "abc", []string{"alice", "bob@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{7, 8}, assigneeIDs(task))
+		assert.Zero(t, client.updates)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		client := &fakeTaskAssigner{task: clickup.Task{ID: "abc"}, users: users}
+
+		_, err := runTaskAssign(ctx, client, "abc", []string{"dave"})
+		assert.ErrorContains(t, err, "failed to find user dave")
+		assert.Zero(t, client.updates)
+	})
+}
+
+func TestRunTaskMoveStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid forward move uses the list's spelling", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t), current: "backlog"}
+		task, err := runTaskMoveStatus(ctx, client, io.Discard, "abc", "in review", true, false)
+		require.NoError(t, err)
+		assert.Equal(t, "In Review", client.status)
+		assert.Equal(t, "In Review", task.Status.Status)
+	})
+
+	t.Run("backward move warns and moves", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t), current: "shipped"}
+		var buf bytes.Buffer
+		_, err := runTaskMoveStatus(ctx, client, &buf, "abc", "backlog", true, false)
+		require.NoError(t, err)
+		assert.Equal(t, "backlog", client.status)
+		assert.Equal(t, "Warning: moving the task back from 'shipped' to 'backlog'\n", buf.String())
+	})
+
+	t.Run("force skips the warning", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t), current: "shipped"}
+		var buf bytes.Buffer
+		_, err := runTaskMoveStatus(ctx, client, &buf, "abc", "backlog", true, true)
+		require.NoError(t, err)
+		assert.Equal(t, "backlog", client.status)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("unknown status lists the list's statuses", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t), current: "backlog"}
+		_, err := runTaskMoveStatus(ctx, client, io.Discard, "abc", "doing", true, true)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "'doing' is not a status of list Sprint")
+		assert.Contains(t, err.Error(), "Valid statuses: backlog, In Review, shipped")
+		assert.Empty(t, client.status)
+	})
+
+	t.Run("without validation the status is sent as given", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t), current: "shipped"}
+		_, err := runTaskMoveStatus(ctx, client, io.Discard, "abc", "doing", false, false)
+		require.NoError(t, err)
+		assert.Equal(t, "doing", client.status)
+	})
+}
+
+func TestRunTaskReopen(t *testing.T) {
+	t.Run("defaults to the first open status", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t)}
+		task, err := runTaskReopen(context.Background(), client, "abc", "")
+		require.NoError(t, err)
+		assert.Equal(t, "backlog", client.status)
+		assert.Equal(t, "backlog", task.Status.Status)
+	})
+
+	t.Run("valid custom status uses the list's spelling", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t)}
+		_, err := runTaskReopen(context.Background(), client, "abc", "in review")
+		require.NoError(t, err)
+		assert.Equal(t, "In Review", client.status)
+	})
+
+	t.Run("invalid status lists valid ones", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t)}
+		_, err := runTaskReopen(context.Background(), client, "abc", "doing")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "'doing' is not an open status of list Sprint")
+		assert.Contains(t, err.Error(), "Valid statuses: backlog, In Review")
+		assert.Empty(t, client.status)
+	})
+
+	t.Run("closed status is rejected", func(t *testing.T) {
+		client := &fakeTaskReopener{list: testStatusList(t)}
+		_, err := runTaskReopen(context.Background(), client, "abc", "shipped")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+
+	t.Run("list without statuses falls back to open", func(t *testing.T) {
+		status, err := resolveReopenStatus(&clickup.List{}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "open", status)
+	})
+}
+
+func TestResolveStatusByType(t *testing.T) {
+	sprint := testStatusList(t)
+
+	var kanban clickup.List
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "list2", "name": "Kanban", "statuses": [
+		{"status": "To Do", "type": "open", "orderindex": 0},
+		{"status": "Doing", "type": "custom", "orderindex": 1},
+		{"status": "Testing", "type": "custom", "orderindex": 2},
+		{"status": "Done", "type": "done", "orderindex": 3},
+		{"status": "Archived", "type": "closed", "orderindex": 4}
+	]}`), &kanban))
+
+	tests := []struct {
+		list       *clickup.List
+		statusType string
+		want       string
+	}{
+		{sprint, "open", "backlog"},
+		{sprint, "custom", "In Review"},
+		{sprint, "done", "shipped"},
+		{sprint, "closed", "shipped"},
+		{&kanban, "open", "To Do"},
+		{&kanban, "custom", "Doing"},
+		{&kanban, "done", "Done"},
+		{&kanban, "closed", "Archived"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.list.Name+" "+test.statusType, func(t *testing.T) {
+			got, err := resolveStatusByType(test.list, test.statusType)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("missing type lists the statuses", func(t *testing.T) {
+		var list clickup.List
+		require.NoError(t, json.Unmarshal([]byte(`{"name": "Simple", "statuses": [
+			{"status": "to do", "type": "open"},
+			{"status": "complete", "type": "closed"}
+		]}`), &list))
+
+		_, err := resolveStatusByType(&list, "custom")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "List Simple has no in progress status")
+		assert.Contains(t, err.Error(), "to do, complete")
+	})
+
+	t.Run("update resolves against the task's list", func(t *testing.T) {
+		client := &fakeTaskReopener{list: &kanban}
+		got, err := resolveTaskStatusByType(context.Background(), client, "abc", "custom")
+		require.NoError(t, err)
+		assert.Equal(t, "Doing", got)
+	})
+
+	t.Run("flags map to status types", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", "", "")
+		addStatusTypeFlags(cmd)
+		assert.Empty(t, statusTypeOption(cmd))
+
+		require.NoError(t, cmd.Flags().Set("status-in-progress", "true"))
+		assert.Equal(t, "custom", statusTypeOption(cmd))
+	})
+}
+
+// fakeTaskUpdater records update calls and returns a fixed plan
+type fakeTaskUpdater struct {
+	plan        *api.TaskUpdatePlan
+	updateCalls int
+	lastOptions *api.TaskUpdateOptions
+}
+
+func (f *fakeTaskUpdater) PlanTaskUpdate(ctx context.Context, options *api.TaskUpdateOptions) (*api.TaskUpdatePlan, error) {
+	return f.plan, nil
+}
+
+func (f *fakeTaskUpdater) UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error) {
+	f.updateCalls++
+	f.lastOptions = options
+	return &clickup.Task{ID: taskID}, nil
+}
+
+func TestRunTaskUpdate(t *testing.T) {
+	due := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	opts := &api.TaskUpdateOptions{
+		Status:          "done",
+		Priority:        "high",
+		DueDate:         "2026-03-15",
+		AddAssignees:    []string{"alice", "789"},
+		RemoveAssignees: []string{"bob"},
+	}
+	plan := &api.TaskUpdatePlan{
+		Status:       "done",
+		Priority:     2,
+		DueDate:      &due,
+		AddAssignees: []int{123, 789},
+	}
+
+	t.Run("dry run prints plan without updating", func(t *testing.T) {
+		client := &fakeTaskUpdater{plan: plan}
+		var out bytes.Buffer
+
+		task, err := runTaskUpdate(context.Background(), client, &out, "table", "abc123", opts, true)
+		require.NoError(t, err)
+		assert.Nil(t, task)
+		assert.Equal(t, 0, client.updateCalls)
+
+		result := out.String()
+		assert.Contains(t, result, "Would update task abc123")
+		assert.Contains(t, result, "Status: done")
+		assert.Contains(t, result, "Priority: high (2)")
+		assert.Contains(t, result, "Due date: 2026-03-15 00:00")
+		assert.Contains(t, result, "Add assignees: alice (123), 789")
+		assert.Contains(t, result, "Remove assignees: bob (could not be resolved, will be skipped)")
+	})
+
+	t.Run("applies update without dry run", func(t *testing.T) {
+		client := &fakeTaskUpdater{plan: plan}
+		var out bytes.Buffer
+
+		task, err := runTaskUpdate(context.Background(), client, &out, "table", "abc123", opts, false)
+		require.NoError(t, err)
+		require.NotNil(t, task)
+		assert.Equal(t, "abc123", task.ID)
+		assert.Equal(t, 1, client.updateCalls)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("unparseable due date is reported", func(t *testing.T) {
+		client := &fakeTaskUpdater{plan: &api.TaskUpdatePlan{}}
+		var out bytes.Buffer
+
+		_, err := runTaskUpdate(context.Background(), client, &out, "table", "abc123", &api.TaskUpdateOptions{DueDate: "someday"}, true)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Due date: someday (could not be parsed")
+		assert.Equal(t, 0, client.updateCalls)
+	})
+}
+
+// fakeTaskGetter returns a task with a fixed description
+type fakeTaskGetter struct {
+	description string
+}
+
+func (f *fakeTaskGetter) GetTask(ctx context.Context, taskID string) (*clickup.Task, error) {
+	return &clickup.Task{ID: taskID, Description: f.description}, nil
+}
+
+func TestAppendTaskDescription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("appends on a new line", func(t *testing.T) {
+		got, err := appendTaskDescription(ctx, &fakeTaskGetter{description: "Original body"}, "abc123", "Follow-up note")
+		require.NoError(t, err)
+		assert.Equal(t, "Original body\nFollow-up note", got)
+	})
+
+	t.Run("trailing newlines are not doubled", func(t *testing.T) {
+		got, err := appendTaskDescription(ctx, &fakeTaskGetter{description: "Original body\n\n"}, "abc123", "Note")
+		require.NoError(t, err)
+		assert.Equal(t, "Original body\nNote", got)
+	})
+
+	t.Run("empty description becomes the text", func(t *testing.T) {
+		got, err := appendTaskDescription(ctx, &fakeTaskGetter{}, "abc123", "Note")
+		require.NoError(t, err)
+		assert.Equal(t, "Note", got)
+	})
+
+	t.Run("replace still overwrites", func(t *testing.T) {
+		client := &fakeTaskUpdater{}
+		opts := &api.TaskUpdateOptions{Description: "New body"}
+
+		_, err := runTaskUpdate(ctx, client, io.Discard, "table", "abc123", opts, false)
+		require.NoError(t, err)
+		require.NotNil(t, client.lastOptions)
+		assert.Equal(t, "New body", client.lastOptions.Description)
+	})
+
+	t.Run("append flag is registered", func(t *testing.T) {
+		assert.NotNil(t, taskUpdateCmd.Flags().Lookup("append-description"))
+	})
+}
+
+// fakeTaskLinker records dependency calls
+type fakeTaskLinker struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeTaskLinker) AddDependency(ctx context.Context, taskID, otherID, depType string) error {
+	f.calls = append(f.calls, fmt.Sprintf("add %s %s %s", taskID, otherID, depType))
+	return f.err
+}
+
+func (f *fakeTaskLinker) RemoveDependency(ctx context.Context, taskID, otherID, depType string) error {
+	f.calls = append(f.calls, fmt.Sprintf("remove %s %s %s", taskID, otherID, depType))
+	return f.err
+}
+
+func TestRunTaskLink(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		depType  string
+		unlink   bool
+		wantCall string
+		wantOut  string
+	}{
+		{"link waiting on", api.DependencyWaitingOn, false, "add a b waiting-on", "Added dependency: a is waiting on b"},
+		{"link blocks", api.DependencyBlocks, false, "add a b blocks", "Added dependency: a blocks b"},
+		{"unlink waiting on", api.DependencyWaitingOn, true, "remove a b waiting-on", "Removed dependency: a is waiting on b"},
+		{"unlink blocks", api.DependencyBlocks, true, "remove a b blocks", "Removed dependency: a blocks b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskLinker{}
+			var buf bytes.Buffer
+
+			require.NoError(t, runTaskLink(ctx, client, &buf, "a", "b", tt.depType, tt.unlink))
+			assert.Equal(t, []string{tt.wantCall}, client.calls)
+			assert.Contains(t, buf.String(), tt.wantOut)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		client := &fakeTaskLinker{err: stderrors.New("boom")}
+		var buf bytes.Buffer
+
+		err := runTaskLink(ctx, client, &buf, "a", "b", api.DependencyBlocks, false)
+		assert.ErrorContains(t, err, "failed to add dependency: boom")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("commands are registered", func(t *testing.T) {
+		for _, name := range []string{"link", "unlink"} {
+			cmd, _, err := taskCmd.Find([]string{name})
+			require.NoError(t, err)
+			assert.Equal(t, name, cmd.Name())
+			assert.Equal(t, api.DependencyWaitingOn, cmd.Flags().Lookup("type").DefValue)
+		}
+	})
+}
+
+// fakeSelfAssigner records the update sent for the current user
+type fakeSelfAssigner struct {
+	userID  int
+	taskID  string
+	options *api.TaskUpdateOptions
+}
+
+func (f *fakeSelfAssigner) GetCurrentUser(ctx context.Context) (*clickup.User, error) {
+	return &clickup.User{ID: f.userID}, nil
+}
+
+func (f *fakeSelfAssigner) UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error) {
+	f.taskID = taskID
+	f.options = options
+	return &clickup.Task{ID: taskID}, nil
+}
+
+func TestRunTaskAssignMe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("assign adds current user", func(t *testing.T) {
+		client := &fakeSelfAssigner{userID: 42}
+
+		task, err := runTaskAssignMe(ctx, client, "abc", false)
+		require.NoError(t, err)
+		assert.Equal(t, "abc", task.ID)
+		assert.Equal(t, []string{"42"}, client.options.AddAssignees)
+		assert.Empty(t, client.options.RemoveAssignees)
+	})
+
+	t.Run("unassign removes current user", func(t *testing.T) {
+		client := &fakeSelfAssigner{userID: 42}
+
+		_, err := runTaskAssignMe(ctx, client, "abc", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"42"}, client.options.RemoveAssignees)
+		assert.Empty(t, client.options.AddAssignees)
+	})
+
+	t.Run("commands are registered", func(t *testing.T) {
+		for _, name := range []string{"assign-me", "unassign-me"} {
+			cmd, _, err := taskCmd.Find([]string{name})
+			require.NoError(t, err)
+			assert.Equal(t, name, cmd.Name())
+		}
+	})
+}
+
+// fakeTaskBumper serves one task with the given priority and records updates
+type fakeTaskBumper struct {
+	priority string
+	updated  string
+	updates  int
+}
+
+func (f *fakeTaskBumper) GetTask(ctx context.Context, taskID string) (*clickup.Task, error) {
+	return &clickup.Task{ID: taskID, Priority: clickup.TaskPriority{Priority: f.priority}}, nil
+}
+
+func (f *fakeTaskBumper) UpdateTask(ctx context.Context, taskID string, options *api.TaskUpdateOptions) (*clickup.Task, error) {
+	f.updates++
+	f.updated = options.Priority
+	return &clickup.Task{ID: taskID, Priority: clickup.TaskPriority{Priority: options.Priority}}, nil
+}
+
+func TestRunTaskBump(t *testing.T) {
+	tests := []struct {
+		priority string
+		up       string
+		down     string
+	}{
+		{"urgent", "urgent", "high"},
+		{"high", "urgent", "normal"},
+		{"normal", "high", "low"},
+		{"low", "normal", "low"},
+		{"", "high", "low"},
+	}
+
+	for _, tt := range tests {
+		for direction, want := range map[string]string{"up": tt.up, "down": tt.down} {
+			t.Run(tt.priority+" "+direction, func(t *testing.T) {
+				client := &fakeTaskBumper{priority: tt.priority}
+
+				result, err := runTaskBump(context.Background(), client, "abc", direction)
+				require.NoError(t, err)
+				assert.Equal(t, want, result.To)
+
+				if result.From == want {
+					assert.Zero(t, client.updates, "clamped priority is not updated")
+				} else {
+					assert.Equal(t, 1, client.updates)
+					assert.Equal(t, want, client.updated)
+					assert.Equal(t, want, result.Task.Priority.Priority)
+				}
+			})
+		}
+	}
+
+	t.Run("task bump is registered", func(t *testing.T) {
+		assert.NotNil(t, taskBumpCmd.Flags().Lookup("priority"))
+	})
+}
+
+// fakeListResolver resolves lists by ID or case-insensitive name like
+// api.Client.ResolveList
+type fakeListResolver struct {
+	lists []clickup.List
+	calls []string
+}
+
+func (f *fakeListResolver) ResolveList(ctx context.Context, nameOrID string) (*clickup.List, error) {
+	f.calls = append(f.calls, nameOrID)
+
+	var matches []clickup.List
+	for _, list := range f.lists {
+		if list.ID == nameOrID {
+			return &list, nil
+		}
+		if strings.EqualFold(list.Name, nameOrID) {
+			matches = append(matches, list)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.NewUserError(fmt.Sprintf("List %q not found", nameOrID), "", errors.ErrNotFound)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, errors.NewUserError(fmt.Sprintf("%d lists are named %q", len(matches), nameOrID), "", errors.ErrInvalidInput)
+	}
+}
+
+func TestResolveCreateListID(t *testing.T) {
+	t.Cleanup(config.Reset)
+
+	client := &fakeListResolver{lists: []clickup.List{
+		{ID: "901", Name: "Sprint Backlog"},
+		{ID: "902", Name: "Bugs"},
+		{ID: "903", Name: "bugs"},
+	}}
+
+	t.Run("list ID", func(t *testing.T) {
+		listID, err := resolveCreateListID(context.Background(), client, "902")
+		require.NoError(t, err)
+		assert.Equal(t, "902", listID)
+	})
+
+	t.Run("unique list name", func(t *testing.T) {
+		listID, err := resolveCreateListID(context.Background(), client, "sprint backlog")
+		require.NoError(t, err)
+		assert.Equal(t, "901", listID)
+	})
+
+	t.Run("ambiguous list name", func(t *testing.T) {
+		_, err := resolveCreateListID(context.Background(), client, "Bugs")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), `2 lists are named "Bugs"`)
+	})
+
+	t.Run("default list is used as is", func(t *testing.T) {
+		config.Reset()
+		config.Set("default_list", "904")
+		client.calls = nil
+
+		listID, err := resolveCreateListID(context.Background(), client, "")
+		require.NoError(t, err)
+		assert.Equal(t, "904", listID)
+		assert.Empty(t, client.calls)
+	})
+
+	t.Run("no list", func(t *testing.T) {
+		config.Reset()
+
+		_, err := resolveCreateListID(context.Background(), client, "")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/cobra"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
+)
+
+// templateFuncs are the task helpers available to --template and the
+// built-in task templates
+var templateFuncs = template.FuncMap{
+	"status":    getTaskStatus,
+	"priority":  getTaskPriority,
+	"assignees": taskAssigneeNames,
+	"due":       taskDueDay,
+}
+
+// taskTemplates are the built-in templates selected with --template-name
+var taskTemplates = map[string]string{
+	"oneline": `{{.ID}} {{.Name}}`,
+	"short":   `{{.ID}} [{{status .}}] {{.Name}}`,
+	"long": `{{.ID}} {{.Name}}
+  status: {{status .}}, priority: {{priority .}}, due: {{due . | default "none"}}, assignees: {{assignees . | default "none"}}
+{{- with .URL}}
+  {{.}}{{end}}`,
+}
+
+// taskTemplateNames returns the names of the built-in task templates in order
+func taskTemplateNames() []string {
+	names := make([]string, 0, len(taskTemplates))
+	for name := range taskTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addTemplateNameFlag adds --template-name to a command that prints tasks
+func addTemplateNameFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template-name", "", fmt.Sprintf("Render tasks with a built-in template (%s); overrides --template", strings.Join(taskTemplateNames(), ", ")))
+}
+
+// applyTemplateName switches output to the built-in template named by
+// --template-name. Without it, a custom --template is left in place.
+func applyTemplateName(cmd *cobra.Command) error {
+	name, _ := cmd.Flags().GetString("template-name")
+	if name == "" {
+		return nil
+	}
+
+	text, ok := taskTemplates[strings.ToLower(name)]
+	if !ok {
+		return errors.NewUserError(
+			fmt.Sprintf("Unknown template '%s'", name),
+			fmt.Sprintf("Use one of: %s, or pass your own with --template", strings.Join(taskTemplateNames(), ", ")),
+			errors.ErrInvalidInput,
+		)
+	}
+
+	output.SetTemplate(text, templateFuncs)
+	return cmd.Flag("output").Value.Set("template")
+}
+
+// taskAssigneeNames returns the usernames of a task's assignees, comma
+// separated
+func taskAssigneeNames(task clickup.Task) string {
+	names := make([]string, 0, len(task.Assignees))
+	for _, assignee := range task.Assignees {
+		names = append(names, assignee.Username)
+	}
+	return strings.Join(names, ", ")
+}
+
+// taskDueDay returns a task's local due day as YYYY-MM-DD, or an empty
+// string without a due date
+func taskDueDay(task clickup.Task) string {
+	if !hasDueDate(task) {
+		return ""
+	}
+	return task.DueDate.Time().Local().Format(time.DateOnly)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/raksul/go-clickup/clickup"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
+)
+
+func TestTaskTemplates(t *testing.T) {
+	task := clickup.Task{
+		ID:        "abc123",
+		Name:      "Write release notes",
+		Status:    clickup.TaskStatus{Status: "in progress"},
+		Priority:  clickup.TaskPriority{Priority: "high"},
+		Assignees: []clickup.User{{Username: "alice"}, {Username: "bob"}},
+		DueDate:   clickup.NewDate(time.Date(2026, 3, 20, 17, 0, 0, 0, time.Local)),
+		URL:       "https://app.clickup.com/t/abc123",
+	}
+
+	tests := []struct {
+		name string
+		task clickup.Task
+		want string
+	}{
+		{"oneline", task, "abc123 Write release notes\n"},
+		{"short", task, "abc123 [in progress] Write release notes\n"},
+		{"long", task, "abc123 Write release notes\n" +
+			"  status: in progress, priority: high, due: 2026-03-20, assignees: alice, bob\n" +
+			"  https://app.clickup.com/t/abc123\n"},
+		{"long", clickup.Task{ID: "def456", Name: "Bare", Status: clickup.TaskStatus{Status: "open"}}, "def456 Bare\n" +
+			"  status: open, priority: Normal, due: none, assignees: none\n"},
+	}
+
+	assert.ElementsMatch(t, []string{"long", "oneline", "short"}, taskTemplateNames())
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			formatter := &output.TemplateFormatter{Template: taskTemplates[test.name], Funcs: templateFuncs}
+
+			var list bytes.Buffer
+			formatter.Writer = &list
+			require.NoError(t, formatter.Format([]clickup.Task{test.task}))
+			assert.Equal(t, test.want, list.String())
+
+			// task view renders a single task pointer
+			var single bytes.Buffer
+			formatter.Writer = &single
+			require.NoError(t, formatter.Format(&test.task))
+			assert.Equal(t, test.want, single.String())
+		})
+	}
+}
+
+func TestApplyTemplateName(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().String("output", "table", "")
+		addTemplateNameFlag(cmd)
+		return cmd
+	}
+	defer output.SetTemplate("", nil)
+
+	t.Run("selects the built-in template", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.Flags().Set("template-name", "oneline"))
+
+		require.NoError(t, applyTemplateName(cmd))
+		assert.Equal(t, "template", cmd.Flag("output").Value.String())
+
+		var buf bytes.Buffer
+		require.NoError(t, output.FormatTo(&buf, "template", []clickup.Task{{ID: "1", Name: "One"}}))
+		assert.Equal(t, "1 One\n", buf.String())
+	})
+
+	t.Run("leaves output alone without a name", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, applyTemplateName(cmd))
+		assert.Equal(t, "table", cmd.Flag("output").Value.String())
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.Flags().Set("template-name", "fancy"))
+
+		err := applyTemplateName(cmd)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "long, oneline, short")
+	})
+}
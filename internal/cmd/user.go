@@ -3,11 +3,16 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/timimsms/cu/internal/api"
-	"github.com/timimsms/cu/internal/cache"
+	"github.com/timimsms/cu/internal/errors"
 	"github.com/timimsms/cu/internal/output"
 )
 
@@ -20,16 +25,12 @@ var userCmd = &cobra.Command{
 var userListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List workspace users",
-	Long:  `List all users in your ClickUp workspace.`,
+	Long: `List all users in your ClickUp workspace. Use --all-workspaces to list the
+members of every workspace you can access.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		// Initialize caches if not already done
-		if cache.UserCache == nil {
-			if err := cache.InitCaches(); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to initialize cache: %v\n", err)
-			}
-		}
+		initCaches()
 
 		// Create API client
 		client, err := api.NewClient()
@@ -50,6 +51,31 @@ var userListCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		format := cmd.Flag("output").Value.String()
+
+		if noHeader, _ := cmd.Flags().GetBool("no-header"); noHeader {
+			output.SetTableHeader(false)
+		}
+
+		allWorkspaces, _ := cmd.Flags().GetBool("all-workspaces")
+		if allWorkspaces {
+			groups, err := collectWorkspaceUsers(ctx, client, workspaces, os.Stderr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			if format == "table" {
+				err = printWorkspaceUsers(os.Stdout, groups)
+			} else {
+				err = output.Format(format, groups)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		// For now, use the first workspace
 		workspace := workspaces[0]
 
@@ -60,33 +86,24 @@ var userListCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		// Get all users
+		// Get all users, in a stable order so --limit is repeatable
 		users := client.UserLookup().GetAllUsers()
+		sort.SliceStable(users, func(i, j int) bool {
+			return strings.ToLower(users[i].Username) < strings.ToLower(users[j].Username)
+		})
+		limit, _ := cmd.Flags().GetInt("limit")
+		users = applyOffsetLimit(users, 0, limit)
 
 		// Format output
-		format := cmd.Flag("output").Value.String()
-
 		if format == "table" {
-			// Prepare table data
-			type userRow struct {
-				ID       int    `json:"id"`
-				Username string `json:"username"`
-				Email    string `json:"email"`
-				Role     string `json:"role"`
-			}
-
-			var rows []userRow
-			for _, user := range users {
-				row := userRow{
-					ID:       user.ID,
-					Username: user.Username,
-					Email:    user.Email,
-					Role:     fmt.Sprintf("%d", user.Role), // Convert role to string
-				}
-				rows = append(rows, row)
+			headers, rows := userTable(users)
+			if err := output.PrintTable(os.Stdout, headers, rows); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
 			}
-
-			if err := output.Format(format, rows); err != nil {
+		} else if format == "csv" {
+			headers, rows := userTable(users)
+			if err := output.Format(format, append([][]string{headers}, rows...)); err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
 				os.Exit(1)
 			}
@@ -100,6 +117,214 @@ var userListCmd = &cobra.Command{
 	},
 }
 
+var userViewCmd = &cobra.Command{
+	Use:   "view <username|email|id>",
+	Short: "Show a workspace user",
+	Long: `Show one member of your ClickUp workspace. The user can be given as a
+username, an email address or a numeric user ID.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		initCaches()
+
+		client, err := api.NewClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create API client: %v\n", err)
+			os.Exit(1)
+		}
+
+		workspaces, err := client.GetWorkspaces(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get workspaces: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(workspaces) == 0 {
+			fmt.Fprintln(os.Stderr, "No workspaces found")
+			os.Exit(1)
+		}
+
+		if err := client.UserLookup().LoadWorkspaceUsers(ctx, workspaces[0].ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load users: %v\n", err)
+			os.Exit(1)
+		}
+
+		user, err := findUser(client.UserLookup(), args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		format := cmd.Flag("output").Value.String()
+		if format == "table" {
+			printUserDetails(os.Stdout, user)
+		} else {
+			if err := output.Format(format, user); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
 func init() {
 	userCmd.AddCommand(userListCmd)
+	userCmd.AddCommand(userViewCmd)
+
+	userListCmd.Flags().Bool("all-workspaces", false, "List members of every workspace, grouped by workspace")
+	userListCmd.Flags().Bool("no-header", false, "Omit the header row of table output")
+	userListCmd.Flags().Int("limit", 0, "Maximum number of users to show (0 for no limit)")
+	userListCmd.MarkFlagsMutuallyExclusive("limit", "all-workspaces")
+}
+
+// userRow is a workspace user as shown in user tables and details
+type userRow struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+}
+
+func newUserRow(user *clickup.TeamUser) userRow {
+	return userRow{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     userRoleName(user.Role),
+	}
+}
+
+// userTable returns the headers and rows of a user table
+func userTable(users []*clickup.TeamUser) ([]string, [][]string) {
+	headers := []string{"id", "username", "email", "role"}
+
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		row := newUserRow(user)
+		rows = append(rows, []string{strconv.Itoa(row.ID), row.Username, row.Email, row.Role})
+	}
+
+	return headers, rows
+}
+
+// userRoleNames maps ClickUp's numeric workspace roles to their names
+var userRoleNames = map[int]string{
+	1: "Owner",
+	2: "Admin",
+	3: "Member",
+	4: "Guest",
+}
+
+// userRoleName returns the name of a workspace role. Members returned
+// without a role have role 0 and are shown as a dash; unknown roles keep
+// their number.
+func userRoleName(role int) string {
+	if role == 0 {
+		return "—"
+	}
+	if name, ok := userRoleNames[role]; ok {
+		return name
+	}
+	return strconv.Itoa(role)
+}
+
+// workspaceUsers is the member list of one workspace
+type workspaceUsers struct {
+	WorkspaceID string             `json:"workspace_id"`
+	Workspace   string             `json:"workspace"`
+	Members     []clickup.TeamUser `json:"members"`
+}
+
+// workspaceMemberLister is the part of the API client used by user list --all-workspaces
+type workspaceMemberLister interface {
+	GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]clickup.TeamUser, error)
+}
+
+// collectWorkspaceUsers fetches the members of each workspace, dropping
+// repeated user IDs within a workspace and sorting members by username.
+// Workspaces whose members can't be fetched are reported to warn and skipped;
+// an error is returned only when every workspace failed.
+func collectWorkspaceUsers(ctx context.Context, client workspaceMemberLister, workspaces []clickup.Team, warn io.Writer) ([]workspaceUsers, error) {
+	groups := make([]workspaceUsers, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		members, err := client.GetWorkspaceMembers(ctx, workspace.ID)
+		if err != nil {
+			fmt.Fprintf(warn, "Warning: failed to get members of workspace %s: %v\n", workspace.Name, err)
+			continue
+		}
+
+		seen := make(map[int]bool, len(members))
+		unique := make([]clickup.TeamUser, 0, len(members))
+		for _, member := range members {
+			if seen[member.ID] {
+				continue
+			}
+			seen[member.ID] = true
+			unique = append(unique, member)
+		}
+		sort.SliceStable(unique, func(i, j int) bool {
+			return strings.ToLower(unique[i].Username) < strings.ToLower(unique[j].Username)
+		})
+
+		groups = append(groups, workspaceUsers{
+			WorkspaceID: workspace.ID,
+			Workspace:   workspace.Name,
+			Members:     unique,
+		})
+	}
+
+	if len(groups) == 0 && len(workspaces) > 0 {
+		return nil, fmt.Errorf("failed to get the members of any workspace")
+	}
+	return groups, nil
+}
+
+// printWorkspaceUsers renders each workspace's members as a table under a
+// "name (count)" heading
+func printWorkspaceUsers(w io.Writer, groups []workspaceUsers) error {
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%d)\n", group.Workspace, len(group.Members))
+
+		members := make([]*clickup.TeamUser, 0, len(group.Members))
+		for i := range group.Members {
+			members = append(members, &group.Members[i])
+		}
+		headers, rows := userTable(members)
+		if err := output.PrintTable(w, headers, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userFinder resolves a user from a username, email address or ID
+type userFinder interface {
+	Lookup(identifier string) (*clickup.TeamUser, error)
+}
+
+// findUser looks up a single workspace member, turning a miss into an error
+// that points at 'cu user list'
+func findUser(users userFinder, identifier string) (*clickup.TeamUser, error) {
+	user, err := users.Lookup(identifier)
+	if err != nil {
+		return nil, errors.NewUserError(
+			fmt.Sprintf("No workspace member matches '%s'", identifier),
+			"Run 'cu user list' to see workspace members",
+			errors.ErrNotFound,
+		)
+	}
+	return user, nil
+}
+
+// printUserDetails writes a user's details as aligned key-value lines
+func printUserDetails(w io.Writer, user *clickup.TeamUser) {
+	row := newUserRow(user)
+	fmt.Fprintf(w, "ID:       %d\n", row.ID)
+	fmt.Fprintf(w, "Username: %s\n", row.Username)
+	fmt.Fprintf(w, "Email:    %s\n", row.Email)
+	fmt.Fprintf(w, "Role:     %s\n", row.Role)
 }
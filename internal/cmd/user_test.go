@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"testing"
 
+	"github.com/raksul/go-clickup/clickup"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timimsms/cu/internal/errors"
+	"github.com/timimsms/cu/internal/output"
 )
 
 func TestUserCommand_Structure(t *testing.T) {
@@ -40,3 +47,153 @@ func TestUserCommand_Structure(t *testing.T) {
 		}
 	})
 }
+
+// fakeUserFinder finds users by exact identifier
+type fakeUserFinder map[string]*clickup.TeamUser
+
+func (f fakeUserFinder) Lookup(identifier string) (*clickup.TeamUser, error) {
+	if user, ok := f[identifier]; ok {
+		return user, nil
+	}
+	return nil, fmt.Errorf("user not found: %s", identifier)
+}
+
+func TestFindUser(t *testing.T) {
+	alice := &clickup.TeamUser{ID: 100, Username: "alice", Email: "alice@example.com"}
+	finder := fakeUserFinder{"alice": alice, "alice@example.com": alice, "100": alice}
+
+	for _, identifier := range []string{"alice", "alice@example.com", "100"} {
+		t.Run(identifier, func(t *testing.T) {
+			user, err := findUser(finder, identifier)
+			require.NoError(t, err)
+			assert.Equal(t, alice, user)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		user, err := findUser(finder, "carol")
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		assert.Contains(t, err.Error(), "No workspace member matches 'carol'")
+	})
+}
+
+func TestPrintUserDetails(t *testing.T) {
+	var buf bytes.Buffer
+	printUserDetails(&buf, &clickup.TeamUser{ID: 100, Username: "alice", Email: "alice@example.com", Role: 3})
+
+	assert.Equal(t, "ID:       100\nUsername: alice\nEmail:    alice@example.com\nRole:     Member\n", buf.String())
+}
+
+func TestUserRoleName(t *testing.T) {
+	tests := map[int]string{
+		0: "—",
+		1: "Owner",
+		2: "Admin",
+		3: "Member",
+		4: "Guest",
+		9: "9",
+	}
+
+	for role, want := range tests {
+		assert.Equal(t, want, userRoleName(role), "role %d", role)
+	}
+}
+
+func TestNewUserRow(t *testing.T) {
+	t.Run("names the role", func(t *testing.T) {
+		row := newUserRow(&clickup.TeamUser{ID: 1, Username: "alice", Role: 2})
+		assert.Equal(t, "Admin", row.Role)
+	})
+
+	t.Run("missing role", func(t *testing.T) {
+		row := newUserRow(&clickup.TeamUser{ID: 2, Username: "bob"})
+		assert.Equal(t, "—", row.Role)
+	})
+
+	t.Run("json output keeps the number", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, output.FormatTo(&buf, "json", &clickup.TeamUser{ID: 1, Role: 2}))
+		assert.Contains(t, buf.String(), `"role": 2`)
+	})
+}
+
+// fakeMemberLister returns fixed members per workspace ID
+type fakeMemberLister struct {
+	members map[string][]clickup.TeamUser
+	errs    map[string]error
+}
+
+func (f *fakeMemberLister) GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]clickup.TeamUser, error) {
+	if err := f.errs[workspaceID]; err != nil {
+		return nil, err
+	}
+	return f.members[workspaceID], nil
+}
+
+func TestCollectWorkspaceUsers(t *testing.T) {
+	workspaces := []clickup.Team{{ID: "1", Name: "Acme"}, {ID: "2", Name: "Side project"}}
+	client := &fakeMemberLister{
+		members: map[string][]clickup.TeamUser{
+			"1": {
+				{ID: 20, Username: "bob", Role: 3},
+				{ID: 10, Username: "alice", Role: 1},
+				{ID: 20, Username: "bob", Role: 3},
+			},
+			"2": {
+				{ID: 10, Username: "alice", Role: 4},
+			},
+		},
+	}
+
+	t.Run("groups by workspace and dedupes within a group", func(t *testing.T) {
+		var warn bytes.Buffer
+		groups, err := collectWorkspaceUsers(context.Background(), client, workspaces, &warn)
+		require.NoError(t, err)
+
+		require.Len(t, groups, 2)
+		assert.Equal(t, "Acme", groups[0].Workspace)
+		require.Len(t, groups[0].Members, 2)
+		assert.Equal(t, "alice", groups[0].Members[0].Username)
+		assert.Equal(t, "bob", groups[0].Members[1].Username)
+		assert.Equal(t, "Side project", groups[1].Workspace)
+		require.Len(t, groups[1].Members, 1)
+		assert.Equal(t, 10, groups[1].Members[0].ID)
+		assert.Empty(t, warn.String())
+
+		var out bytes.Buffer
+		require.NoError(t, printWorkspaceUsers(&out, groups))
+		assert.Contains(t, out.String(), "Acme (2)\n")
+		assert.Contains(t, out.String(), "\n\nSide project (1)\n")
+		assert.Contains(t, out.String(), "Guest")
+	})
+
+	t.Run("warns and continues when a workspace fails", func(t *testing.T) {
+		failing := &fakeMemberLister{
+			members: client.members,
+			errs:    map[string]error{"1": fmt.Errorf("forbidden")},
+		}
+
+		var warn bytes.Buffer
+		groups, err := collectWorkspaceUsers(context.Background(), failing, workspaces, &warn)
+		require.NoError(t, err)
+
+		require.Len(t, groups, 1)
+		assert.Equal(t, "Side project", groups[0].Workspace)
+		assert.Equal(t, "Warning: failed to get members of workspace Acme: forbidden\n", warn.String())
+	})
+
+	t.Run("fails when every workspace fails", func(t *testing.T) {
+		failing := &fakeMemberLister{
+			errs: map[string]error{"1": fmt.Errorf("forbidden"), "2": fmt.Errorf("timeout")},
+		}
+
+		var warn bytes.Buffer
+		_, err := collectWorkspaceUsers(context.Background(), failing, workspaces, &warn)
+		assert.EqualError(t, err, "failed to get the members of any workspace")
+		assert.Equal(t,
+			"Warning: failed to get members of workspace Acme: forbidden\n"+
+				"Warning: failed to get members of workspace Side project: timeout\n",
+			warn.String())
+	})
+}
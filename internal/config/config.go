@@ -5,20 +5,30 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/viper"
+	"github.com/timimsms/cu/internal/fsutil"
+	"gopkg.in/yaml.v3"
 )
 
 // Config represents the application configuration
 type Config struct {
-	DefaultSpace  string            `mapstructure:"default_space"`
-	DefaultFolder string            `mapstructure:"default_folder"`
-	DefaultList   string            `mapstructure:"default_list"`
-	Output        string            `mapstructure:"output"`
-	Debug         bool              `mapstructure:"debug"`
-	APIToken      string            `mapstructure:"api_token"`
-	Workspaces    map[string]string `mapstructure:"workspaces"`
+	DefaultSpace       string            `mapstructure:"default_space"`
+	DefaultFolder      string            `mapstructure:"default_folder"`
+	DefaultList        string            `mapstructure:"default_list"`
+	Output             string            `mapstructure:"output"`
+	Debug              bool              `mapstructure:"debug"`
+	NoColor            bool              `mapstructure:"no_color"`
+	CompactJSON        bool              `mapstructure:"compact_json"`
+	TimeFormat         string            `mapstructure:"time_format"`
+	RateLimit          int               `mapstructure:"rate_limit"`
+	CacheEncrypt       bool              `mapstructure:"cache_encrypt"`
+	CacheTTLTasks      string            `mapstructure:"cache_ttl_tasks"`
+	CacheTTLWorkspaces string            `mapstructure:"cache_ttl_workspaces"`
+	APIToken           string            `mapstructure:"api_token"`
+	Workspaces         map[string]string `mapstructure:"workspaces"`
 }
 
 var (
@@ -34,6 +44,9 @@ var (
 	// Track if we're in a project with config
 	hasProjectConfig  bool
 	projectConfigPath string
+
+	// changed holds the values set since the last Save
+	changed = map[string]interface{}{}
 )
 
 // Init initializes the configuration
@@ -44,13 +57,13 @@ func Init(cfgFile string) error {
 	}
 
 	// Set default values
-	viper.SetDefault("output", "table")
+	viper.SetDefault("output", "auto")
 	viper.SetDefault("debug", false)
 
 	// Look for project config file in current directory and parent directories
 	projectConfigPath = findProjectConfig()
-	if projectConfigPath != "" {
-		hasProjectConfig = true
+	hasProjectConfig = projectConfigPath != ""
+	if hasProjectConfig {
 		projectViper := viper.New()
 		projectViper.SetConfigFile(projectConfigPath)
 
@@ -67,6 +80,12 @@ func Init(cfgFile string) error {
 	return nil
 }
 
+// Reset clears viper and any changes not yet saved
+func Reset() {
+	viper.Reset()
+	changed = map[string]interface{}{}
+}
+
 // Load loads the configuration from file
 func Load() (*Config, error) {
 	var cfg Config
@@ -76,10 +95,74 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save saves the current configuration to file
+// Save writes the values changed with Set to the global config file. Keys
+// the file already has are kept and nothing else is added, so flag
+// overrides and project settings merged into viper stay out of it. The
+// file is replaced atomically so an interrupted save never leaves a
+// truncated config behind.
 func Save() error {
-	configPath := filepath.Join(DefaultConfigDir, ConfigFileName+"."+ConfigType)
-	return viper.WriteConfigAs(configPath)
+	fileViper, err := readGlobalConfig()
+	if err != nil {
+		return err
+	}
+	for key, value := range changed {
+		fileViper.Set(key, value)
+	}
+
+	if err := writeConfigFile(globalConfigPath(), fileViper.AllSettings()); err != nil {
+		return err
+	}
+	changed = map[string]interface{}{}
+	return nil
+}
+
+// globalConfigPath returns the path of the global config file
+func globalConfigPath() string {
+	return filepath.Join(DefaultConfigDir, ConfigFileName+"."+ConfigType)
+}
+
+// readGlobalConfig reads the global config file into its own viper, so its
+// keys can be told apart from flags, defaults and project settings
+func readGlobalConfig() (*viper.Viper, error) {
+	fileViper := viper.New()
+	fileViper.SetConfigFile(globalConfigPath())
+	if _, err := os.Stat(globalConfigPath()); err == nil {
+		if err := fileViper.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+	return fileViper, nil
+}
+
+// deleteSetting removes a dotted key from nested settings
+func deleteSetting(settings map[string]interface{}, key string) {
+	parts := strings.Split(strings.ToLower(key), ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := settings[part].(map[string]interface{})
+		if !ok {
+			return
+		}
+		settings = next
+	}
+	delete(settings, parts[len(parts)-1])
+}
+
+// writeConfigFile encodes settings as YAML and atomically writes them to path
+func writeConfigFile(path string, settings map[string]interface{}) error {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".yml" && ext != ".yaml" {
+		return fmt.Errorf("unsupported config file type: %s", filepath.Base(path))
+	}
+
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := fsutil.WriteFileAtomic(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
 }
 
 // Get returns a configuration value
@@ -87,9 +170,29 @@ func Get(key string) interface{} {
 	return viper.Get(key)
 }
 
-// Set sets a configuration value
+// Set sets a configuration value. The next Save writes it to the global
+// config file.
 func Set(key string, value interface{}) {
 	viper.Set(key, value)
+	changed[key] = value
+}
+
+// Unset removes a key from the global configuration file
+func Unset(key string) error {
+	fileViper, err := readGlobalConfig()
+	if err != nil {
+		return err
+	}
+	settings := fileViper.AllSettings()
+	deleteSetting(settings, key)
+	if err := writeConfigFile(globalConfigPath(), settings); err != nil {
+		return err
+	}
+
+	delete(changed, key)
+	// A nil override would fall back to the value viper read from the file
+	viper.Set(key, "")
+	return nil
 }
 
 // GetString returns a string configuration value
@@ -102,6 +205,16 @@ func GetBool(key string) bool {
 	return viper.GetBool(key)
 }
 
+// GetInt returns an integer configuration value
+func GetInt(key string) int {
+	return viper.GetInt(key)
+}
+
+// GetStringSlice returns a string slice configuration value
+func GetStringSlice(key string) []string {
+	return viper.GetStringSlice(key)
+}
+
 // findProjectConfig looks for .cu.yml in current directory and parent directories
 func findProjectConfig() string {
 	dir, err := os.Getwd()
@@ -148,6 +261,20 @@ func GetProjectConfigPath() string {
 	return projectConfigPath
 }
 
+// InProjectConfig reports whether the project config file sets key
+func InProjectConfig(key string) bool {
+	if projectConfigPath == "" {
+		return false
+	}
+
+	projectViper := viper.New()
+	projectViper.SetConfigFile(projectConfigPath)
+	if err := projectViper.ReadInConfig(); err != nil {
+		return false
+	}
+	return projectViper.IsSet(key)
+}
+
 // SaveProjectConfig saves configuration to the project config file
 func SaveProjectConfig(settings map[string]interface{}) error {
 	// If no project config exists, create one in current directory
@@ -161,9 +288,42 @@ func SaveProjectConfig(settings map[string]interface{}) error {
 
 	// Clean and validate the config path
 	projectConfigPath = filepath.Clean(projectConfigPath)
+	if err := checkProjectConfigPath(projectConfigPath); err != nil {
+		return err
+	}
+
+	// Create a new viper instance for project config
+	projectViper := viper.New()
+	projectViper.SetConfigFile(projectConfigPath)
 
+	// If file exists, read current content
+	if _, err := os.Stat(projectConfigPath); err == nil {
+		if err := projectViper.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read existing project config: %w", err)
+		}
+	}
+
+	// Update with new settings
+	for k, v := range settings {
+		projectViper.Set(k, v)
+		// Also update main viper
+		viper.Set(k, v)
+	}
+
+	// Write the file
+	if err := writeConfigFile(projectConfigPath, projectViper.AllSettings()); err != nil {
+		return fmt.Errorf("failed to save project config: %w", err)
+	}
+
+	hasProjectConfig = true
+	return nil
+}
+
+// checkProjectConfigPath rejects a project config path that is neither in
+// the current directory tree nor the .cu.yml found in a parent directory
+func checkProjectConfigPath(path string) error {
 	// Get absolute path for validation
-	absPath, err := filepath.Abs(projectConfigPath)
+	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path: %w", err)
 	}
@@ -177,8 +337,9 @@ func SaveProjectConfig(settings map[string]interface{}) error {
 	// Convert to absolute for comparison
 	absCwd, _ := filepath.Abs(cwd)
 
-	// The config should be within the current directory tree
-	if !strings.HasPrefix(absPath, absCwd) {
+	// The config should be within the current directory tree, or be the
+	// project config that applies to it
+	if !strings.HasPrefix(absPath, absCwd) && absPath != findProjectConfig() {
 		return fmt.Errorf("invalid config path: outside current directory")
 	}
 
@@ -195,42 +356,50 @@ func SaveProjectConfig(settings map[string]interface{}) error {
 		}
 	}
 
-	// Create a new viper instance for project config
-	projectViper := viper.New()
-	projectViper.SetConfigFile(projectConfigPath)
+	return nil
+}
 
-	// If file exists, read current content
-	if _, err := os.Stat(projectConfigPath); err == nil {
-		if err := projectViper.ReadInConfig(); err != nil {
-			return fmt.Errorf("failed to read existing project config: %w", err)
-		}
+// UnsetProjectConfig removes a key from the project config file
+func UnsetProjectConfig(key string) error {
+	if projectConfigPath == "" {
+		return fmt.Errorf("no project config found")
+	}
+	if err := checkProjectConfigPath(projectConfigPath); err != nil {
+		return err
 	}
 
-	// Update with new settings
-	for k, v := range settings {
-		projectViper.Set(k, v)
-		// Also update main viper
-		viper.Set(k, v)
+	projectViper := viper.New()
+	projectViper.SetConfigFile(projectConfigPath)
+	if err := projectViper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read project config: %w", err)
 	}
 
-	// Write the file
-	if err := projectViper.WriteConfig(); err != nil {
-		// If file doesn't exist, create it
-		if os.IsNotExist(err) {
-			if err := projectViper.SafeWriteConfig(); err != nil {
-				return fmt.Errorf("failed to create project config: %w", err)
-			}
-		} else {
-			return fmt.Errorf("failed to write project config: %w", err)
-		}
+	settings := projectViper.AllSettings()
+	delete(settings, key)
+	if err := writeConfigFile(projectConfigPath, settings); err != nil {
+		return fmt.Errorf("failed to save project config: %w", err)
 	}
 
-	hasProjectConfig = true
+	viper.Set(key, nil)
 	return nil
 }
 
+// ProjectTemplate holds values written into a new project config file.
+// Empty values are left as commented-out examples.
+type ProjectTemplate struct {
+	Space     string
+	List      string
+	Workspace string
+}
+
 // InitProjectConfig creates a new project config file in the current directory
 func InitProjectConfig() error {
+	return InitProjectConfigWith(ProjectTemplate{})
+}
+
+// InitProjectConfigWith creates a new project config file in the current
+// directory, seeded with the template's values
+func InitProjectConfigWith(tmpl ProjectTemplate) error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get current directory: %w", err)
@@ -258,7 +427,7 @@ func InitProjectConfig() error {
 	projectViper.Set("project_name", filepath.Base(cwd))
 	projectViper.SetDefault("default_list", "")
 	projectViper.SetDefault("default_space", "")
-	projectViper.SetDefault("output", "table")
+	projectViper.SetDefault("output", "auto")
 
 	// Add helpful comments by writing a template
 	template := `# ClickUp CLI Project Configuration
@@ -267,14 +436,18 @@ func InitProjectConfig() error {
 # Project name
 project_name: %s
 
+# Default workspace for this project
+%s
+
 # Default space for this project
-# default_space: "My Space"
+%s
 
 # Default list for task operations
-# default_list: "abc123"
+%s
 
-# Default output format (table|json|yaml|csv)
-output: table
+# Default output format (auto|table|json|yaml|csv). auto, the default, is a
+# table on a terminal and json when piped.
+# output: table
 
 # Team member aliases for easier assignment
 # aliases:
@@ -282,7 +455,12 @@ output: table
 #   jane: jane.smith@example.com
 `
 
-	content := fmt.Sprintf(template, filepath.Base(cwd))
+	content := fmt.Sprintf(template,
+		filepath.Base(cwd),
+		templateSetting("default_workspace", tmpl.Workspace, "My Workspace"),
+		templateSetting("default_space", tmpl.Space, "My Space"),
+		templateSetting("default_list", tmpl.List, "abc123"),
+	)
 	// #nosec G304 - configPath is validated to be within current directory
 	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
 		return fmt.Errorf("failed to write project config: %w", err)
@@ -293,3 +471,12 @@ output: table
 
 	return nil
 }
+
+// templateSetting renders a project config line, commented out with an
+// example value when value is empty
+func templateSetting(key, value, example string) string {
+	if value == "" {
+		return fmt.Sprintf("# %s: %s", key, strconv.Quote(example))
+	}
+	return fmt.Sprintf("%s: %s", key, strconv.Quote(value))
+}
@@ -37,7 +37,7 @@ func TestInit(t *testing.T) {
 
 func TestGetSet(t *testing.T) {
 	// Reset viper for clean test
-	viper.Reset()
+	Reset()
 
 	// Test Set and Get
 	Set("test_key", "test_value")
@@ -56,7 +56,7 @@ func TestGetSet(t *testing.T) {
 
 func TestLoad(t *testing.T) {
 	// Reset viper
-	viper.Reset()
+	Reset()
 
 	// Set some test values
 	viper.Set("default_space", "TestSpace")
@@ -81,7 +81,7 @@ func TestLoad(t *testing.T) {
 
 func TestLoadError(t *testing.T) {
 	// Reset viper
-	viper.Reset()
+	Reset()
 
 	// Set invalid value that can't be unmarshaled
 	viper.Set("debug", "not-a-bool")
@@ -100,8 +100,8 @@ func TestSave(t *testing.T) {
 	defer func() { DefaultConfigDir = oldConfigDir }()
 
 	// Reset viper
-	viper.Reset()
-	viper.Set("test_key", "test_value")
+	Reset()
+	Set("test_key", "test_value")
 
 	err := Save()
 	require.NoError(t, err)
@@ -112,8 +112,75 @@ func TestSave(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSaveAtomic(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldConfigDir := DefaultConfigDir
+	DefaultConfigDir = tmpDir
+	defer func() { DefaultConfigDir = oldConfigDir }()
+
+	configPath := filepath.Join(tmpDir, ConfigFileName+"."+ConfigType)
+	require.NoError(t, os.WriteFile(configPath, []byte("default_list: original\n"), 0600))
+
+	Reset()
+	Set("default_list", "new")
+
+	require.NoError(t, Save())
+
+	data, err := os.ReadFile(configPath) // #nosec G304 - test file
+	require.NoError(t, err)
+	assert.Equal(t, "default_list: new\n", string(data))
+
+	// The temporary file was renamed into place
+	matches, err := filepath.Glob(filepath.Join(tmpDir, "*.tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestSaveWritesOnlyChangedKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldConfigDir := DefaultConfigDir
+	DefaultConfigDir = tmpDir
+	defer func() { DefaultConfigDir = oldConfigDir }()
+
+	configPath := filepath.Join(tmpDir, ConfigFileName+"."+ConfigType)
+	require.NoError(t, os.WriteFile(configPath, []byte("default_space: space1\n"), 0600))
+
+	Reset()
+	// Flag overrides and merged project settings live only in viper
+	viper.Set("cache_ttl", "5s")
+	viper.Set("project_name", "proj")
+	Set("default_list", "list1")
+
+	require.NoError(t, Save())
+
+	data, err := os.ReadFile(configPath) // #nosec G304 - test file
+	require.NoError(t, err)
+	assert.Equal(t, "default_list: list1\ndefault_space: space1\n", string(data))
+}
+
+func TestUnset(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldConfigDir := DefaultConfigDir
+	DefaultConfigDir = tmpDir
+	defer func() { DefaultConfigDir = oldConfigDir }()
+
+	configPath := filepath.Join(tmpDir, ConfigFileName+"."+ConfigType)
+	require.NoError(t, os.WriteFile(configPath, []byte("api_token: secret\ndefault_list: list1\n"), 0600))
+
+	Reset()
+	viper.SetConfigFile(configPath)
+	require.NoError(t, viper.ReadInConfig())
+
+	require.NoError(t, Unset("api_token"))
+
+	data, err := os.ReadFile(configPath) // #nosec G304 - test file
+	require.NoError(t, err)
+	assert.Equal(t, "default_list: list1\n", string(data))
+	assert.Empty(t, GetString("api_token"))
+}
+
 func TestGet(t *testing.T) {
-	viper.Reset()
+	Reset()
 	viper.Set("test_key", "test_value")
 
 	value := Get("test_key")
@@ -148,7 +215,7 @@ output: json
 		// Reset globals
 		hasProjectConfig = false
 		projectConfigPath = ""
-		viper.Reset()
+		Reset()
 
 		// Initialize
 		err := Init("")
@@ -255,7 +322,7 @@ func TestSaveProjectConfig(t *testing.T) {
 		// Reset globals
 		projectConfigPath = ""
 		hasProjectConfig = false
-		viper.Reset()
+		Reset()
 
 		settings := map[string]interface{}{
 			"default_space": "TestSpace",
@@ -291,7 +358,7 @@ output: table`
 		// Reset projectConfigPath to let SaveProjectConfig find it
 		projectConfigPath = ""
 		hasProjectConfig = false
-		viper.Reset()
+		Reset()
 
 		// Initialize project config to find the existing file
 		err := Init("")
@@ -308,9 +375,37 @@ output: table`
 		assert.Equal(t, "NewSpace", viper.GetString("default_space"))
 	})
 
+	t.Run("from a subdirectory of the project", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		childDir := filepath.Join(tmpDir, "child", "subchild")
+		require.NoError(t, os.MkdirAll(childDir, 0750))
+
+		configPath := filepath.Join(tmpDir, ProjectConfigFileName)
+		require.NoError(t, os.WriteFile(configPath, []byte("default_space: OldSpace\noutput: table\n"), 0600))
+
+		oldWd, _ := os.Getwd()
+		require.NoError(t, os.Chdir(childDir))
+		defer func() { _ = os.Chdir(oldWd) }()
+
+		projectConfigPath = ""
+		hasProjectConfig = false
+		Reset()
+		require.NoError(t, Init(""))
+
+		require.NoError(t, SaveProjectConfig(map[string]interface{}{"default_space": "NewSpace"}))
+		require.NoError(t, UnsetProjectConfig("output"))
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "NewSpace")
+		assert.NotContains(t, string(data), "output")
+		_, err = os.Stat(filepath.Join(childDir, ProjectConfigFileName))
+		assert.True(t, os.IsNotExist(err))
+	})
+
 	t.Run("invalid path", func(t *testing.T) {
 		projectConfigPath = "../../../etc/passwd"
-		viper.Reset()
+		Reset()
 
 		err := SaveProjectConfig(map[string]interface{}{})
 		assert.Error(t, err)
@@ -341,7 +436,7 @@ output: table`
 		require.NoError(t, os.Remove(testDir))
 
 		projectConfigPath = ""
-		viper.Reset()
+		Reset()
 
 		err := SaveProjectConfig(map[string]interface{}{})
 		// Error may vary based on when getcwd fails
@@ -373,12 +468,63 @@ func TestInitProjectConfig(t *testing.T) {
 		assert.Contains(t, string(content), "project_name:")
 		assert.Contains(t, string(content), filepath.Base(tmpDir))
 		assert.True(t, hasProjectConfig)
+
+		// The output format is left to auto
+		projectViper := viper.New()
+		projectViper.SetConfigFile(configPath)
+		require.NoError(t, projectViper.ReadInConfig())
+		assert.False(t, projectViper.IsSet("output"))
 		// Compare paths with symlink resolution
 		actualPath, _ := filepath.EvalSymlinks(projectConfigPath)
 		expectedPath, _ := filepath.EvalSymlinks(configPath)
 		assert.Equal(t, expectedPath, actualPath)
 	})
 
+	t.Run("seeded with template values", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldWd, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(oldWd) }()
+
+		projectConfigPath = ""
+		hasProjectConfig = false
+
+		err := InitProjectConfigWith(ProjectTemplate{Space: "space1", List: "list 42", Workspace: "Acme"})
+		require.NoError(t, err)
+
+		configPath := filepath.Join(tmpDir, ProjectConfigFileName)
+		content, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "\ndefault_space: \"space1\"\n")
+		assert.Contains(t, string(content), "\ndefault_list: \"list 42\"\n")
+		assert.Contains(t, string(content), "\ndefault_workspace: \"Acme\"\n")
+
+		projectViper := viper.New()
+		projectViper.SetConfigFile(configPath)
+		require.NoError(t, projectViper.ReadInConfig())
+		assert.Equal(t, "space1", projectViper.GetString("default_space"))
+		assert.Equal(t, "list 42", projectViper.GetString("default_list"))
+		assert.Equal(t, "Acme", projectViper.GetString("default_workspace"))
+	})
+
+	t.Run("defaults stay commented out", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		oldWd, _ := os.Getwd()
+		require.NoError(t, os.Chdir(tmpDir))
+		defer func() { _ = os.Chdir(oldWd) }()
+
+		projectConfigPath = ""
+		hasProjectConfig = false
+
+		require.NoError(t, InitProjectConfig())
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, ProjectConfigFileName))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "# default_space: \"My Space\"")
+		assert.Contains(t, string(content), "# default_list: \"abc123\"")
+		assert.NotContains(t, string(content), "\ndefault_list:")
+	})
+
 	t.Run("config already exists", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		oldWd, _ := os.Getwd()
@@ -445,7 +591,7 @@ func TestEdgeCases(t *testing.T) {
 	})
 
 	t.Run("config with workspaces map", func(t *testing.T) {
-		viper.Reset()
+		Reset()
 		viper.Set("workspaces", map[string]string{
 			"dev":  "dev-token",
 			"prod": "prod-token",
@@ -480,3 +626,33 @@ func TestConfigSafetyChecks(t *testing.T) {
 		}
 	})
 }
+
+func TestWriteConfigFile(t *testing.T) {
+	t.Run("rejects unsupported file types", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+
+		err := writeConfigFile(path, map[string]interface{}{"output": "json"})
+		assert.ErrorContains(t, err, "unsupported config file type")
+
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("writes yaml", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ProjectConfigFileName)
+
+		require.NoError(t, writeConfigFile(path, map[string]interface{}{"default_list": "abc"}))
+
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		assert.Equal(t, "default_list: abc\n", string(data))
+	})
+}
+
+func TestUnsetProjectConfigWithoutProject(t *testing.T) {
+	projectConfigPath = ""
+	hasProjectConfig = false
+
+	err := UnsetProjectConfig("default_list")
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// secretKeyParts mark configuration keys whose values must never be exported
+// or imported
+var secretKeyParts = []string{"token", "secret", "password", "api_key", "apikey"}
+
+// IsSecretKey reports whether a configuration key may hold a credential
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportSettings writes the merged configuration as YAML, leaving out secret
+// keys at any depth
+func ExportSettings(w io.Writer) error {
+	data, err := yaml.Marshal(withoutSecrets(viper.AllSettings()))
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ImportSettings reads YAML settings, applies every non-secret top-level key
+// and saves the global configuration. It returns the imported and skipped
+// keys, sorted.
+func ImportSettings(r io.Reader) ([]string, []string, error) {
+	var settings map[string]interface{}
+	if err := yaml.NewDecoder(r).Decode(&settings); err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	var imported, skipped []string
+	for key, value := range settings {
+		if IsSecretKey(key) {
+			skipped = append(skipped, key)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = withoutSecrets(nested)
+		}
+		Set(key, value)
+		imported = append(imported, key)
+	}
+	sort.Strings(imported)
+	sort.Strings(skipped)
+
+	if len(imported) == 0 {
+		return imported, skipped, nil
+	}
+	if err := Save(); err != nil {
+		return nil, nil, err
+	}
+	return imported, skipped, nil
+}
+
+// withoutSecrets returns a copy of settings with secret keys removed
+func withoutSecrets(settings map[string]interface{}) map[string]interface{} {
+	filtered := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		if IsSecretKey(key) {
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = withoutSecrets(nested)
+		}
+		filtered[key] = value
+	}
+	return filtered
+}
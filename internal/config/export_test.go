@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	assert.True(t, IsSecretKey("api_token"))
+	assert.True(t, IsSecretKey("Client_Secret"))
+	assert.True(t, IsSecretKey("password"))
+	assert.False(t, IsSecretKey("default_list"))
+	assert.False(t, IsSecretKey("cache_encrypt"))
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldConfigDir := DefaultConfigDir
+	DefaultConfigDir = tmpDir
+	defer func() { DefaultConfigDir = oldConfigDir }()
+	defer Reset()
+
+	Reset()
+	viper.Set("default_list", "list123")
+	viper.Set("output", "json")
+	viper.Set("api_token", "pk_secret")
+	viper.Set("aliases", map[string]interface{}{"john": "john@example.com", "token": "pk_nested"})
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportSettings(&buf))
+	exported := buf.String()
+	assert.Contains(t, exported, "default_list: list123")
+	assert.Contains(t, exported, "john: john@example.com")
+	assert.NotContains(t, exported, "pk_secret")
+	assert.NotContains(t, exported, "pk_nested")
+
+	// A hand-edited export with a token must not bring the token back
+	Reset()
+	input := exported + "api_token: pk_injected\n"
+	imported, skipped, err := ImportSettings(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"aliases", "default_list", "output"}, imported)
+	assert.Equal(t, []string{"api_token"}, skipped)
+	assert.Equal(t, "list123", viper.GetString("default_list"))
+	assert.Equal(t, "json", viper.GetString("output"))
+	assert.Nil(t, viper.Get("api_token"))
+
+	saved, err := os.ReadFile(filepath.Join(tmpDir, ConfigFileName+"."+ConfigType))
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), "default_list: list123")
+	assert.NotContains(t, string(saved), "pk_injected")
+}
+
+func TestImportSettingsInvalidYAML(t *testing.T) {
+	_, _, err := ImportSettings(strings.NewReader("default_list: [unclosed"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// OutputKeyPrefix starts per-command output format keys such as
+// output_task_list, which override output for 'cu task list'
+const OutputKeyPrefix = "output_"
+
+// KnownKey describes a configuration key that cu reads
+type KnownKey struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     string `json:"default"`
+}
+
+// knownKeys lists every configuration key cu reads, keyed by name
+var knownKeys = map[string]KnownKey{
+	"default_space": {
+		Description: "Space used when a command needs one and none is given",
+	},
+	"default_folder": {
+		Description: "Folder used when a command needs one and none is given",
+	},
+	"default_list": {
+		Description: "List used by task commands when --list is not given",
+	},
+	"default_list_name": {
+		Description: "Name of the default list, saved by 'cu list default'",
+	},
+	"default_workspace": {
+		Description: "Workspace whose stored token is used for API calls",
+	},
+	"auth_workspaces": {
+		Description: "Workspaces logged into with 'cu auth login', offered when switching workspaces",
+	},
+	"output": {
+		Description: "Default output format (auto, table, json, yaml, csv); auto is table on a terminal and json when piped. output_<command> keys such as output_task_list override it per command",
+		Default:     "auto",
+	},
+	"debug": {
+		Description: "Enable debug output",
+		Default:     "false",
+	},
+	"no_color": {
+		Description: "Disable colored output",
+		Default:     "false",
+	},
+	"time_format": {
+		Description: "How times are shown in tables: relative, absolute (local time) or iso (RFC 3339 UTC)",
+		Default:     "relative",
+	},
+	"compact_json": {
+		Description: "Print JSON output on a single line instead of indented",
+		Default:     "false",
+	},
+	"rate_limit": {
+		Description: "Maximum API requests per minute",
+		Default:     "100",
+	},
+	"cache_encrypt": {
+		Description: "Encrypt cached data at rest",
+		Default:     "false",
+	},
+	"cache_ttl": {
+		Description: "Override how long every cache stays valid (e.g. 30s, 10m)",
+	},
+	"cache_ttl_tasks": {
+		Description: "How long cached tasks stay valid",
+		Default:     "5m",
+	},
+	"cache_ttl_workspaces": {
+		Description: "How long cached workspaces and users stay valid",
+		Default:     "1h",
+	},
+}
+
+// KnownKeys returns the known configuration keys sorted by name
+func KnownKeys() []KnownKey {
+	keys := make([]KnownKey, 0, len(knownKeys))
+	for name, key := range knownKeys {
+		key.Name = name
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// LookupKey returns the known configuration key with the given name.
+// Per-command output keys are known for any command.
+func LookupKey(name string) (KnownKey, bool) {
+	key, ok := knownKeys[name]
+	if ok {
+		key.Name = name
+		return key, true
+	}
+
+	if command, found := strings.CutPrefix(name, OutputKeyPrefix); found && command != "" {
+		return KnownKey{
+			Name:        name,
+			Description: "Default output format for 'cu " + strings.ReplaceAll(command, "_", " ") + "'",
+		}, true
+	}
+	return KnownKey{}, false
+}
+
+// SuggestKey returns the known key closest to name, if one is close enough to
+// be a likely typo
+func SuggestKey(name string) (string, bool) {
+	best, bestDist := "", -1
+	for known := range knownKeys {
+		dist := levenshtein(name, known)
+		if bestDist < 0 || dist < bestDist || (dist == bestDist && known < best) {
+			best, bestDist = known, dist
+		}
+	}
+
+	maxDist := len(name) / 3
+	if maxDist < 2 {
+		maxDist = 2
+	}
+	if bestDist < 0 || bestDist > maxDist {
+		return "", false
+	}
+	return best, true
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
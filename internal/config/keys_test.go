@@ -0,0 +1,68 @@
+package config
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnownKeys(t *testing.T) {
+	keys := KnownKeys()
+
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.Name)
+		assert.NotEmpty(t, key.Description, key.Name)
+	}
+	assert.True(t, sort.StringsAreSorted(names))
+	assert.Contains(t, names, "default_list")
+	assert.Contains(t, names, "cache_ttl_tasks")
+
+	key, ok := LookupKey("output")
+	assert.True(t, ok)
+	assert.Equal(t, "output", key.Name)
+	assert.Equal(t, "auto", key.Default)
+
+	_, ok = LookupKey("default.list")
+	assert.False(t, ok)
+}
+
+func TestSuggestKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"deafult_list", "default_list", true},
+		{"default.list", "default_list", true},
+		{"ouptut", "output", true},
+		{"cache_ttl_task", "cache_ttl_tasks", true},
+		{"favorite_color", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion, ok := SuggestKey(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, suggestion)
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("output", "output"))
+	assert.Equal(t, 2, levenshtein("deafult", "default"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 4, levenshtein("", "abcd"))
+}
+
+func TestLookupKeyPerCommandOutput(t *testing.T) {
+	key, ok := LookupKey("output_task_list")
+	assert.True(t, ok)
+	assert.Equal(t, "output_task_list", key.Name)
+	assert.Contains(t, key.Description, "'cu task list'")
+
+	_, ok = LookupKey(OutputKeyPrefix)
+	assert.False(t, ok)
+}
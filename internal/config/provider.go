@@ -53,8 +53,13 @@ func (p *Provider) GetStringMap(key string) map[string]interface{} {
 	return p.viper.GetStringMap(key)
 }
 
-// Set sets a configuration value
+// Set sets a configuration value. On the global viper instance the value
+// is also recorded for Save.
 func (p *Provider) Set(key string, value interface{}) {
+	if p.viper == viper.GetViper() {
+		Set(key, value)
+		return
+	}
 	p.viper.Set(key, value)
 }
 
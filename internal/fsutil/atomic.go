@@ -0,0 +1,58 @@
+// Package fsutil provides file system helpers shared by the cache and config
+// packages.
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// createTemp creates the temporary file written by WriteFileAtomic. Tests
+// replace it to simulate write failures.
+var createTemp = os.CreateTemp
+
+// WriteFileAtomic writes data to a temporary file in the same directory as
+// filename and renames it into place. Rename is atomic on the same file
+// system, so readers see either the old or the new content and a failed write
+// leaves the original file intact. New files are created with perm; existing
+// files keep their mode.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(filename); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := createTemp(filepath.Dir(filename), filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	// Clean up the temporary file on any failure
+	success := false
+	defer func() {
+		if !success {
+			_ = os.Remove(tmpName)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, filename); err != nil {
+		return err
+	}
+
+	success = true
+	return nil
+}
@@ -0,0 +1,99 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("creates new file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+
+		require.NoError(t, WriteFileAtomic(path, []byte("key: value\n"), 0600))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "key: value\n", string(data))
+		assertNoTempFiles(t, filepath.Dir(path))
+	})
+
+	t.Run("replaces existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte("a much longer original value\n"), 0600))
+
+		require.NoError(t, WriteFileAtomic(path, []byte("new\n"), 0600))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "new\n", string(data))
+		assertNoTempFiles(t, filepath.Dir(path))
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file permissions are not enforced on Windows")
+		}
+
+		dir := t.TempDir()
+		newPath := filepath.Join(dir, "new.yml")
+		require.NoError(t, WriteFileAtomic(newPath, []byte("x"), 0600))
+		info, err := os.Stat(newPath)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		// Existing files keep their mode
+		existing := filepath.Join(dir, "existing.yml")
+		require.NoError(t, os.WriteFile(existing, []byte("x"), 0640)) // #nosec G306 - testing mode preservation
+		require.NoError(t, os.Chmod(existing, 0640))                  // #nosec G302 - testing mode preservation
+		require.NoError(t, WriteFileAtomic(existing, []byte("y"), 0600))
+		info, err = os.Stat(existing)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	})
+
+	t.Run("write error leaves original intact", func(t *testing.T) {
+		oldCreateTemp := createTemp
+		defer func() { createTemp = oldCreateTemp }()
+
+		// Hand back the temp file opened read-only so writes fail
+		createTemp = func(dir, pattern string) (*os.File, error) {
+			f, err := os.CreateTemp(dir, pattern)
+			if err != nil {
+				return nil, err
+			}
+			name := f.Name()
+			_ = f.Close()
+			return os.Open(name) // #nosec G304 - test temp file
+		}
+
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte("original\n"), 0600))
+
+		err := WriteFileAtomic(path, []byte("replacement\n"), 0600)
+		require.Error(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "original\n", string(data))
+		assertNoTempFiles(t, filepath.Dir(path))
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "config.yml")
+
+		assert.Error(t, WriteFileAtomic(path, []byte("x"), 0600))
+	})
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
@@ -19,6 +19,7 @@ type APIClient interface {
 	// Space operations
 	GetSpaces(ctx context.Context, teamID string) ([]clickup.Space, error)
 	GetSpace(ctx context.Context, spaceID string) (*clickup.Space, error)
+	GetSpaceTags(ctx context.Context, spaceID string) ([]clickup.Tag, error)
 	CreateSpace(ctx context.Context, teamID string, request *clickup.SpaceRequest) (*clickup.Space, error)
 	UpdateSpace(ctx context.Context, spaceID string, request *clickup.SpaceRequest) (*clickup.Space, error)
 	DeleteSpace(ctx context.Context, spaceID string) error
@@ -41,14 +42,24 @@ type APIClient interface {
 
 	// Task operations
 	GetTasks(ctx context.Context, listID string, options *TaskQueryOptions) ([]clickup.Task, error)
+	GetAllTasks(ctx context.Context, listID string, options *TaskQueryOptions) ([]clickup.Task, error)
 	GetTask(ctx context.Context, taskID string) (*clickup.Task, error)
 	CreateTask(ctx context.Context, listID string, options *TaskCreateOptions) (*clickup.Task, error)
 	UpdateTask(ctx context.Context, taskID string, options *TaskUpdateOptions) (*clickup.Task, error)
 	DeleteTask(ctx context.Context, taskID string) error
 
+	// Dependency operations
+	AddDependency(ctx context.Context, taskID, otherID, depType string) error
+	RemoveDependency(ctx context.Context, taskID, otherID, depType string) error
+
+	// Checklist operations
+	CreateChecklist(ctx context.Context, taskID, name string) (*clickup.Checklist, error)
+	CreateChecklistItem(ctx context.Context, checklistID, name string) (*clickup.Checklist, error)
+
 	// User operations
 	GetCurrentUser(ctx context.Context) (*clickup.User, error)
 	GetWorkspaceMembers(ctx context.Context, workspaceID string) ([]clickup.TeamUser, error)
+	ResolveAssignees(ctx context.Context, assignees []string) ([]string, error)
 
 	// Member operations
 	GetMembers(ctx context.Context, listID string) ([]clickup.Member, error)
@@ -83,12 +94,17 @@ type APIClient interface {
 
 // TaskQueryOptions represents options for querying tasks
 type TaskQueryOptions struct {
-	Page      int
-	Assignees []string
-	Statuses  []string
-	Tags      []string
-	Priority  *int
-	DueDate   *time.Time
+	Page          int
+	Assignees     []string
+	Statuses      []string
+	Tags          []string
+	Priority      *int
+	DueDate       *time.Time
+	IncludeClosed bool
+	Subtasks      bool
+	// Archived includes archived tasks in the results
+	Archived            bool
+	IncludeCustomFields bool
 }
 
 // TaskCreateOptions represents options for creating a task
@@ -100,6 +116,7 @@ type TaskCreateOptions struct {
 	Priority    string
 	Tags        []string
 	DueDate     string
+	NotifyAll   *bool
 }
 
 // TaskUpdateOptions represents options for updating a task
@@ -112,6 +129,7 @@ type TaskUpdateOptions struct {
 	DueDate         string
 	AddAssignees    []string
 	RemoveAssignees []string
+	NotifyAll       *bool
 }
 
 // HasUpdates checks if any updates are specified
@@ -7,11 +7,16 @@ type OutputFormatter interface {
 	// Print methods
 	Print(data interface{}) error
 	PrintTo(w io.Writer, data interface{}) error
+	PrintTable(headers []string, rows [][]string) error
 	PrintError(err error)
 	PrintSuccess(message string)
 	PrintWarning(message string)
 	PrintInfo(message string)
 
+	// Prompts
+	Confirm(prompt string) (bool, error)
+	ConfirmExact(prompt, expected string) (bool, error)
+
 	// Configuration
 	SetFormat(format string) error
 	GetFormat() string
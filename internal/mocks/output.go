@@ -17,10 +17,16 @@ type MockOutputFormatter struct {
 	ColorEnabled bool
 	QuietMode    bool
 	Headers      []string
+	Prompts      []string
+	// TableHeaders and TableRows hold the last table passed to PrintTable
+	TableHeaders []string
+	TableRows    [][]string
 
 	// Control behavior
-	PrintErr    error // Renamed to avoid conflict with method
-	FormatError error
+	PrintErr        error // Renamed to avoid conflict with method
+	FormatError     error
+	ConfirmResponse bool
+	ConfirmErr      error
 }
 
 // NewMockOutputFormatter creates a new mock output formatter
@@ -55,6 +61,16 @@ func (m *MockOutputFormatter) PrintTo(w io.Writer, data interface{}) error {
 	return err
 }
 
+// PrintTable captures the headers and rows of a table
+func (m *MockOutputFormatter) PrintTable(headers []string, rows [][]string) error {
+	if m.PrintErr != nil {
+		return m.PrintErr
+	}
+	m.TableHeaders = headers
+	m.TableRows = rows
+	return nil
+}
+
 // PrintError captures error messages
 func (m *MockOutputFormatter) PrintError(err error) {
 	m.Errors = append(m.Errors, err)
@@ -75,6 +91,20 @@ func (m *MockOutputFormatter) PrintInfo(message string) {
 	m.InfoMsg = append(m.InfoMsg, message)
 }
 
+// Confirm captures the prompt and returns the configured response
+func (m *MockOutputFormatter) Confirm(prompt string) (bool, error) {
+	m.Prompts = append(m.Prompts, prompt)
+	if m.ConfirmErr != nil {
+		return false, m.ConfirmErr
+	}
+	return m.ConfirmResponse, nil
+}
+
+// ConfirmExact captures the prompt and returns the configured response
+func (m *MockOutputFormatter) ConfirmExact(prompt, expected string) (bool, error) {
+	return m.Confirm(prompt)
+}
+
 // SetFormat sets the output format
 func (m *MockOutputFormatter) SetFormat(format string) error {
 	if m.FormatError != nil {
@@ -111,5 +141,8 @@ func (m *MockOutputFormatter) Reset() {
 	m.SuccessMsg = make([]string, 0)
 	m.WarningMsg = make([]string, 0)
 	m.InfoMsg = make([]string, 0)
+	m.Prompts = nil
 	m.Headers = nil
+	m.TableHeaders = nil
+	m.TableRows = nil
 }
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/timimsms/cu/internal/interfaces"
+)
+
+var _ interfaces.OutputFormatter = (*MockOutputFormatter)(nil)
+
+func TestMockOutputFormatterPrintTable(t *testing.T) {
+	t.Run("captures headers and rows", func(t *testing.T) {
+		m := NewMockOutputFormatter()
+
+		err := m.PrintTable([]string{"id", "name"}, [][]string{{"1", "Alpha"}, {"2", "Beta"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"id", "name"}, m.TableHeaders)
+		assert.Equal(t, [][]string{{"1", "Alpha"}, {"2", "Beta"}}, m.TableRows)
+
+		m.Reset()
+		assert.Nil(t, m.TableHeaders)
+		assert.Nil(t, m.TableRows)
+	})
+
+	t.Run("returns PrintErr", func(t *testing.T) {
+		m := NewMockOutputFormatter()
+		m.PrintErr = errors.New("broken pipe")
+
+		err := m.PrintTable([]string{"id"}, [][]string{{"1"}})
+		assert.EqualError(t, err, "broken pipe")
+		assert.Nil(t, m.TableRows)
+	})
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// FormatAuto is the output format that picks table output on a terminal and
+// json when stdout is piped or redirected
+const FormatAuto = "auto"
+
+// StdoutIsTerminal reports whether stdout is a terminal
+func StdoutIsTerminal() bool {
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// ResolveAuto returns the format to use for format. Auto becomes table when
+// isTerminal reports a terminal and json otherwise; other formats are
+// returned unchanged.
+func ResolveAuto(format string, isTerminal func() bool) string {
+	if format != FormatAuto {
+		return format
+	}
+	if isTerminal() {
+		return "table"
+	}
+	return "json"
+}
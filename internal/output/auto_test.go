@@ -0,0 +1,20 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAuto(t *testing.T) {
+	terminal := func() bool { return true }
+	piped := func() bool { return false }
+
+	assert.Equal(t, "table", ResolveAuto("auto", terminal))
+	assert.Equal(t, "json", ResolveAuto("auto", piped))
+
+	// Explicit formats are kept whether or not stdout is a terminal
+	assert.Equal(t, "yaml", ResolveAuto("yaml", piped))
+	assert.Equal(t, "table", ResolveAuto("table", piped))
+	assert.Equal(t, "csv", ResolveAuto("csv", terminal))
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// ansiColor is one of the 16 standard terminal colors
+type ansiColor struct {
+	code    int
+	r, g, b int
+}
+
+// ansiPalette approximates the xterm defaults for the 16 standard colors.
+// Every code is two digits so colored cells have a fixed escape length.
+var ansiPalette = []ansiColor{
+	{30, 0, 0, 0},
+	{31, 205, 0, 0},
+	{32, 0, 205, 0},
+	{33, 205, 205, 0},
+	{34, 0, 0, 238},
+	{35, 205, 0, 205},
+	{36, 0, 205, 205},
+	{37, 229, 229, 229},
+	{90, 127, 127, 127},
+	{91, 255, 0, 0},
+	{92, 0, 255, 0},
+	{93, 255, 255, 0},
+	{94, 92, 92, 255},
+	{95, 255, 0, 255},
+	{96, 0, 255, 255},
+	{97, 255, 255, 255},
+}
+
+const (
+	ansiDefault = "\x1b[39m"
+	ansiReset   = "\x1b[0m"
+)
+
+// ColorEnabled reports whether colored output should be written. It is false
+// when --no-color or NO_COLOR is set or stdout is not a terminal.
+func ColorEnabled() bool {
+	return !color.NoColor
+}
+
+// SetColorEnabled turns colored output on or off for the whole process
+func SetColorEnabled(enabled bool) {
+	color.NoColor = !enabled
+}
+
+// NearestANSI returns the escape sequence of the standard terminal color
+// closest to a hex color such as "#d33d44". Invalid colors use the terminal's
+// default foreground.
+func NearestANSI(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return ansiDefault
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ansiDefault
+	}
+	r, g, b := int(rgb>>16&0xff), int(rgb>>8&0xff), int(rgb&0xff)
+
+	best, bestDist := ansiPalette[0], -1
+	for _, c := range ansiPalette {
+		dr, dg, db := r-c.r, g-c.g, b-c.b
+		dist := dr*dr + dg*dg + db*db
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = c, dist
+		}
+	}
+	return "\x1b[" + strconv.Itoa(best.code) + "m"
+}
+
+// ColorCell wraps a table cell in the nearest terminal color to hex. Cells
+// without a color still get escape codes of the same length so tab-aligned
+// columns stay aligned.
+func ColorCell(value, hex string) string {
+	code := ansiDefault
+	if hex != "" {
+		code = NearestANSI(hex)
+	}
+	return code + value + ansiReset
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNearestANSI(t *testing.T) {
+	tests := []struct {
+		hex      string
+		expected string
+	}{
+		{"#f50000", "\x1b[91m"},
+		{"#d33d44", "\x1b[31m"},
+		{"#ffcc00", "\x1b[33m"},
+		{"#6fddff", "\x1b[96m"},
+		{"#d8d8d8", "\x1b[37m"},
+		{"#000", "\x1b[30m"},
+		{"fff", "\x1b[97m"},
+		{"", ansiDefault},
+		{"#zzzzzz", ansiDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hex, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NearestANSI(tt.hex))
+		})
+	}
+}
+
+func TestTableFormatterColumnColors(t *testing.T) {
+	type row struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	rows := []row{{"1", "open"}, {"2", "in progress"}, {"3", "blocked"}}
+	colors := map[string]map[string]string{
+		"status": {"open": "#d8d8d8", "in progress": "#4194f6"},
+	}
+
+	t.Run("no escape codes when color is disabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &TableFormatter{Writer: &buf, ColumnColors: colors}
+
+		require.NoError(t, formatter.Format(rows))
+		assert.NotContains(t, buf.String(), "\x1b[")
+	})
+
+	t.Run("colored cells when enabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &TableFormatter{Writer: &buf, ColorEnabled: true, ColumnColors: colors}
+
+		require.NoError(t, formatter.Format(rows))
+		out := buf.String()
+		assert.Contains(t, out, NearestANSI("#d8d8d8")+"open"+ansiReset)
+		assert.Contains(t, out, NearestANSI("#4194f6")+"in progress"+ansiReset)
+		assert.Contains(t, out, ansiDefault+"blocked"+ansiReset)
+
+		// Uncolored columns are untouched and status cells stay aligned
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		require.Len(t, lines, 5)
+		col := strings.Index(lines[0], "\x1b[")
+		for _, line := range lines[1:] {
+			assert.Equal(t, col, strings.Index(line, "\x1b["), line)
+		}
+	})
+}
+
+func TestSetColorEnabled(t *testing.T) {
+	old := ColorEnabled()
+	defer SetColorEnabled(old)
+
+	SetColorEnabled(false)
+	assert.False(t, ColorEnabled())
+	SetColorEnabled(true)
+	assert.True(t, ColorEnabled())
+}
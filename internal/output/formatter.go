@@ -1,13 +1,17 @@
 package output
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -19,17 +23,30 @@ type Formatter interface {
 
 // Format formats and prints data according to the specified format
 func Format(format string, data interface{}) error {
+	return FormatTo(os.Stdout, format, data)
+}
+
+// FormatTo formats data according to the specified format and writes it to w.
+// A nil slice is written as an empty one, so json prints [] rather than
+// null, and an empty slice in table format prints "No items found".
+func FormatTo(w io.Writer, format string, data interface{}) error {
+	data = nonNilSlice(data)
+
 	var formatter Formatter
 
 	switch strings.ToLower(format) {
 	case "json":
-		formatter = &JSONFormatter{Writer: os.Stdout}
+		formatter = &JSONFormatter{Writer: w, Compact: jsonCompact, Depth: jsonDepth}
 	case "yaml", "yml":
-		formatter = &YAMLFormatter{Writer: os.Stdout}
+		formatter = &YAMLFormatter{Writer: w}
 	case "csv":
-		formatter = &CSVFormatter{Writer: os.Stdout}
+		formatter = &CSVFormatter{Writer: w}
 	case "table":
-		formatter = &TableFormatter{Writer: os.Stdout}
+		formatter = &TableFormatter{Writer: w, NoHeader: !TableHeaderEnabled(), ShowEmpty: true}
+	case "markdown", "md":
+		formatter = &MarkdownFormatter{Writer: w}
+	case "template":
+		formatter = &TemplateFormatter{Writer: w, Template: templateText, Funcs: templateFuncs}
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -37,17 +54,244 @@ func Format(format string, data interface{}) error {
 	return formatter.Format(data)
 }
 
+// nonNilSlice returns an empty slice of the same type for a nil slice and
+// data unchanged otherwise
+func nonNilSlice(data interface{}) interface{} {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+	return data
+}
+
+// jsonCompact controls whether JSON from FormatTo is printed on one line
+var jsonCompact = false
+
+// SetJSONCompact turns compact JSON output on or off for the whole process.
+// It is set by --compact.
+func SetJSONCompact(compact bool) {
+	jsonCompact = compact
+}
+
+// jsonDepth limits the nesting of JSON from FormatTo; 0 is no limit
+var jsonDepth = 0
+
+// SetJSONDepth limits JSON output to depth levels of nesting for the whole
+// process, 0 meaning no limit. It is set by --depth.
+func SetJSONDepth(depth int) {
+	jsonDepth = depth
+}
+
+// templateText and templateFuncs are used by template output from FormatTo
+var (
+	templateText  string
+	templateFuncs template.FuncMap
+)
+
+// SetTemplate sets the Go template, and any extra functions it may call,
+// used for template output for the whole process. It is set by --template.
+func SetTemplate(text string, funcs template.FuncMap) {
+	templateText = text
+	templateFuncs = funcs
+}
+
+// JSONElided replaces objects and arrays nested deeper than the JSON depth
+const JSONElided = "…"
+
 // JSONFormatter formats output as JSON
 type JSONFormatter struct {
 	Writer io.Writer
+	// Compact prints the JSON on a single line instead of indented
+	Compact bool
+	// Depth, when positive, replaces objects and arrays nested deeper than
+	// this many levels with JSONElided
+	Depth int
 }
 
 func (f *JSONFormatter) Format(data interface{}) error {
+	if f.Depth > 0 {
+		return f.formatTruncated(data)
+	}
+
 	encoder := json.NewEncoder(f.Writer)
-	encoder.SetIndent("", "  ")
+	if !f.Compact {
+		encoder.SetIndent("", "  ")
+	}
 	return encoder.Encode(data)
 }
 
+// formatTruncated encodes data, elides values nested deeper than f.Depth and
+// writes the result. Key order is kept.
+func (f *JSONFormatter) formatTruncated(data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var truncated bytes.Buffer
+	if err := writeTruncatedJSON(decoder, &truncated, 0, f.Depth); err != nil {
+		return err
+	}
+
+	out := truncated.Bytes()
+	if !f.Compact {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, out, "", "  "); err != nil {
+			return err
+		}
+		out = indented.Bytes()
+	}
+	_, err = f.Writer.Write(append(out, '\n'))
+	return err
+}
+
+// writeTruncatedJSON copies the next value from decoder to buf. Objects and
+// arrays at level depth or below are replaced with JSONElided.
+func writeTruncatedJSON(decoder *json.Decoder, buf *bytes.Buffer, level, depth int) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		scalar, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		buf.Write(scalar)
+		return nil
+	}
+
+	if level >= depth {
+		// Skip the rest of the object or array
+		for open := 1; open > 0; {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			switch token {
+			case json.Delim('{'), json.Delim('['):
+				open++
+			case json.Delim('}'), json.Delim(']'):
+				open--
+			}
+		}
+		elided, _ := json.Marshal(JSONElided)
+		buf.Write(elided)
+		return nil
+	}
+
+	buf.WriteString(delim.String())
+	for i := 0; decoder.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if delim == '{' {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			encodedKey, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+		}
+		if err := writeTruncatedJSON(decoder, buf, level+1, depth); err != nil {
+			return err
+		}
+	}
+
+	// Closing delimiter
+	closing, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	buf.WriteString(closing.(json.Delim).String())
+	return nil
+}
+
+// Markdowner is implemented by values that can render themselves as markdown
+type Markdowner interface {
+	Markdown(w io.Writer) error
+}
+
+// MarkdownFormatter formats output as markdown. Only values implementing
+// Markdowner are supported.
+type MarkdownFormatter struct {
+	Writer io.Writer
+}
+
+func (f *MarkdownFormatter) Format(data interface{}) error {
+	m, ok := data.(Markdowner)
+	if !ok {
+		return fmt.Errorf("markdown output is not supported for %T", data)
+	}
+	return m.Markdown(f.Writer)
+}
+
+// TemplateFormatter formats output with a Go text/template. A slice is
+// rendered one element at a time, anything else once; each rendering ends
+// with a newline.
+type TemplateFormatter struct {
+	Writer   io.Writer
+	Template string
+	// Funcs are made available to the template alongside default and join
+	Funcs template.FuncMap
+}
+
+func (f *TemplateFormatter) Format(data interface{}) error {
+	if f.Template == "" {
+		return fmt.Errorf("template output needs a template")
+	}
+
+	tmpl, err := template.New("output").Funcs(template.FuncMap{
+		"default": templateDefault,
+		"join":    strings.Join,
+	}).Funcs(f.Funcs).Parse(f.Template)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	items := []interface{}{data}
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		items = make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	}
+
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := f.Writer.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateDefault returns value, or fallback when value is empty. Pipelines
+// pass the value last: {{.Name | default "untitled"}}.
+func templateDefault(fallback string, value interface{}) interface{} {
+	if value == nil {
+		return fallback
+	}
+	if v := reflect.ValueOf(value); v.IsZero() {
+		return fallback
+	}
+	return value
+}
+
 // YAMLFormatter formats output as YAML
 type YAMLFormatter struct {
 	Writer io.Writer
@@ -76,11 +320,8 @@ func (f *CSVFormatter) Format(data interface{}) error {
 		if len(v) == 0 {
 			return nil
 		}
-		// Extract headers
-		var headers []string
-		for k := range v[0] {
-			headers = append(headers, k)
-		}
+		// Extract headers in sorted order so columns are stable
+		headers := sortedKeys(v[0])
 		if err := writer.Write(headers); err != nil {
 			return err
 		}
@@ -99,11 +340,8 @@ func (f *CSVFormatter) Format(data interface{}) error {
 		if len(v) == 0 {
 			return nil
 		}
-		// Extract headers
-		var headers []string
-		for k := range v[0] {
-			headers = append(headers, k)
-		}
+		// Extract headers in sorted order so columns are stable
+		headers := sortedKeys(v[0])
 		if err := writer.Write(headers); err != nil {
 			return err
 		}
@@ -120,11 +358,10 @@ func (f *CSVFormatter) Format(data interface{}) error {
 		return nil
 	case map[string]interface{}:
 		// Handle single map as a single row
-		var headers []string
-		var values []string
-		for k, val := range v {
-			headers = append(headers, k)
-			values = append(values, fmt.Sprint(val))
+		headers := sortedKeys(v)
+		values := make([]string, 0, len(headers))
+		for _, k := range headers {
+			values = append(values, fmt.Sprint(v[k]))
 		}
 		if err := writer.Write(headers); err != nil {
 			return err
@@ -132,110 +369,218 @@ func (f *CSVFormatter) Format(data interface{}) error {
 		return writer.Write(values)
 	case map[string]string:
 		// Handle single map as a single row
-		var headers []string
-		var values []string
-		for k, val := range v {
-			headers = append(headers, k)
-			values = append(values, val)
+		headers := sortedKeys(v)
+		values := make([]string, 0, len(headers))
+		for _, k := range headers {
+			values = append(values, v[k])
 		}
 		if err := writer.Write(headers); err != nil {
 			return err
 		}
 		return writer.Write(values)
 	default:
-		// Try to convert to slice of maps using reflection
-		rv := reflect.ValueOf(data)
-		if rv.Kind() == reflect.Slice {
-			// Handle slice of structs with headers
-			if rv.Len() > 0 {
-				firstItem := rv.Index(0).Interface()
-				headers, err := structToHeaders(firstItem)
-				if err == nil {
-					// Write headers
-					if err := writer.Write(headers); err != nil {
-						return err
-					}
-				}
-			}
-			var rows [][]string
-			for i := 0; i < rv.Len(); i++ {
-				item := rv.Index(i).Interface()
-				row, err := structToSlice(item)
-				if err != nil {
-					return err
-				}
-				rows = append(rows, row)
-			}
-			return writer.WriteAll(rows)
+		return writeStructsCSV(writer, data)
+	}
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// csvColumn is a CSV column of a struct field. index is the field's index
+// path, which has more than one entry for fields of nested structs.
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// writeStructsCSV writes a struct, or a slice of structs or struct pointers,
+// as CSV with a header row. Column names come from the json tags. Nested
+// structs are flattened into dotted columns such as status.status, and
+// slices and maps are written as JSON. A slice of other values is written one
+// value per row without a header.
+func writeStructsCSV(w *csv.Writer, data interface{}) error {
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	var items []reflect.Value
+	var elemType reflect.Type
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, rv.Index(i))
 		}
+		elemType = rv.Type().Elem()
+	case reflect.Struct:
+		items = []reflect.Value{rv}
+		elemType = rv.Type()
+	default:
+		return fmt.Errorf("unsupported CSV data type: %T", data)
+	}
 
-		// Handle single struct
-		if rv.Kind() == reflect.Struct || (rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Struct) {
-			headers, err := structToHeaders(data)
-			if err != nil {
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		for _, item := range items {
+			if err := w.Write([]string{csvValue(item)}); err != nil {
 				return err
 			}
-			values, err := structToSlice(data)
-			if err != nil {
-				return err
+		}
+		return nil
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	columns := csvColumns(elemType, "")
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = csvValue(csvField(item, column.index))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvColumns returns the columns of a struct type, skipping unexported fields
+// and fields tagged json:"-"
+func csvColumns(t reflect.Type, prefix string) []csvColumn {
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.ToLower(field.Name)
+		if tag := field.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
 			}
-			if err := writer.Write(headers); err != nil {
-				return err
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name = tagName
+			}
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && !isCSVLeaf(fieldType) {
+			for _, nested := range csvColumns(fieldType, prefix+name+".") {
+				nested.index = append([]int{i}, nested.index...)
+				columns = append(columns, nested)
 			}
-			return writer.Write(values)
+			continue
 		}
 
-		return fmt.Errorf("unsupported CSV data type")
+		columns = append(columns, csvColumn{name: prefix + name, index: []int{i}})
 	}
+	return columns
 }
 
-func structToSlice(v interface{}) ([]string, error) {
-	rv := reflect.ValueOf(v)
-	if rv.Kind() == reflect.Ptr {
-		rv = rv.Elem()
+// isCSVLeaf reports whether a struct type is written as one value rather
+// than flattened
+func isCSVLeaf(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	return t == timeType ||
+		t.Implements(jsonMarshalerType) || ptr.Implements(jsonMarshalerType) ||
+		t.Implements(stringerType) || ptr.Implements(stringerType)
+}
+
+// csvField follows an index path from v, returning an invalid value when a
+// nil pointer is in the way
+func csvField(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
 	}
+	return v
+}
 
-	var result []string
-	switch rv.Kind() {
-	case reflect.Struct:
-		for i := 0; i < rv.NumField(); i++ {
-			result = append(result, fmt.Sprint(rv.Field(i).Interface()))
+// csvValue formats a single value for a CSV cell. Nil values and empty
+// slices and maps are written as empty cells.
+func csvValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
 		}
-	case reflect.Map:
-		for _, key := range rv.MapKeys() {
-			result = append(result, fmt.Sprint(rv.MapIndex(key).Interface()))
+		if v.Kind() == reflect.Ptr && v.Type().Implements(jsonMarshalerType) {
+			break
 		}
-	default:
-		result = append(result, fmt.Sprint(v))
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
 	}
-	return result, nil
-}
 
-func structToHeaders(v interface{}) ([]string, error) {
-	rv := reflect.ValueOf(v)
-	if rv.Kind() == reflect.Ptr {
-		rv = rv.Elem()
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
 	}
 
-	var result []string
-	switch rv.Kind() {
-	case reflect.Struct:
-		rt := rv.Type()
-		for i := 0; i < rv.NumField(); i++ {
-			field := rt.Field(i)
-			// Use json tag as header name, fallback to field name
-			if tag := field.Tag.Get("json"); tag != "" && tag != "-" {
-				result = append(result, strings.Split(tag, ",")[0])
-			} else {
-				result = append(result, strings.ToLower(field.Name))
-			}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		if v.Len() == 0 {
+			return ""
 		}
-	case reflect.Map:
-		for _, key := range rv.MapKeys() {
-			result = append(result, fmt.Sprint(key.Interface()))
+	case reflect.Struct:
+		if !v.Type().Implements(jsonMarshalerType) && v.Type().Implements(stringerType) {
+			return v.Interface().(fmt.Stringer).String()
 		}
-	default:
-		return nil, fmt.Errorf("unsupported type for headers")
+	case reflect.String:
+		return v.String()
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface())
+	}
+
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	var str string
+	if json.Unmarshal(b, &str) == nil {
+		return str
+	}
+	if string(b) == "null" {
+		return ""
 	}
-	return result, nil
+	return string(b)
 }
@@ -2,10 +2,15 @@ package output
 
 import (
 	"bytes"
+	"io"
 	"os"
+	"strings"
 	"testing"
+	"text/template"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTableFormatter(t *testing.T) {
@@ -179,7 +184,277 @@ func TestFormat(t *testing.T) {
 	})
 }
 
+// markdownItem renders a fixed markdown string
+type markdownItem string
+
+func (m markdownItem) Markdown(w io.Writer) error {
+	_, err := io.WriteString(w, string(m))
+	return err
+}
+
+func TestFormatTo(t *testing.T) {
+	rows := []map[string]string{{"id": "1"}}
+
+	tests := []struct {
+		format string
+		data   interface{}
+		want   string
+	}{
+		{"json", rows, "[\n  {\n    \"id\": \"1\"\n  }\n]\n"},
+		{"yaml", rows, "- id: \"1\"\n"},
+		{"yml", rows, "- id: \"1\"\n"},
+		{"csv", rows, "id\n1\n"},
+		{"table", rows, "id\n----------\n1\n"},
+		{"markdown", markdownItem("## Title\n"), "## Title\n"},
+		{"md", markdownItem("## Title\n"), "## Title\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := FormatTo(&buf, tt.format, tt.data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	t.Run("unsupported format", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := FormatTo(&buf, "xml", rows)
+		assert.ErrorContains(t, err, "unsupported output format: xml")
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestFormatToEmpty(t *testing.T) {
+	type item struct {
+		ID string `json:"id"`
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		data   interface{}
+		want   string
+	}{
+		{"nil slice as json", "json", []item(nil), "[]\n"},
+		{"empty slice as json", "json", []item{}, "[]\n"},
+		{"nil slice as yaml", "yaml", []item(nil), "[]\n"},
+		{"nil slice as table", "table", []item(nil), "No items found\n"},
+		{"empty slice as table", "table", []item{}, "No items found\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, FormatTo(&buf, tt.format, tt.data))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestFormatToNoHeader(t *testing.T) {
+	SetTableHeader(false)
+	defer SetTableHeader(true)
+
+	rows := []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}{
+		{"1", "short"},
+		{"12345", "longer name"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, FormatTo(&buf, "table", rows))
+	assert.Equal(t, "1      short\n12345  longer name\n", buf.String())
+}
+
+func TestPrintTable(t *testing.T) {
+	headers := []string{"id", "name"}
+	rows := [][]string{{"1", "short"}, {"12345", "longer name"}}
+
+	t.Run("aligns rows under headers", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintTable(&buf, headers, rows))
+		assert.Equal(t, "id          name\n----------  ----------\n1           short\n12345       longer name\n", buf.String())
+	})
+
+	t.Run("honors no-header", func(t *testing.T) {
+		SetTableHeader(false)
+		defer SetTableHeader(true)
+
+		var buf bytes.Buffer
+		assert.NoError(t, PrintTable(&buf, headers, rows))
+		assert.Equal(t, "1      short\n12345  longer name\n", buf.String())
+	})
+
+	t.Run("no rows prints nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, PrintTable(&buf, headers, nil))
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestFormatToCompactJSON(t *testing.T) {
+	rows := []map[string]string{{"id": "1"}, {"id": "2"}}
+
+	var pretty bytes.Buffer
+	assert.NoError(t, FormatTo(&pretty, "json", rows))
+	assert.Equal(t, "[\n  {\n    \"id\": \"1\"\n  },\n  {\n    \"id\": \"2\"\n  }\n]\n", pretty.String())
+
+	SetJSONCompact(true)
+	defer SetJSONCompact(false)
+
+	var compact bytes.Buffer
+	assert.NoError(t, FormatTo(&compact, "json", rows))
+	assert.Equal(t, `[{"id":"1"},{"id":"2"}]`+"\n", compact.String())
+
+	// Other formats are unaffected
+	var yaml bytes.Buffer
+	assert.NoError(t, FormatTo(&yaml, "yaml", rows))
+	assert.Equal(t, "- id: \"1\"\n- id: \"2\"\n", yaml.String())
+}
+
+func TestFormatToTemplate(t *testing.T) {
+	type task struct {
+		ID   string
+		Name string
+		Tags []string
+	}
+
+	SetTemplate(`{{.ID}}: {{.Name | default "untitled"}} {{join .Tags ","}}`, nil)
+	defer SetTemplate("", nil)
+
+	t.Run("renders each element of a slice", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := FormatTo(&buf, "template", []task{{ID: "1", Name: "One", Tags: []string{"a", "b"}}, {ID: "2"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "1: One a,b\n2: untitled \n", buf.String())
+	})
+
+	t.Run("renders other values once", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, FormatTo(&buf, "template", &task{ID: "1", Name: "One"}))
+		assert.Equal(t, "1: One \n", buf.String())
+	})
+
+	t.Run("extra functions", func(t *testing.T) {
+		formatter := &TemplateFormatter{
+			Template: "{{upper .}}\n",
+			Funcs:    template.FuncMap{"upper": strings.ToUpper},
+		}
+		var buf bytes.Buffer
+		formatter.Writer = &buf
+		assert.NoError(t, formatter.Format("hi"))
+		assert.Equal(t, "HI\n", buf.String(), "a trailing newline is not doubled")
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		err := (&TemplateFormatter{Writer: io.Discard, Template: "{{.ID"}).Format(task{})
+		assert.ErrorContains(t, err, "invalid template")
+	})
+
+	t.Run("missing template", func(t *testing.T) {
+		err := (&TemplateFormatter{Writer: io.Discard}).Format(task{})
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatToJSONDepth(t *testing.T) {
+	type status struct {
+		Status string `json:"status"`
+		Color  string `json:"color"`
+	}
+	type task struct {
+		ID       string            `json:"id"`
+		Status   status            `json:"status"`
+		Tags     []string          `json:"tags"`
+		Points   float64           `json:"points"`
+		Archived bool              `json:"archived"`
+		Parent   *string           `json:"parent"`
+		Fields   []map[string]bool `json:"fields"`
+	}
+	data := task{
+		ID:     "abc",
+		Status: status{Status: "open", Color: "#fff"},
+		Tags:   []string{"bug"},
+		Points: 2.5,
+		Fields: []map[string]bool{{"done": true}},
+	}
+
+	SetJSONCompact(true)
+	defer SetJSONCompact(false)
+
+	t.Run("elides nested fields past the depth", func(t *testing.T) {
+		SetJSONDepth(1)
+		defer SetJSONDepth(0)
+
+		var buf bytes.Buffer
+		assert.NoError(t, FormatTo(&buf, "json", data))
+		assert.Equal(t, `{"id":"abc","status":"…","tags":"…","points":2.5,"archived":false,"parent":null,"fields":"…"}`+"\n", buf.String())
+	})
+
+	t.Run("deeper limit keeps more levels", func(t *testing.T) {
+		SetJSONDepth(2)
+		defer SetJSONDepth(0)
+
+		var buf bytes.Buffer
+		assert.NoError(t, FormatTo(&buf, "json", []task{data}))
+		assert.Equal(t, `[{"id":"abc","status":"…","tags":"…","points":2.5,"archived":false,"parent":null,"fields":"…"}]`+"\n", buf.String())
+	})
+
+	t.Run("full output by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, FormatTo(&buf, "json", data))
+		assert.Contains(t, buf.String(), `"status":{"status":"open","color":"#fff"}`)
+		assert.Contains(t, buf.String(), `"fields":[{"done":true}]`)
+	})
+
+	t.Run("indented", func(t *testing.T) {
+		SetJSONCompact(false)
+		defer SetJSONCompact(true)
+		SetJSONDepth(1)
+		defer SetJSONDepth(0)
+
+		var buf bytes.Buffer
+		assert.NoError(t, FormatTo(&buf, "json", map[string]interface{}{"a": map[string]int{"b": 1}}))
+		assert.Equal(t, "{\n  \"a\": \"…\"\n}\n", buf.String())
+	})
+}
+
+func TestMarkdownFormatter(t *testing.T) {
+	t.Run("renders Markdowner values", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &MarkdownFormatter{Writer: &buf}
+
+		err := formatter.Format(markdownItem("## Title\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "## Title\n", buf.String())
+	})
+
+	t.Run("rejects other values", func(t *testing.T) {
+		formatter := &MarkdownFormatter{Writer: &bytes.Buffer{}}
+
+		err := formatter.Format(map[string]string{"id": "1"})
+		assert.ErrorContains(t, err, "markdown output is not supported")
+	})
+}
+
 func TestCSVFormatter_Format(t *testing.T) {
+	t.Run("writes map columns in sorted order", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &CSVFormatter{Writer: &buf}
+
+		data := []map[string]string{
+			{"status": "open", "id": "1", "name": "test"},
+			{"status": "closed", "id": "2", "name": "test2"},
+		}
+
+		assert.NoError(t, formatter.Format(data))
+		assert.Equal(t, "id,name,status\n1,test,open\n2,test2,closed\n", buf.String())
+	})
+
 	t.Run("formats [][]string data", func(t *testing.T) {
 		var buf bytes.Buffer
 		formatter := &CSVFormatter{Writer: &buf}
@@ -311,6 +586,52 @@ func TestCSVFormatter_Format(t *testing.T) {
 		assert.Contains(t, output, "test2")
 	})
 
+	t.Run("flattens rows with headers from struct tags", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &CSVFormatter{Writer: &buf}
+
+		type owner struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		type row struct {
+			ID       string    `json:"id"`
+			Title    string    `json:"title,omitempty"`
+			Count    int       // no tag
+			Owner    owner     `json:"owner"`
+			Backup   *owner    `json:"backup"`
+			Tags     []string  `json:"tags"`
+			Due      time.Time `json:"due"`
+			Secret   string    `json:"-"`
+			internal string
+		}
+
+		due := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+		data := []*row{
+			{ID: "1", Title: "Write, test", Count: 2, Owner: owner{7, "ann"}, Tags: []string{"a", "b"}, Due: due, Secret: "x", internal: "y"},
+			{ID: "2", Backup: &owner{8, "bo"}},
+		}
+
+		err := formatter.Format(data)
+		assert.NoError(t, err)
+		assert.Equal(t, "id,title,count,owner.id,owner.name,backup.id,backup.name,tags,due\n"+
+			"1,\"Write, test\",2,7,ann,,,\"[\"\"a\"\",\"\"b\"\"]\",2026-03-10T09:30:00Z\n"+
+			"2,,0,0,,8,bo,,\n", buf.String())
+	})
+
+	t.Run("handles empty slice of structs", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &CSVFormatter{Writer: &buf}
+
+		type row struct {
+			ID string `json:"id"`
+		}
+
+		err := formatter.Format([]row{})
+		assert.NoError(t, err)
+		assert.Empty(t, buf.String())
+	})
+
 	t.Run("handles unsupported data type", func(t *testing.T) {
 		var buf bytes.Buffer
 		formatter := &CSVFormatter{Writer: &buf}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Prompter asks the user for confirmation on an input stream
+type Prompter struct {
+	In  io.Reader
+	Out io.Writer
+}
+
+// DefaultPrompter reads answers from stdin and writes prompts to stdout.
+// Tests can replace its In field to script answers.
+var DefaultPrompter = NewPrompter(os.Stdin, os.Stdout)
+
+// NewPrompter creates a new prompter reading from in and writing to out
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		In:  in,
+		Out: out,
+	}
+}
+
+// Confirm asks a yes/no question and returns true only for "y" or "yes".
+// An empty answer or end of input counts as "no".
+func (p *Prompter) Confirm(prompt string) (bool, error) {
+	_, _ = fmt.Fprintf(p.Out, "%s [y/N] ", prompt)
+
+	response, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes", nil
+}
+
+// ConfirmExact asks the user to type expected to confirm. The comparison is
+// case-sensitive; anything else, including end of input, counts as "no".
+func (p *Prompter) ConfirmExact(prompt, expected string) (bool, error) {
+	_, _ = fmt.Fprintf(p.Out, "%s ", prompt)
+
+	response, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(response) == expected, nil
+}
+
+// readLine reads a single line without buffering past the newline, so that
+// any remaining input is left for the caller
+func (p *Prompter) readLine() (string, error) {
+	if p.In == nil {
+		return "", nil
+	}
+
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := p.In.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			line = append(line, buf[0])
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return "", fmt.Errorf("failed to read confirmation: %w", err)
+		}
+	}
+
+	return strings.TrimSuffix(string(line), "\r"), nil
+}
+
+// Confirm asks a yes/no question using the default prompter
+func Confirm(prompt string) (bool, error) {
+	return DefaultPrompter.Confirm(prompt)
+}
+
+// ConfirmExact asks the user to type expected using the default prompter
+func ConfirmExact(prompt, expected string) (bool, error) {
+	return DefaultPrompter.ConfirmExact(prompt, expected)
+}
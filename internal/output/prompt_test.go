@@ -0,0 +1,147 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errReader always fails with the configured error
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func TestPrompter_Confirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"lowercase y", "y\n", true},
+		{"uppercase Y", "Y\n", true},
+		{"yes", "yes\n", true},
+		{"yes with whitespace", "  Yes  \n", true},
+		{"windows line ending", "y\r\n", true},
+		{"no", "n\n", false},
+		{"other text", "maybe\n", false},
+		{"empty answer", "\n", false},
+		{"EOF without input", "", false},
+		{"EOF after answer", "y", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := NewPrompter(strings.NewReader(tt.input), &out)
+
+			confirmed, err := p.Confirm("Continue?")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, confirmed)
+			assert.Equal(t, "Continue? [y/N] ", out.String())
+		})
+	}
+
+	t.Run("read error", func(t *testing.T) {
+		p := NewPrompter(&errReader{err: errors.New("boom")}, &bytes.Buffer{})
+
+		confirmed, err := p.Confirm("Continue?")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read confirmation")
+		assert.False(t, confirmed)
+	})
+
+	t.Run("leaves remaining input unread", func(t *testing.T) {
+		in := strings.NewReader("y\nn\n")
+		p := NewPrompter(in, &bytes.Buffer{})
+
+		first, err := p.Confirm("First?")
+		require.NoError(t, err)
+		second, err := p.Confirm("Second?")
+		require.NoError(t, err)
+
+		assert.True(t, first)
+		assert.False(t, second)
+	})
+
+	t.Run("nil input counts as no", func(t *testing.T) {
+		p := NewPrompter(nil, &bytes.Buffer{})
+
+		confirmed, err := p.Confirm("Continue?")
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+}
+
+func TestPrompter_ConfirmExact(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"exact match", "delete\n", true},
+		{"match with whitespace", " delete \n", true},
+		{"different case", "DELETE\n", false},
+		{"yes is not enough", "y\n", false},
+		{"empty answer", "\n", false},
+		{"EOF without input", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := NewPrompter(strings.NewReader(tt.input), &out)
+
+			confirmed, err := p.ConfirmExact("Type 'delete' to confirm:", "delete")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, confirmed)
+			assert.Equal(t, "Type 'delete' to confirm: ", out.String())
+		})
+	}
+
+	t.Run("read error", func(t *testing.T) {
+		p := NewPrompter(&errReader{err: errors.New("boom")}, &bytes.Buffer{})
+
+		confirmed, err := p.ConfirmExact("Type 'delete' to confirm:", "delete")
+		assert.Error(t, err)
+		assert.False(t, confirmed)
+	})
+}
+
+func TestConfirm_UsesDefaultPrompter(t *testing.T) {
+	oldPrompter := DefaultPrompter
+	defer func() { DefaultPrompter = oldPrompter }()
+
+	var out bytes.Buffer
+	DefaultPrompter = NewPrompter(strings.NewReader("yes\ndelete\n"), &out)
+
+	confirmed, err := Confirm("Proceed?")
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = ConfirmExact("Type 'delete':", "delete")
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	assert.Equal(t, "Proceed? [y/N] Type 'delete': ", out.String())
+}
+
+func TestFormatterWrapper_Confirm(t *testing.T) {
+	formatter := NewFormatter(nil)
+	formatter.SetPrompter(NewPrompter(strings.NewReader("y\nnope\n"), &bytes.Buffer{}))
+
+	confirmed, err := formatter.Confirm("Proceed?")
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = formatter.ConfirmExact("Type 'delete':", "delete")
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+}
@@ -5,18 +5,43 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
+
+	"github.com/timimsms/cu/internal/clock"
 )
 
 // TableFormatter formats output as a table
 type TableFormatter struct {
-	Writer       io.Writer
-	NoHeader     bool
-	Columns      []string
-	ShowEmpty    bool
+	Writer    io.Writer
+	NoHeader  bool
+	Columns   []string
+	ShowEmpty bool
+	// EmptyMessage is printed for empty data when ShowEmpty is set. It
+	// defaults to "No items found". It is left out with NoHeader, so
+	// scripts reading the rows see no output for no data.
+	EmptyMessage string
 	ColorEnabled bool
+	// ColumnColors maps a column header to the hex color of each cell value
+	// in that column. It is only used when ColorEnabled is set.
+	ColumnColors map[string]map[string]string
+}
+
+// tableHeader controls whether tables from FormatTo print their header row
+var tableHeader = true
+
+// TableHeaderEnabled reports whether table output includes the header row.
+// It is false after --no-header.
+func TableHeaderEnabled() bool {
+	return tableHeader
+}
+
+// SetTableHeader turns the header row of table output on or off for the
+// whole process
+func SetTableHeader(enabled bool) {
+	tableHeader = enabled
 }
 
 func (f *TableFormatter) Format(data interface{}) error {
@@ -51,10 +76,17 @@ func (f *TableFormatter) Format(data interface{}) error {
 	}
 }
 
+func (f *TableFormatter) emptyMessage() string {
+	if f.EmptyMessage != "" {
+		return f.EmptyMessage
+	}
+	return "No items found"
+}
+
 func (f *TableFormatter) formatSlice(w io.Writer, rv reflect.Value) error {
 	if rv.Len() == 0 {
-		if f.ShowEmpty {
-			_, _ = fmt.Fprintln(w, "No items found")
+		if f.ShowEmpty && !f.NoHeader {
+			_, _ = fmt.Fprintln(w, f.emptyMessage())
 		}
 		return nil
 	}
@@ -66,27 +98,92 @@ func (f *TableFormatter) formatSlice(w io.Writer, rv reflect.Value) error {
 		return err
 	}
 
-	// Print headers
+	rows := make([][]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		row, err := f.getRow(rv.Index(i).Interface(), headers)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	f.writeRows(w, headers, rows)
+	return nil
+}
+
+// FormatRows writes rows of cells as an aligned table under headers. Cells
+// are printed as given, so callers format and truncate values themselves.
+func (f *TableFormatter) FormatRows(headers []string, rows [][]string) error {
+	if f.Writer == nil {
+		f.Writer = os.Stdout
+	}
+
+	w := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
+	defer func() { _ = w.Flush() }()
+
+	if len(rows) == 0 {
+		if f.ShowEmpty && !f.NoHeader {
+			_, _ = fmt.Fprintln(w, f.emptyMessage())
+		}
+		return nil
+	}
+
+	f.writeRows(w, headers, rows)
+	return nil
+}
+
+// writeRows prints the header and separator rows, unless NoHeader is set,
+// followed by the data rows
+func (f *TableFormatter) writeRows(w io.Writer, headers []string, rows [][]string) {
 	if !f.NoHeader && len(headers) > 0 {
-		_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
-		// Print separator
+		_, _ = fmt.Fprintln(w, strings.Join(f.colorRow(headers, headers, false), "\t"))
 		var sep []string
 		for range headers {
 			sep = append(sep, strings.Repeat("-", 10))
 		}
-		_, _ = fmt.Fprintln(w, strings.Join(sep, "\t"))
+		_, _ = fmt.Fprintln(w, strings.Join(f.colorRow(headers, sep, false), "\t"))
 	}
 
-	// Print rows
-	for i := 0; i < rv.Len(); i++ {
-		row, err := f.getRow(rv.Index(i).Interface(), headers)
-		if err != nil {
-			return err
-		}
-		_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(w, strings.Join(f.colorRow(headers, row, true), "\t"))
 	}
+}
 
-	return nil
+// PrintTable writes rows as a table to w, honoring --no-header and the
+// color setting
+func PrintTable(w io.Writer, headers []string, rows [][]string) error {
+	formatter := &TableFormatter{
+		Writer:       w,
+		NoHeader:     !TableHeaderEnabled(),
+		ColorEnabled: ColorEnabled(),
+	}
+	return formatter.FormatRows(headers, rows)
+}
+
+// colorRow colors the cells of columns listed in ColumnColors. Header and
+// separator cells get uncolored escapes of the same length so columns align.
+func (f *TableFormatter) colorRow(headers, cells []string, isData bool) []string {
+	if !f.ColorEnabled || len(f.ColumnColors) == 0 {
+		return cells
+	}
+
+	colored := make([]string, len(cells))
+	for i, cell := range cells {
+		var colors map[string]string
+		ok := false
+		if i < len(headers) {
+			colors, ok = f.ColumnColors[headers[i]]
+		}
+		switch {
+		case !ok:
+			colored[i] = cell
+		case isData:
+			colored[i] = ColorCell(cell, colors[cell])
+		default:
+			colored[i] = ColorCell(cell, "")
+		}
+	}
+	return colored
 }
 
 func (f *TableFormatter) formatMap(w io.Writer, rv reflect.Value) error {
@@ -95,7 +192,13 @@ func (f *TableFormatter) formatMap(w io.Writer, rv reflect.Value) error {
 		_, _ = fmt.Fprintln(w, "---\t-----")
 	}
 
-	for _, key := range rv.MapKeys() {
+	// Keys are sorted so the output is the same on every run
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	for _, key := range keys {
 		value := rv.MapIndex(key)
 		_, _ = fmt.Fprintf(w, "%v\t%v\n", key.Interface(), f.formatValue(value.Interface()))
 	}
@@ -241,13 +344,7 @@ func (f *TableFormatter) formatValue(v interface{}) string {
 		if val.IsZero() {
 			return ""
 		}
-		// Format as relative time if recent
-		now := time.Now()
-		diff := now.Sub(val)
-		if diff < 24*time.Hour && diff > -24*time.Hour {
-			return formatRelativeTime(val)
-		}
-		return val.Format("2006-01-02")
+		return FormatTime(val, formatRecentTime)
 	case *time.Time:
 		if val == nil || val.IsZero() {
 			return ""
@@ -271,8 +368,17 @@ func (f *TableFormatter) formatValue(v interface{}) string {
 	}
 }
 
+// formatRecentTime formats times within a day of now as relative times and
+// older or later ones as dates
+func formatRecentTime(t time.Time) string {
+	if diff := clock.Now().Sub(t); diff < 24*time.Hour && diff > -24*time.Hour {
+		return formatRelativeTime(t)
+	}
+	return t.Format("2006-01-02")
+}
+
 func formatRelativeTime(t time.Time) string {
-	now := time.Now()
+	now := clock.Now()
 	diff := now.Sub(t)
 
 	switch {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/timimsms/cu/internal/clock"
 )
 
 func TestTableFormatter_Format(t *testing.T) {
@@ -62,6 +63,19 @@ func TestTableFormatter_Format(t *testing.T) {
 		assert.Empty(t, buf.String())
 	})
 
+	t.Run("empty slice without headers prints nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &TableFormatter{
+			Writer:    &buf,
+			NoHeader:  true,
+			ShowEmpty: true,
+		}
+
+		err := formatter.Format([]map[string]string{})
+		assert.NoError(t, err)
+		assert.Empty(t, buf.String())
+	})
+
 	t.Run("formats slice without headers", func(t *testing.T) {
 		var buf bytes.Buffer
 		formatter := &TableFormatter{
@@ -109,6 +123,21 @@ func TestTableFormatter_Format(t *testing.T) {
 		assert.Contains(t, output, "true")
 	})
 
+	t.Run("formats map keys in sorted order", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &TableFormatter{Writer: &buf, NoHeader: true}
+
+		data := map[string]interface{}{
+			"rate_limit":   250,
+			"default_list": "901",
+			"output":       "json",
+			"debug":        false,
+		}
+
+		assert.NoError(t, formatter.Format(data))
+		assert.Equal(t, "debug         false\ndefault_list  901\noutput        json\nrate_limit    250\n", buf.String())
+	})
+
 	t.Run("formats map without headers", func(t *testing.T) {
 		var buf bytes.Buffer
 		formatter := &TableFormatter{
@@ -475,3 +504,12 @@ func TestTableFormatter_EdgeCases(t *testing.T) {
 		assert.NotContains(t, output, "42")
 	})
 }
+
+func TestFormatRecentTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+	defer clock.Set(clock.Fixed(now))()
+
+	assert.Equal(t, "2 hours ago", formatRecentTime(now.Add(-2*time.Hour)))
+	assert.Equal(t, "in 3 hours", formatRecentTime(now.Add(3*time.Hour+time.Minute)))
+	assert.Equal(t, "2026-03-08", formatRecentTime(now.Add(-48*time.Hour)))
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeFormat selects how times are shown in human-readable output
+type TimeFormat string
+
+const (
+	// TimeRelative shows times relative to now, such as "3 days ago"
+	TimeRelative TimeFormat = "relative"
+	// TimeAbsolute shows times as a date and time in the local timezone
+	TimeAbsolute TimeFormat = "absolute"
+	// TimeISO shows times as RFC 3339 timestamps in UTC
+	TimeISO TimeFormat = "iso"
+)
+
+// absoluteTimeLayout is the layout of TimeAbsolute times
+const absoluteTimeLayout = "2006-01-02 15:04"
+
+// timeFormat is the time format used by FormatTime
+var timeFormat = TimeRelative
+
+// ParseTimeFormat parses a --time-format value
+func ParseTimeFormat(value string) (TimeFormat, error) {
+	switch format := TimeFormat(strings.ToLower(value)); format {
+	case TimeRelative, TimeAbsolute, TimeISO:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid time format %q (use relative, absolute or iso)", value)
+	}
+}
+
+// CurrentTimeFormat returns the time format used by FormatTime
+func CurrentTimeFormat() TimeFormat {
+	return timeFormat
+}
+
+// SetTimeFormat sets the time format for the whole process. It is set by
+// --time-format.
+func SetTimeFormat(format TimeFormat) {
+	timeFormat = format
+}
+
+// FormatTime formats t in the current time format. Relative times are
+// produced by relative, so each view can word them its own way.
+func FormatTime(t time.Time, relative func(time.Time) string) string {
+	switch timeFormat {
+	case TimeAbsolute:
+		return t.Local().Format(absoluteTimeLayout)
+	case TimeISO:
+		return t.UTC().Format(time.RFC3339)
+	default:
+		return relative(t)
+	}
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeFormat(t *testing.T) {
+	for _, value := range []string{"relative", "absolute", "iso", "ISO"} {
+		_, err := ParseTimeFormat(value)
+		assert.NoError(t, err, value)
+	}
+
+	_, err := ParseTimeFormat("local")
+	assert.ErrorContains(t, err, "use relative, absolute or iso")
+}
+
+func TestFormatTime(t *testing.T) {
+	defer SetTimeFormat(CurrentTimeFormat())
+
+	oldLocal := time.Local
+	defer func() { time.Local = oldLocal }()
+	loc := time.FixedZone("EST", -5*60*60)
+	time.Local = loc
+
+	fixed := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+	relative := func(time.Time) string { return "3 days ago" }
+
+	tests := []struct {
+		format TimeFormat
+		want   string
+	}{
+		{TimeRelative, "3 days ago"},
+		{TimeAbsolute, "2026-03-10 09:30"},
+		{TimeISO, "2026-03-10T14:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			SetTimeFormat(tt.format)
+			assert.Equal(t, tt.want, FormatTime(fixed, relative))
+			assert.Equal(t, tt.want, FormatTime(fixed.In(loc), relative), "result does not depend on the input's zone")
+		})
+	}
+
+	t.Run("tables use the time format", func(t *testing.T) {
+		SetTimeFormat(TimeISO)
+		formatter := &TableFormatter{}
+		assert.Equal(t, "2026-03-10T14:30:00Z", formatter.formatValue(fixed))
+	})
+}
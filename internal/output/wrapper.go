@@ -13,6 +13,7 @@ type FormatterWrapper struct {
 	config      interface{ GetString(string) string }
 	quietMode   bool
 	colorOutput bool
+	prompter    *Prompter
 }
 
 // NewFormatter creates a new output formatter with config
@@ -20,29 +21,18 @@ func NewFormatter(config interface{ GetString(string) string }) *FormatterWrappe
 	return &FormatterWrapper{
 		config:      config,
 		colorOutput: true, // Default to color output
+		prompter:    DefaultPrompter,
 	}
 }
 
 // Print formats and prints data according to the configured format
 func (f *FormatterWrapper) Print(data interface{}) error {
-	format := "table" // default
-	if f.config != nil {
-		if fmt := f.config.GetString("output"); fmt != "" {
-			format = fmt
-		}
-	}
-
-	return Format(format, data)
+	return Format(f.GetFormat(), data)
 }
 
 // PrintTo formats and prints data to the specified writer
 func (f *FormatterWrapper) PrintTo(w io.Writer, data interface{}) error {
-	format := "table" // default
-	if f.config != nil {
-		if fmt := f.config.GetString("output"); fmt != "" {
-			format = fmt
-		}
-	}
+	format := f.GetFormat()
 
 	// Temporarily redirect output to the provided writer
 	oldStdout := os.Stdout
@@ -59,6 +49,30 @@ func (f *FormatterWrapper) PrintTo(w io.Writer, data interface{}) error {
 	return err
 }
 
+// PrintTable prints rows under headers. Table output is aligned, csv output
+// has headers as its first record and other formats print one object per row
+// keyed by header.
+func (f *FormatterWrapper) PrintTable(headers []string, rows [][]string) error {
+	switch format := f.GetFormat(); format {
+	case "table":
+		return PrintTable(os.Stdout, headers, rows)
+	case "csv":
+		return Format(format, append([][]string{headers}, rows...))
+	default:
+		records := make([]map[string]string, 0, len(rows))
+		for _, row := range rows {
+			record := make(map[string]string, len(headers))
+			for i, header := range headers {
+				if i < len(row) {
+					record[header] = row[i]
+				}
+			}
+			records = append(records, record)
+		}
+		return Format(format, records)
+	}
+}
+
 // PrintInfo prints an informational message
 func (f *FormatterWrapper) PrintInfo(msg string) {
 	if f.quietMode {
@@ -111,6 +125,21 @@ func (f *FormatterWrapper) PrintWarning(msg string) {
 	}
 }
 
+// Confirm asks a yes/no question and returns the user's answer
+func (f *FormatterWrapper) Confirm(prompt string) (bool, error) {
+	return f.prompter.Confirm(prompt)
+}
+
+// ConfirmExact asks the user to type expected to confirm
+func (f *FormatterWrapper) ConfirmExact(prompt, expected string) (bool, error) {
+	return f.prompter.ConfirmExact(prompt, expected)
+}
+
+// SetPrompter sets the prompter used for confirmations
+func (f *FormatterWrapper) SetPrompter(p *Prompter) {
+	f.prompter = p
+}
+
 // SetQuiet sets quiet mode
 func (f *FormatterWrapper) SetQuiet(quiet bool) {
 	f.quietMode = quiet
@@ -121,7 +150,8 @@ func (f *FormatterWrapper) SetColor(useColor bool) {
 	f.colorOutput = useColor
 }
 
-// GetFormat returns the current output format
+// GetFormat returns the current output format, with auto settled to table or
+// json for the current stdout
 func (f *FormatterWrapper) GetFormat() string {
 	format := "table" // default
 	if f.config != nil {
@@ -129,14 +159,14 @@ func (f *FormatterWrapper) GetFormat() string {
 			format = fmt
 		}
 	}
-	return format
+	return ResolveAuto(format, StdoutIsTerminal)
 }
 
 // SetFormat sets the output format
 func (f *FormatterWrapper) SetFormat(format string) error {
 	// Validate format
 	switch format {
-	case "json", "yaml", "table", "csv":
+	case FormatAuto, "json", "yaml", "table", "csv":
 		// Valid formats - store it if we have a way to persist it
 		// For now, this is a no-op since we read from config
 		return nil
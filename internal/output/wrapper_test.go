@@ -86,6 +86,43 @@ func TestFormatterWrapper_Print(t *testing.T) {
 	})
 }
 
+func TestFormatterWrapper_PrintTable(t *testing.T) {
+	headers := []string{"id", "name"}
+	rows := [][]string{{"1", "Alpha"}}
+
+	capture := func(format string) string {
+		config := &mockConfig{values: map[string]string{"output": format}}
+		formatter := NewFormatter(config)
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := formatter.PrintTable(headers, rows)
+
+		_ = w.Close()
+		os.Stdout = oldStdout
+
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+
+		assert.NoError(t, err)
+		return buf.String()
+	}
+
+	t.Run("table", func(t *testing.T) {
+		assert.Equal(t, "id          name\n----------  ----------\n1           Alpha\n", capture("table"))
+	})
+
+	t.Run("csv starts with headers", func(t *testing.T) {
+		assert.Equal(t, "id,name\n1,Alpha\n", capture("csv"))
+	})
+
+	t.Run("json keys rows by header", func(t *testing.T) {
+		assert.JSONEq(t, `[{"id": "1", "name": "Alpha"}]`, capture("json"))
+	})
+}
+
 func TestFormatterWrapper_PrintTo(t *testing.T) {
 	testData := map[string]string{"key": "value"}
 